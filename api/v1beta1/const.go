@@ -2,9 +2,11 @@ package v1beta1
 
 //nolint:revive
 const (
-	PlatformType_GITHUB = "github"
-	PlatformType_GITEA  = "gitea"
-	PlatformType_GITLAB = "gitlab"
+	PlatformType_GITHUB           = "github"
+	PlatformType_GITEA            = "gitea"
+	PlatformType_GITLAB           = "gitlab"
+	PlatformType_BITBUCKET_SERVER = "bitbucket-server"
+	PlatformType_AZURE            = "azure"
 
 	// LabelRenovator is the label used to associate resources with a Renovator instance.
 	LabelRenovator = "renovate.thegeeklab.de/renovator"
@@ -12,6 +14,14 @@ const (
 	LabelGitRepo = "renovate.thegeeklab.de/gitrepo"
 	// LabelAuthProvider is the label used to associate resources with an AuthProvider for access control.
 	LabelAuthProvider = "renovate.thegeeklab.de/auth-provider"
+	// LabelBatchGitRepoPrefix is the prefix of the labels used to associate a renovate
+	// job processing a batch of Git repositories with each of them.
+	LabelBatchGitRepoPrefix = "gitrepo.renovate.thegeeklab.de/"
+	// LabelRunnerPool is the label used to associate renovate jobs with a RunnerPool.
+	LabelRunnerPool = "renovate.thegeeklab.de/runner-pool"
+	// AnnotationPoolReservation is the annotation used to record the ID of the
+	// RunnerPool job slot reservation a renovate job was created for.
+	AnnotationPoolReservation = "renovate.thegeeklab.de/pool-reservation"
 	// LabelLogsCollected is the annotation used to mark jobs whose logs
 	// have already been archived to the persistent store.
 	LabelLogsCollected = "renovate.thegeeklab.de/logs-collected"
@@ -27,10 +37,38 @@ const (
 	// OperationRenovate is the value used to trigger immediate renovate run.
 	OperationRenovate = "renovate"
 
+	// AnnotationTriggerSource is the annotation used to record the origin of the
+	// RenovatorOperation annotation.
+	AnnotationTriggerSource = "renovate.thegeeklab.de/trigger-source"
+	// TriggerSourceWebhook is the value used for operations triggered by platform webhooks.
+	TriggerSourceWebhook = "webhook"
+	// TriggerSourceAPI is the value used for operations triggered by the trigger API.
+	TriggerSourceAPI = "api"
+	// TriggerSourceRegistry is the value used for operations triggered by container registry push events.
+	TriggerSourceRegistry = "registry"
+
+	// AnnotationDebugRun enables debug logging for the next triggered Renovate
+	// run of a GitRepo. It is removed together with the RenovatorOperation
+	// annotation.
+	AnnotationDebugRun = "renovate.thegeeklab.de/debug-run"
+	// AnnotationSkipScheduledRun skips the next scheduled Renovate run of a GitRepo.
+	AnnotationSkipScheduledRun = "renovate.thegeeklab.de/skip-scheduled-run"
+
+	// AnnotationWebhookFirstEvent records the time of the first webhook event of
+	// a debounced burst.
+	AnnotationWebhookFirstEvent = "renovate.thegeeklab.de/webhook-first-event"
+	// AnnotationWebhookLastEvent records the time of the latest webhook event of
+	// a debounced burst.
+	AnnotationWebhookLastEvent = "renovate.thegeeklab.de/webhook-last-event"
+
 	// FinalizerGitRepoWebhook is the finalizer added to GitRepo resources to ensure
 	// remote webhooks are cleaned up before the resource is deleted.
 	FinalizerGitRepoWebhook = "renovate.thegeeklab.de/webhook-cleanup"
 
+	// FinalizerDiscoveryWebhook is the finalizer added to Discovery resources to ensure
+	// remote organization webhooks are cleaned up before the resource is deleted.
+	FinalizerDiscoveryWebhook = "renovate.thegeeklab.de/organization-webhook-cleanup"
+
 	// FinalizerMetricsCleanup is the finalizer added to GitRepo, Runner, and Discovery resources to ensure
 	// per-resource Prometheus metric series are removed from the operator registry
 	// before the resource is deleted.
@@ -43,6 +81,13 @@ const (
 	// WebhookSecretDataKey is the key used in the webhook Secret to store the validation token.
 	WebhookSecretDataKey = "secret"
 
+	// PlatformTokenDataKey is the key used in the managed GitHub App token Secret to store
+	// the installation access token.
+	PlatformTokenDataKey = "token"
+	// AnnotationTokenExpiresAt is the annotation used to record the expiry of the
+	// installation access token stored in the managed GitHub App token Secret.
+	AnnotationTokenExpiresAt = "renovate.thegeeklab.de/token-expires-at"
+
 	// ValueTrue represents the string boolean "true" for labels and annotations.
 	ValueTrue = "true"
 	// ValueFalse represents the string boolean "false" for labels and annotations.
@@ -75,7 +120,10 @@ const (
 	ReasonReconcileSuccess       = "ReconcileSuccess"
 	ReasonReconcileError         = "ReconcileError"
 	ReasonReconciled             = "Reconciled"
+	ReasonPruneWithheld          = "PruneWithheld"
+	ReasonBlackoutActive         = "BlackoutActive"
 
 	// Event action constants.
 	EventActionReconciling = "Reconciling"
+	EventActionPruning     = "Pruning"
 )
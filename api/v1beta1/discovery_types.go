@@ -1,6 +1,8 @@
 package v1beta1
 
 import (
+	"time"
+
 	api_meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -12,6 +14,28 @@ const (
 	DiscoveryConditionDiscoveryCompleted = "DiscoveryCompleted"
 	// DiscoveryConditionDiscoveryFailed indicates whether the last discovery job failed.
 	DiscoveryConditionDiscoveryFailed = "DiscoveryFailed"
+	// DiscoveryConditionPruneWithheld indicates whether pruning of orphaned
+	// GitRepos was withheld because it exceeded the configured limits.
+	DiscoveryConditionPruneWithheld = "PruneWithheld"
+)
+
+// +kubebuilder:validation:Enum=job;native;static
+type DiscoveryMode string
+
+//nolint:revive
+const (
+	DiscoveryMode_JOB    = "job"
+	DiscoveryMode_NATIVE = "native"
+	DiscoveryMode_STATIC = "static"
+)
+
+// +kubebuilder:validation:Enum=delete;orphan
+type PrunePolicy string
+
+//nolint:revive
+const (
+	PrunePolicy_DELETE = "delete"
+	PrunePolicy_ORPHAN = "orphan"
 )
 
 // DiscoverySpec defines the desired state of Discovery.
@@ -28,6 +52,14 @@ type DiscoverySpec struct {
 
 	PodSpec `json:",inline"`
 
+	// Mode selects how repositories are discovered. `job` runs Renovate
+	// autodiscover in a Job, `native` lists the repositories directly from
+	// the platform API in the operator without launching a pod and `static`
+	// disables autodiscovery and only manages the listed repositories.
+	// Defaults to `job`.
+	// +kubebuilder:validation:Optional
+	Mode DiscoveryMode `json:"mode,omitempty"`
+
 	// +kubebuilder:validation:Optional
 	Filter []string `json:"filter,omitempty"`
 
@@ -48,6 +80,24 @@ type DiscoverySpec struct {
 	// +kubebuilder:validation:Optional
 	Webhooks WebhooksSpec `json:"webhooks,omitempty"`
 
+	// Repositories lists repositories managed in addition to the discovered
+	// ones, e.g. repositories not visible to autodiscover. They are never
+	// pruned while listed.
+	// +kubebuilder:validation:Optional
+	// +listType=map
+	// +listMapKey=name
+	Repositories []RepositorySpec `json:"repositories,omitempty"`
+
+	// OverrideRules set the overrides of the managed GitRepos. The overrides
+	// of all matching rules are merged in order, the overrides of a statically
+	// configured repository take precedence.
+	// +kubebuilder:validation:Optional
+	OverrideRules []OverrideRuleSpec `json:"overrideRules,omitempty"`
+
+	// Prune configures how GitRepos missing from the discovery result are handled.
+	// +kubebuilder:validation:Optional
+	Prune *PruneSpec `json:"prune,omitempty"`
+
 	// PodLabelTemplates are merged into Job pod labels. Values support
 	// Go template variables: {{ .namespace }}, {{ .renovator }}, {{ .discovery }}.
 	// +kubebuilder:validation:Optional
@@ -62,6 +112,73 @@ type GitLabOptions struct {
 	SkipPendingDeletion *bool `json:"skipPendingDeletion,omitempty"`
 }
 
+// RepositorySpec defines a statically configured repository.
+type RepositorySpec struct {
+	// Name is the full name of the repository, e.g. `owner/repo`.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// ConfigRef overrides the RenovateConfig used for this repository.
+	// +kubebuilder:validation:Optional
+	ConfigRef string `json:"configRef,omitempty"`
+
+	// Webhooks overrides the webhook management for this repository.
+	// +kubebuilder:validation:Optional
+	Webhooks *WebhooksSpec `json:"webhooks,omitempty"`
+
+	// Overrides are merged over the overrides of the matching override rules.
+	// +kubebuilder:validation:Optional
+	Overrides *GitRepoOverrides `json:"overrides,omitempty"`
+}
+
+// OverrideRuleSpec applies GitRepo overrides to the repositories matching all
+// configured criteria. A rule without criteria matches every repository.
+type OverrideRuleSpec struct {
+	// Filter limits the rule to repositories matching at least one of the
+	// patterns, using the same syntax as the discovery filter.
+	// +kubebuilder:validation:Optional
+	Filter []string `json:"filter,omitempty"`
+
+	// Topics limits the rule to repositories having all specified topics.
+	// +kubebuilder:validation:Optional
+	Topics []string `json:"topics,omitempty"`
+
+	// Selector limits the rule to GitRepos whose labels match the selector.
+	// +kubebuilder:validation:Optional
+	Selector *metav1.LabelSelector `json:"selector,omitempty"`
+
+	// Overrides are applied to the GitRepos of the matching repositories.
+	Overrides GitRepoOverrides `json:"overrides"`
+}
+
+// PruneSpec defines how GitRepos missing from the discovery result are handled.
+type PruneSpec struct {
+	// Policy selects what happens to GitRepos missing from the discovery result.
+	// `delete` removes them, `orphan` only marks them with the `Orphaned`
+	// status condition. Defaults to `delete`.
+	// +kubebuilder:validation:Optional
+	Policy PrunePolicy `json:"policy,omitempty"`
+
+	// MaxCount is the maximum number of GitRepos pruned in a single run. If
+	// more GitRepos are missing from the discovery result, pruning is withheld.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	MaxCount *int32 `json:"maxCount,omitempty"`
+
+	// MaxPercentage is the maximum share of the managed GitRepos in percent
+	// pruned in a single run. If more GitRepos are missing from the discovery
+	// result, pruning is withheld.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	MaxPercentage *int32 `json:"maxPercentage,omitempty"`
+
+	// GracePeriod is the duration a GitRepo must be missing from the discovery
+	// result before it is deleted.
+	// +kubebuilder:validation:Optional
+	GracePeriod *metav1.Duration `json:"gracePeriod,omitempty"`
+}
+
 // DiscoveryStatus defines the observed state of Discovery.
 //
 //nolint:lll
@@ -69,6 +186,31 @@ type DiscoveryStatus struct {
 	Conditions        []metav1.Condition `json:"conditions,omitempty" patchStrategy:"merge" patchMergeKey:"type" protobuf:"bytes,1,rep,name=conditions"`
 	LastScheduleTime  *metav1.Time       `json:"lastScheduleTime,omitempty"`
 	LastDiscoveryTime *metav1.Time       `json:"lastDiscoveryTime,omitempty"`
+
+	// DiscoveredRepositories is the number of repositories in the last
+	// discovery result.
+	// +kubebuilder:validation:Optional
+	DiscoveredRepositories int32 `json:"discoveredRepositories,omitempty"`
+
+	// ResultShards is the number of ConfigMaps the last discovery result is
+	// split across.
+	// +kubebuilder:validation:Optional
+	ResultShards int32 `json:"resultShards,omitempty"`
+
+	// Webhooks lists the organization webhooks registered on the remote Git
+	// provider when the webhook scope is `organization`.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	Webhooks []OrganizationWebhookStatus `json:"webhooks,omitempty"`
+}
+
+// OrganizationWebhookStatus describes a webhook registered on an organization or group.
+type OrganizationWebhookStatus struct {
+	// Organization is the name of the organization or full path of the group.
+	Organization string `json:"organization"`
+
+	// ID is the ID of the webhook on the remote Git provider.
+	ID string `json:"id"`
 }
 
 // +kubebuilder:object:root=true
@@ -112,6 +254,11 @@ func (d *Discovery) GetSuspend() bool {
 	return *d.Spec.Suspend
 }
 
+// GetBlackoutWindows returns the windows in which scheduled runs are suppressed.
+func (d *Discovery) GetBlackoutWindows() []BlackoutWindowSpec {
+	return d.Spec.BlackoutWindows
+}
+
 // GetLastScheduleTime returns the time of the last execution.
 func (d *Discovery) GetLastScheduleTime() *metav1.Time {
 	return d.Status.LastScheduleTime
@@ -153,11 +300,59 @@ func (d *Discovery) GetSkipForks() bool {
 	return *d.Spec.SkipForks
 }
 
+// GetNativeMode returns true if repositories are discovered by the operator
+// instead of a Renovate autodiscover Job.
+func (d *Discovery) GetNativeMode() bool {
+	return d.Spec.Mode == DiscoveryMode_NATIVE
+}
+
+// GetPrunePolicy returns the policy for GitRepos missing from the discovery
+// result. Defaults to `delete`.
+func (d *Discovery) GetPrunePolicy() PrunePolicy {
+	if d.Spec.Prune == nil || d.Spec.Prune.Policy == "" {
+		return PrunePolicy_DELETE
+	}
+
+	return d.Spec.Prune.Policy
+}
+
+// GetPruneGracePeriod returns the duration a GitRepo must be missing from the
+// discovery result before it is deleted.
+func (d *Discovery) GetPruneGracePeriod() time.Duration {
+	if d.Spec.Prune == nil || d.Spec.Prune.GracePeriod == nil {
+		return 0
+	}
+
+	return d.Spec.Prune.GracePeriod.Duration
+}
+
+// GetStaticMode returns true if autodiscovery is disabled and only the
+// statically configured repositories are managed.
+func (d *Discovery) GetStaticMode() bool {
+	return d.Spec.Mode == DiscoveryMode_STATIC
+}
+
 // GetTopics returns the list of topics to filter repositories by.
 func (d *Discovery) GetTopics() []string {
 	return d.Spec.Topics
 }
 
+// GetWebhooksEnabled returns true when webhook management is enabled for the
+// discovered repositories. Defaults to true when the field is not set.
+func (d *Discovery) GetWebhooksEnabled() bool {
+	if d.Spec.Webhooks.Enabled == nil {
+		return true
+	}
+
+	return *d.Spec.Webhooks.Enabled
+}
+
+// GetOrganizationWebhooks returns true when webhooks are enabled and managed
+// on the organization or group level instead of per repository.
+func (d *Discovery) GetOrganizationWebhooks() bool {
+	return d.GetWebhooksEnabled() && d.Spec.Webhooks.Scope == WebhookScope_ORGANIZATION
+}
+
 // GetSkipPendingDeletion returns true if repositories marked for deletion should be excluded.
 func (d *Discovery) GetSkipPendingDeletion() bool {
 	if d.Spec.GitLab == nil || d.Spec.GitLab.SkipPendingDeletion == nil {
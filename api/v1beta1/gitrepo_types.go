@@ -1,6 +1,7 @@
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	api_meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -12,6 +13,24 @@ const (
 	GitRepoConditionRenovateCompleted = "RenovateCompleted"
 	// GitRepoConditionRenovateFailed indicates whether the last renovate job failed.
 	GitRepoConditionRenovateFailed = "RenovateFailed"
+	// GitRepoConditionOrphaned indicates that the repository is missing from the
+	// latest result of the Discovery managing the GitRepo.
+	GitRepoConditionOrphaned = "Orphaned"
+
+	// MaxWebhookDeliveries is the number of webhook deliveries kept in the
+	// status of a GitRepo.
+	MaxWebhookDeliveries = 20
+)
+
+// +kubebuilder:validation:Enum=accepted;ignored;filtered;rejected
+type WebhookDeliveryDecision string
+
+//nolint:revive
+const (
+	WebhookDeliveryDecision_ACCEPTED WebhookDeliveryDecision = "accepted"
+	WebhookDeliveryDecision_IGNORED  WebhookDeliveryDecision = "ignored"
+	WebhookDeliveryDecision_FILTERED WebhookDeliveryDecision = "filtered"
+	WebhookDeliveryDecision_REJECTED WebhookDeliveryDecision = "rejected"
 )
 
 // GitRepoSpec defines the desired state of GitRepo.
@@ -27,6 +46,63 @@ type GitRepoSpec struct {
 	// any previously managed webhook.
 	// +kubebuilder:validation:Optional
 	Webhooks WebhooksSpec `json:"webhooks,omitempty"`
+
+	// Overrides are merged over the configuration of the Runner when the
+	// renovate Job of this repository is built. For GitRepos managed by a
+	// Discovery they are set from the override rules of the Discovery.
+	// +kubebuilder:validation:Optional
+	Overrides *GitRepoOverrides `json:"overrides,omitempty"`
+}
+
+// GitRepoOverrides defines per-repository settings taking precedence over the
+// configuration of the Runner.
+type GitRepoOverrides struct {
+	// Suspend pauses scheduled runs of the repository. Manually triggered runs
+	// are still executed.
+	// +kubebuilder:validation:Optional
+	Suspend *bool `json:"suspend,omitempty"`
+
+	// Schedule runs the repository on its own cron schedule instead of the
+	// schedule of the Runner.
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Timezone specifies the IANA timezone name used to evaluate the schedule.
+	// Defaults to the timezone of the Runner.
+	// +kubebuilder:validation:Optional
+	Timezone string `json:"timezone,omitempty"`
+
+	// Image overrides the renovate container image.
+	// +kubebuilder:validation:Optional
+	Image string `json:"image,omitempty"`
+
+	// Resources overrides the resource requirements for the renovate container.
+	// +kubebuilder:validation:Optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ExtraEnv specifies additional environment variables for the renovate
+	// container. They are appended to the variables of the Runner.
+	// +kubebuilder:validation:Optional
+	ExtraEnv []corev1.EnvVar `json:"extraEnv,omitempty"`
+
+	// NodeSelector overrides the node selector for scheduling the renovate pod.
+	// +kubebuilder:validation:Optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations overrides the tolerations for scheduling the renovate pod.
+	// +kubebuilder:validation:Optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Timeout limits the duration of the renovate Job. The Job is terminated
+	// and marked as failed once the timeout is exceeded.
+	// +kubebuilder:validation:Optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Priority orders the repository in the dispatch queue of the Runner when
+	// the number of parallel jobs is limited. Repositories with a higher priority
+	// are dispatched first if they are equally stale. Defaults to 0.
+	// +kubebuilder:validation:Optional
+	Priority *int32 `json:"priority,omitempty"`
 }
 
 // GitRepoStatus defines the observed state of GitRepo.
@@ -41,6 +117,62 @@ type GitRepoStatus struct {
 	// +kubebuilder:validation:Optional
 	LastRenovateTime *metav1.Time `json:"lastRenovateTime,omitempty"`
 
+	// LastScheduleTime is the time the repository was last run on the schedule
+	// of its overrides.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// NextRunTime is the time the repository is due to run within the spread
+	// window of a scheduled Runner execution.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	NextRunTime *metav1.Time `json:"nextRunTime,omitempty"`
+
+	// QueuePosition is the position of the repository in the dispatch queue of
+	// the Runner while it waits for a free job slot. It is unset if the
+	// repository is not queued.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	QueuePosition int32 `json:"queuePosition,omitempty"`
+
+	// RetryAttempts is the number of retries of the failed run of the
+	// repository. It is reset once a run succeeds.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	RetryAttempts int32 `json:"retryAttempts,omitempty"`
+
+	// NextRetryTime is the time the failed run of the repository is retried.
+	// It is unset if no retry is pending.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	NextRetryTime *metav1.Time `json:"nextRetryTime,omitempty"`
+
+	// PendingRerun is true if the repository was triggered while its Job was
+	// active. The repository runs once more after the Job has finished.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	PendingRerun bool `json:"pendingRerun,omitempty"`
+
+	// PendingDebugRun is true if the pending rerun of the repository was
+	// requested with debug logging.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	PendingDebugRun bool `json:"pendingDebugRun,omitempty"`
+
+	// Images lists the container images the last finished Renovate run found
+	// as dependencies of the repository. Pushes of these images to a container
+	// registry trigger a run of the repository.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	Images []string `json:"images,omitempty"`
+
+	// WebhookDeliveries is the history of the webhook deliveries received for
+	// the repository, newest first. It is limited to the most recent deliveries.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	WebhookDeliveries []WebhookDelivery `json:"webhookDeliveries,omitempty"`
+
 	// WebhookID is the ID of the webhook registered on the remote Git provider.
 	// This field is managed by the operator and should not be set manually.
 	// +kubebuilder:validation:Optional
@@ -55,6 +187,45 @@ type GitRepoStatus struct {
 	// This field is managed by the operator and should not be set manually.
 	// +kubebuilder:validation:Optional
 	RepoURL string `json:"repoUrl,omitempty"`
+
+	// DefaultBranch is the default branch of the repository. It is only
+	// resolved for platforms that do not include the default branch in push
+	// webhook payloads, and pushes to other branches are ignored.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	DefaultBranch string `json:"defaultBranch,omitempty"`
+
+	// RepositoryID is the immutable platform ID of the repository. It is used
+	// to follow renamed and transferred repositories during discovery.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	RepositoryID string `json:"repositoryId,omitempty"`
+}
+
+// WebhookDelivery records a webhook delivery received for a GitRepo and the
+// decision taken on it.
+type WebhookDelivery struct {
+	// ID is the delivery ID sent by the Git provider, if any.
+	// +kubebuilder:validation:Optional
+	ID string `json:"id,omitempty"`
+
+	// Event is the webhook event type sent by the Git provider.
+	// +kubebuilder:validation:Optional
+	Event string `json:"event,omitempty"`
+
+	// Sender is the user who caused the event, if known.
+	// +kubebuilder:validation:Optional
+	Sender string `json:"sender,omitempty"`
+
+	// Decision is the outcome of the delivery.
+	Decision WebhookDeliveryDecision `json:"decision"`
+
+	// Reason explains the decision taken on the delivery.
+	// +kubebuilder:validation:Optional
+	Reason string `json:"reason,omitempty"`
+
+	// Time is the time the delivery was received.
+	Time metav1.Time `json:"time"`
 }
 
 // +kubebuilder:object:root=true
@@ -35,6 +35,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&RenovatorList{},
 		&Runner{},
 		&RunnerList{},
+		&RunnerPool{},
+		&RunnerPoolList{},
 	)
 	metav1.AddToGroupVersion(scheme, GroupVersion)
 
@@ -13,13 +13,39 @@ const (
 	RenovatorConditionRenovateConfigReady = "RenovateConfigReady"
 )
 
-// +kubebuilder:validation:Enum=github;gitea;gitlab
+// +kubebuilder:validation:Enum=github;gitea;gitlab;bitbucket-server;azure
 type PlatformType string
 
 type PlatformSpec struct {
-	Type     PlatformType        `json:"type"`
-	Endpoint string              `json:"endpoint"`
-	Token    corev1.EnvVarSource `json:"token"`
+	Type     PlatformType `json:"type"`
+	Endpoint string       `json:"endpoint"`
+
+	// Token is the source of the platform access token. Required unless
+	// GitHubApp is set, in which case the operator points it to a managed
+	// Secret holding a short-lived installation token.
+	// +kubebuilder:validation:Optional
+	Token corev1.EnvVarSource `json:"token,omitempty"`
+
+	// GitHubApp configures authentication as a GitHub App installation instead
+	// of a static token. Only supported on the github platform.
+	// +kubebuilder:validation:Optional
+	GitHubApp *GitHubAppSpec `json:"githubApp,omitempty"`
+}
+
+// GitHubAppSpec configures authentication as a GitHub App installation.
+type GitHubAppSpec struct {
+	// AppID is the numeric ID of the GitHub App.
+	// +kubebuilder:validation:Minimum=1
+	AppID int64 `json:"appId"`
+
+	// InstallationID is the numeric ID of the App installation for which
+	// access tokens are minted.
+	// +kubebuilder:validation:Minimum=1
+	InstallationID int64 `json:"installationId"`
+
+	// PrivateKey is a reference to the Secret key holding the PEM-encoded
+	// private key of the GitHub App.
+	PrivateKey corev1.SecretKeySelector `json:"privateKey"`
 }
 
 // +kubebuilder:validation:Enum=extract;lookup;full
@@ -51,6 +77,7 @@ const (
 	DefaultFailedLimit            int32 = 1
 	DefaultBackoffLimit           int32 = 0
 	DefaultScratchVolumePath            = "/tmp/renovate"
+	DefaultWebhookCommandPrefix         = "/renovate"
 )
 
 type LoggingSpec struct {
@@ -110,6 +137,42 @@ type JobSpec struct {
 	// automatically deleted after the specified number of seconds.
 	// +kubebuilder:validation:Optional
 	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// BlackoutWindows specifies periods in which scheduled runs are suppressed,
+	// e.g. release freezes, weekends or holidays.
+	// +kubebuilder:validation:Optional
+	BlackoutWindows []BlackoutWindowSpec `json:"blackoutWindows,omitempty"`
+}
+
+// BlackoutWindowSpec defines a period in which scheduled runs are suppressed. A
+// window either recurs on a cron schedule for the given duration or covers a
+// fixed time range from start to end.
+type BlackoutWindowSpec struct {
+	// Name identifies the blackout window.
+	// +kubebuilder:validation:Required
+	Name string `json:"name"`
+
+	// Schedule specifies the cron-formatted start of a recurring window. It is
+	// evaluated in the timezone of the job schedule.
+	// +kubebuilder:validation:Optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Duration specifies how long a recurring window lasts.
+	// +kubebuilder:validation:Optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// Start specifies the beginning of a fixed window.
+	// +kubebuilder:validation:Optional
+	Start *metav1.Time `json:"start,omitempty"`
+
+	// End specifies the end of a fixed window.
+	// +kubebuilder:validation:Optional
+	End *metav1.Time `json:"end,omitempty"`
+
+	// BlockWebhooks specifies whether runs triggered by webhooks are suppressed
+	// as well. Manual triggers are never suppressed.
+	// +kubebuilder:validation:Optional
+	BlockWebhooks bool `json:"blockWebhooks,omitempty"`
 }
 
 // ScratchVolumeSpec configures a scratch volume for RENOVATE_BASE_DIR.
@@ -176,6 +239,15 @@ type PodSpec struct {
 	ScratchVolume *ScratchVolumeSpec `json:"scratchVolume,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=repository;organization
+type WebhookScope string
+
+//nolint:revive
+const (
+	WebhookScope_REPOSITORY   = "repository"
+	WebhookScope_ORGANIZATION = "organization"
+)
+
 // WebhooksSpec configures webhook management for the discovered repositories.
 type WebhooksSpec struct {
 	// Enabled controls whether the operator manages webhooks on the remote Git
@@ -185,6 +257,81 @@ type WebhooksSpec struct {
 	// Defaults to true.
 	// +kubebuilder:validation:Optional
 	Enabled *bool `json:"enabled,omitempty"`
+
+	// Scope controls where webhooks are registered. With `repository` every
+	// GitRepo manages its own webhook. With `organization` the Discovery
+	// manages a single webhook per organization (GitHub, Gitea) or group
+	// (GitLab) and incoming events are routed to the matching GitRepo.
+	// Repository lifecycle events, e.g. created, renamed or archived
+	// repositories, trigger the Discovery.
+	// Defaults to `repository`.
+	// +kubebuilder:validation:Optional
+	Scope WebhookScope `json:"scope,omitempty"`
+
+	// Organizations lists the organizations or groups on which webhooks are
+	// registered when Scope is `organization`. Defaults to the owners of the
+	// discovered repositories.
+	// +kubebuilder:validation:Optional
+	Organizations []string `json:"organizations,omitempty"`
+
+	// Debounce delays webhook triggered runs until no further events arrived
+	// for the quiet period. Bursts of events result in a single run.
+	// +kubebuilder:validation:Optional
+	Debounce *WebhookDebounceSpec `json:"debounce,omitempty"`
+
+	// PathFilter restricts push triggered runs to pushes changing files that
+	// may affect dependencies. Pushes not changing any matching file are
+	// ignored.
+	// +kubebuilder:validation:Optional
+	PathFilter *WebhookPathFilterSpec `json:"pathFilter,omitempty"`
+
+	// Commands enables ChatOps commands in comments on Renovate pull requests,
+	// merge requests and the Dependency Dashboard. Commands are only accepted
+	// from the user of the platform token.
+	// +kubebuilder:validation:Optional
+	Commands *WebhookCommandsSpec `json:"commands,omitempty"`
+}
+
+// WebhookDebounceSpec configures the debouncing of webhook events.
+type WebhookDebounceSpec struct {
+	// QuietPeriod is the time without further events after which the
+	// triggered run starts.
+	// +kubebuilder:validation:Required
+	QuietPeriod metav1.Duration `json:"quietPeriod"`
+
+	// MaxDelay caps the delay of the run after the first event of a burst.
+	// Without a cap, a continuous stream of events defers the run
+	// indefinitely.
+	// +kubebuilder:validation:Optional
+	MaxDelay *metav1.Duration `json:"maxDelay,omitempty"`
+}
+
+// WebhookPathFilterSpec configures the files of a push that trigger a run.
+type WebhookPathFilterSpec struct {
+	// Patterns are the glob patterns matched against the changed files of a
+	// push. Patterns without a slash match the file name in any directory.
+	// Defaults to the manifests and lock files of common package managers and
+	// the Renovate configuration files.
+	// +kubebuilder:validation:Optional
+	Patterns []string `json:"patterns,omitempty"`
+}
+
+// WebhookCommandsSpec configures the ChatOps commands read from comments.
+type WebhookCommandsSpec struct {
+	// Prefix starts a command line of a comment, e.g. `/renovate run`.
+	// Defaults to `/renovate`.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Pattern=`^\S+$`
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// GetPrefix returns the prefix starting a command line of a comment.
+func (s *WebhookCommandsSpec) GetPrefix() string {
+	if s.Prefix == "" {
+		return DefaultWebhookCommandPrefix
+	}
+
+	return s.Prefix
 }
 
 // RenovatorSpec defines the desired state of Renovator.
@@ -216,6 +363,21 @@ type RenovatorSpec struct {
 	// and GitRepo resources.
 	// +kubebuilder:validation:Optional
 	Webhooks WebhooksSpec `json:"webhooks,omitempty"`
+
+	// Trigger configures the trigger API of the event receiver for this
+	// Renovator.
+	// +kubebuilder:validation:Optional
+	Trigger TriggerSpec `json:"trigger,omitempty"`
+}
+
+// TriggerSpec configures the authentication of trigger API requests.
+type TriggerSpec struct {
+	// TokenSecret references the bearer token that authorizes trigger API
+	// requests for this Renovator. Requests with any other token are
+	// authenticated by a Kubernetes TokenReview and require permission to
+	// update the Renovator.
+	// +kubebuilder:validation:Optional
+	TokenSecret *corev1.SecretKeySelector `json:"tokenSecret,omitempty"`
 }
 
 // RenovatorStatus defines the observed state of Renovator.
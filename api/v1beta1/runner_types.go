@@ -1,12 +1,49 @@
 package v1beta1
 
 import (
+	"time"
+
 	api_meta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+// +kubebuilder:validation:Enum=hash;random
+type SpreadStrategy string
+
+//nolint:revive
+const (
+	SpreadStrategy_HASH   = "hash"
+	SpreadStrategy_RANDOM = "random"
+)
+
+// +kubebuilder:validation:Enum=queue;owner
+type BatchStrategy string
+
+//nolint:revive
+const (
+	BatchStrategy_QUEUE = "queue"
+	BatchStrategy_OWNER = "owner"
+)
+
+// +kubebuilder:validation:Enum=Forbid;Queue;Replace
+type ConcurrencyPolicy string
+
+//nolint:revive
+const (
+	ConcurrencyPolicy_FORBID  = "Forbid"
+	ConcurrencyPolicy_QUEUE   = "Queue"
+	ConcurrencyPolicy_REPLACE = "Replace"
 )
 
 const (
 	DefaultRunnerMaxParallel int32 = 0
+
+	DefaultRetryInitialDelay       = 10 * time.Minute
+	DefaultRetryMultiplier   int32 = 2
+
+	// RunnerConditionBlackout indicates whether a blackout window currently suppresses scheduled runs.
+	RunnerConditionBlackout = "Blackout"
 )
 
 // RunnerSpec defines the desired state of Runner.
@@ -28,12 +65,101 @@ type RunnerSpec struct {
 	// +kubebuilder:validation:Optional
 	MaxParallel *int32 `json:"maxParallel,omitempty"`
 
+	// Pool references a RunnerPool limiting the renovate Jobs running
+	// concurrently across all Runners referencing it.
+	// +kubebuilder:validation:Optional
+	Pool *RunnerPoolRef `json:"pool,omitempty"`
+
+	// Spread distributes the GitRepo runs of a scheduled execution across a time
+	// window instead of dispatching all of them at once.
+	// +kubebuilder:validation:Optional
+	Spread *SpreadSpec `json:"spread,omitempty"`
+
+	// Retry retries failed GitRepo runs with an exponential backoff instead of
+	// waiting for the next scheduled execution.
+	// +kubebuilder:validation:Optional
+	Retry *RetrySpec `json:"retry,omitempty"`
+
+	// BatchSize is the maximum number of GitRepos processed by a single renovate
+	// Job. GitRepos with overrides for the Job are always processed alone.
+	// A value of 1 disables batching.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	BatchSize *int32 `json:"batchSize,omitempty"`
+
+	// BatchStrategy selects how pending GitRepos are grouped into batches.
+	// `queue` fills the batches in dispatch order, `owner` only groups GitRepos
+	// of the same owner or organization. Defaults to `queue`.
+	// +kubebuilder:validation:Optional
+	BatchStrategy BatchStrategy `json:"batchStrategy,omitempty"`
+
+	// ConcurrencyPolicy specifies how triggers for a GitRepo with an active Job
+	// are handled. `Forbid` drops the trigger, `Queue` records it and runs the
+	// GitRepo once more after the active Job has finished, `Replace` deletes the
+	// active Job and starts a new one. Defaults to `Queue`.
+	// +kubebuilder:validation:Optional
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
 	// PodLabelTemplates are merged into Job pod labels. Values support
 	// Go template variables: {{ .namespace }}, {{ .renovator }}, {{ .runner }}, {{ .discovery }}, {{ .gitrepo }}.
 	// +kubebuilder:validation:Optional
 	PodLabelTemplates map[string]string `json:"podLabelTemplates,omitempty"`
 }
 
+// SpreadSpec defines how the GitRepo runs of a scheduled execution are
+// distributed across a time window.
+type SpreadSpec struct {
+	// Window is the duration across which the GitRepo runs are distributed.
+	// +kubebuilder:validation:Required
+	Window metav1.Duration `json:"window"`
+
+	// Strategy selects how the due time of each GitRepo inside the window is
+	// chosen. `hash` derives a stable offset from the GitRepo name, `random`
+	// picks a new offset on every execution. Defaults to `hash`.
+	// +kubebuilder:validation:Optional
+	Strategy SpreadStrategy `json:"strategy,omitempty"`
+}
+
+// RetrySpec defines how failed GitRepo runs are retried.
+type RetrySpec struct {
+	// MaxAttempts is the maximum number of retries of a failed run.
+	// +kubebuilder:validation:Minimum=1
+	MaxAttempts int32 `json:"maxAttempts"`
+
+	// InitialDelay is the delay before the first retry. Defaults to 10m.
+	// +kubebuilder:validation:Optional
+	InitialDelay *metav1.Duration `json:"initialDelay,omitempty"`
+
+	// Multiplier is the factor the delay grows with on every further retry.
+	// Defaults to 2.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	Multiplier *int32 `json:"multiplier,omitempty"`
+
+	// TransientOnly restricts retries to runs failed by transient errors, e.g.
+	// unavailable hosts, rate limits or concurrent changes of the repository.
+	// +kubebuilder:validation:Optional
+	TransientOnly bool `json:"transientOnly,omitempty"`
+}
+
+// GetInitialDelay returns the delay before the first retry.
+func (s *RetrySpec) GetInitialDelay() time.Duration {
+	if s.InitialDelay == nil {
+		return DefaultRetryInitialDelay
+	}
+
+	return s.InitialDelay.Duration
+}
+
+// GetMultiplier returns the factor the delay grows with on every retry.
+func (s *RetrySpec) GetMultiplier() int32 {
+	if s.Multiplier == nil || *s.Multiplier < 1 {
+		return DefaultRetryMultiplier
+	}
+
+	return *s.Multiplier
+}
+
 // RunnerStatus defines the observed state of Runner.
 //
 //nolint:lll
@@ -82,6 +208,11 @@ func (r *Runner) GetSuspend() bool {
 	return *r.Spec.Suspend
 }
 
+// GetBlackoutWindows returns the windows in which scheduled runs are suppressed.
+func (r *Runner) GetBlackoutWindows() []BlackoutWindowSpec {
+	return r.Spec.BlackoutWindows
+}
+
 // GetLastScheduleTime returns the time of the last execution.
 func (r *Runner) GetLastScheduleTime() *metav1.Time {
 	return r.Status.LastScheduleTime
@@ -111,6 +242,31 @@ func (r *Runner) GetMaxParallel() int {
 	return int(*r.Spec.MaxParallel)
 }
 
+// GetBatchSize returns the maximum number of GitRepos processed by a single
+// renovate Job.
+func (r *Runner) GetBatchSize() int {
+	if r.Spec.BatchSize == nil || *r.Spec.BatchSize < 1 {
+		return 1
+	}
+
+	return int(*r.Spec.BatchSize)
+}
+
+// GetRunnerPool returns the key of the RunnerPool referenced by the Runner or
+// nil if the Runner is not part of a pool.
+func (r *Runner) GetRunnerPool() *types.NamespacedName {
+	if r.Spec.Pool == nil || r.Spec.Pool.Name == "" {
+		return nil
+	}
+
+	namespace := r.Spec.Pool.Namespace
+	if namespace == "" {
+		namespace = r.Namespace
+	}
+
+	return &types.NamespacedName{Namespace: namespace, Name: r.Spec.Pool.Name}
+}
+
 func (r *Runner) SetCondition(
 	conditionType string,
 	status metav1.ConditionStatus,
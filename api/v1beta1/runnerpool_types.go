@@ -0,0 +1,161 @@
+package v1beta1
+
+import (
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const DefaultRunnerPoolWeight int32 = 1
+
+// RunnerPoolSpec defines the desired state of RunnerPool.
+type RunnerPoolSpec struct {
+	// MaxParallel is the maximum number of renovate Jobs of all Runners
+	// referencing the pool that can run concurrently.
+	// +kubebuilder:validation:Minimum=1
+	MaxParallel int32 `json:"maxParallel"`
+
+	// DefaultWeight is the fair-share weight of Renovators not listed in Weights.
+	// +kubebuilder:validation:Optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:default=1
+	DefaultWeight int32 `json:"defaultWeight,omitempty"`
+
+	// Weights assigns fair-share weights to Renovators. While Renovators compete
+	// for free job slots, each of them is granted a share of MaxParallel
+	// proportional to its weight.
+	// +kubebuilder:validation:Optional
+	Weights []RunnerPoolWeightSpec `json:"weights,omitempty"`
+
+	// AllowedNamespaces lists the namespaces whose Runners may reference the
+	// pool besides its own namespace. "*" allows all namespaces.
+	// +kubebuilder:validation:Optional
+	AllowedNamespaces []string `json:"allowedNamespaces,omitempty"`
+}
+
+// RunnerPoolWeightSpec defines the fair-share weight of a Renovator.
+type RunnerPoolWeightSpec struct {
+	// Name is the name of the Renovator or of a Runner not managed by a Renovator.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Renovator. Defaults to the namespace of
+	// the RunnerPool.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Weight is the relative share of job slots granted to the Renovator.
+	// +kubebuilder:validation:Minimum=1
+	Weight int32 `json:"weight"`
+}
+
+// RunnerPoolRef references a RunnerPool.
+type RunnerPoolRef struct {
+	// Name is the name of the RunnerPool.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the RunnerPool. Defaults to the namespace of
+	// the referencing resource. A RunnerPool of another namespace must list the
+	// namespace of the referencing resource in its allowed namespaces.
+	// +kubebuilder:validation:Optional
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// RunnerPoolStatus defines the observed state of RunnerPool.
+type RunnerPoolStatus struct {
+	// Active is the number of active and reserved renovate Jobs in the pool,
+	// observed at the last admission of a Job.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	Active int32 `json:"active,omitempty"`
+
+	// Reservations lists the job slots granted to Renovators whose Jobs have
+	// not been observed yet. They count against MaxParallel until the Job is
+	// observed or the reservation expires.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	Reservations []RunnerPoolReservation `json:"reservations,omitempty"`
+
+	// Waiting lists the Renovators waiting for a free job slot.
+	// This field is managed by the operator and should not be set manually.
+	// +kubebuilder:validation:Optional
+	Waiting []RunnerPoolWaiter `json:"waiting,omitempty"`
+}
+
+// RunnerPoolReservation is a job slot of a RunnerPool granted to a Renovator.
+type RunnerPoolReservation struct {
+	// ID identifies the reservation. It is recorded on the Job created for it.
+	ID string `json:"id"`
+
+	// Name is the name of the Renovator.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Renovator.
+	Namespace string `json:"namespace"`
+
+	// Time is the time the job slot was granted.
+	Time metav1.Time `json:"time"`
+}
+
+// RunnerPoolWaiter is a Renovator waiting for a free job slot of a RunnerPool.
+type RunnerPoolWaiter struct {
+	// Name is the name of the Renovator.
+	Name string `json:"name"`
+
+	// Namespace is the namespace of the Renovator.
+	Namespace string `json:"namespace"`
+
+	// LastRequestTime is the time a job slot was last requested for the Renovator.
+	LastRequestTime metav1.Time `json:"lastRequestTime"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:path=runnerpools
+
+// RunnerPool is the Schema for the runnerpools API. It limits the number of
+// renovate Jobs running concurrently across all Runners referencing it.
+type RunnerPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RunnerPoolSpec   `json:"spec,omitempty"`
+	Status RunnerPoolStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RunnerPoolList contains a list of RunnerPool.
+type RunnerPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []RunnerPool `json:"items"`
+}
+
+// AllowsNamespace reports whether Runners of the namespace may reference the
+// RunnerPool.
+func (p *RunnerPool) AllowsNamespace(namespace string) bool {
+	return namespace == p.Namespace ||
+		slices.Contains(p.Spec.AllowedNamespaces, namespace) ||
+		slices.Contains(p.Spec.AllowedNamespaces, "*")
+}
+
+// GetWeight returns the fair-share weight of the Renovator with the given key.
+func (p *RunnerPool) GetWeight(key types.NamespacedName) int32 {
+	for _, weight := range p.Spec.Weights {
+		namespace := weight.Namespace
+		if namespace == "" {
+			namespace = p.Namespace
+		}
+
+		if weight.Name == key.Name && namespace == key.Namespace {
+			return weight.Weight
+		}
+	}
+
+	if p.Spec.DefaultWeight > 0 {
+		return p.Spec.DefaultWeight
+	}
+
+	return DefaultRunnerPoolWeight
+}
@@ -112,6 +112,34 @@ func (in *AuthProviderStatus) DeepCopy() *AuthProviderStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlackoutWindowSpec) DeepCopyInto(out *BlackoutWindowSpec) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Start != nil {
+		in, out := &in.Start, &out.Start
+		*out = (*in).DeepCopy()
+	}
+	if in.End != nil {
+		in, out := &in.End, &out.End
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlackoutWindowSpec.
+func (in *BlackoutWindowSpec) DeepCopy() *BlackoutWindowSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BlackoutWindowSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Discovery) DeepCopyInto(out *Discovery) {
 	*out = *in
@@ -203,6 +231,25 @@ func (in *DiscoverySpec) DeepCopyInto(out *DiscoverySpec) {
 		(*in).DeepCopyInto(*out)
 	}
 	in.Webhooks.DeepCopyInto(&out.Webhooks)
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]RepositorySpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OverrideRules != nil {
+		in, out := &in.OverrideRules, &out.OverrideRules
+		*out = make([]OverrideRuleSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Prune != nil {
+		in, out := &in.Prune, &out.Prune
+		*out = new(PruneSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.PodLabelTemplates != nil {
 		in, out := &in.PodLabelTemplates, &out.PodLabelTemplates
 		*out = make(map[string]string, len(*in))
@@ -240,6 +287,11 @@ func (in *DiscoveryStatus) DeepCopyInto(out *DiscoveryStatus) {
 		in, out := &in.LastDiscoveryTime, &out.LastDiscoveryTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = make([]OrganizationWebhookStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiscoveryStatus.
@@ -252,6 +304,22 @@ func (in *DiscoveryStatus) DeepCopy() *DiscoveryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubAppSpec) DeepCopyInto(out *GitHubAppSpec) {
+	*out = *in
+	in.PrivateKey.DeepCopyInto(&out.PrivateKey)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubAppSpec.
+func (in *GitHubAppSpec) DeepCopy() *GitHubAppSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubAppSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitLabOptions) DeepCopyInto(out *GitLabOptions) {
 	*out = *in
@@ -331,10 +399,71 @@ func (in *GitRepoList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitRepoOverrides) DeepCopyInto(out *GitRepoOverrides) {
+	*out = *in
+	if in.Suspend != nil {
+		in, out := &in.Suspend, &out.Suspend
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(v1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExtraEnv != nil {
+		in, out := &in.ExtraEnv, &out.ExtraEnv
+		*out = make([]v1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]v1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepoOverrides.
+func (in *GitRepoOverrides) DeepCopy() *GitRepoOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(GitRepoOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *GitRepoSpec) DeepCopyInto(out *GitRepoSpec) {
 	*out = *in
 	in.Webhooks.DeepCopyInto(&out.Webhooks)
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(GitRepoOverrides)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepoSpec.
@@ -361,6 +490,30 @@ func (in *GitRepoStatus) DeepCopyInto(out *GitRepoStatus) {
 		in, out := &in.LastRenovateTime, &out.LastRenovateTime
 		*out = (*in).DeepCopy()
 	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRunTime != nil {
+		in, out := &in.NextRunTime, &out.NextRunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NextRetryTime != nil {
+		in, out := &in.NextRetryTime, &out.NextRetryTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.WebhookDeliveries != nil {
+		in, out := &in.WebhookDeliveries, &out.WebhookDeliveries
+		*out = make([]WebhookDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitRepoStatus.
@@ -421,6 +574,13 @@ func (in *JobSpec) DeepCopyInto(out *JobSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.BlackoutWindows != nil {
+		in, out := &in.BlackoutWindows, &out.BlackoutWindows
+		*out = make([]BlackoutWindowSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSpec.
@@ -448,10 +608,61 @@ func (in *LoggingSpec) DeepCopy() *LoggingSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OrganizationWebhookStatus) DeepCopyInto(out *OrganizationWebhookStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OrganizationWebhookStatus.
+func (in *OrganizationWebhookStatus) DeepCopy() *OrganizationWebhookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(OrganizationWebhookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OverrideRuleSpec) DeepCopyInto(out *OverrideRuleSpec) {
+	*out = *in
+	if in.Filter != nil {
+		in, out := &in.Filter, &out.Filter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Topics != nil {
+		in, out := &in.Topics, &out.Topics
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Selector != nil {
+		in, out := &in.Selector, &out.Selector
+		*out = new(metav1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Overrides.DeepCopyInto(&out.Overrides)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OverrideRuleSpec.
+func (in *OverrideRuleSpec) DeepCopy() *OverrideRuleSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OverrideRuleSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PlatformSpec) DeepCopyInto(out *PlatformSpec) {
 	*out = *in
 	in.Token.DeepCopyInto(&out.Token)
+	if in.GitHubApp != nil {
+		in, out := &in.GitHubApp, &out.GitHubApp
+		*out = new(GitHubAppSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PlatformSpec.
@@ -542,6 +753,36 @@ func (in *PodSpec) DeepCopy() *PodSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PruneSpec) DeepCopyInto(out *PruneSpec) {
+	*out = *in
+	if in.MaxCount != nil {
+		in, out := &in.MaxCount, &out.MaxCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxPercentage != nil {
+		in, out := &in.MaxPercentage, &out.MaxPercentage
+		*out = new(int32)
+		**out = **in
+	}
+	if in.GracePeriod != nil {
+		in, out := &in.GracePeriod, &out.GracePeriod
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PruneSpec.
+func (in *PruneSpec) DeepCopy() *PruneSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PruneSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RenovateConfig) DeepCopyInto(out *RenovateConfig) {
 	*out = *in
@@ -735,6 +976,7 @@ func (in *RenovatorSpec) DeepCopyInto(out *RenovatorSpec) {
 	in.Runner.DeepCopyInto(&out.Runner)
 	in.Renovate.DeepCopyInto(&out.Renovate)
 	in.Webhooks.DeepCopyInto(&out.Webhooks)
+	in.Trigger.DeepCopyInto(&out.Trigger)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RenovatorSpec.
@@ -769,6 +1011,56 @@ func (in *RenovatorStatus) DeepCopy() *RenovatorStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RepositorySpec) DeepCopyInto(out *RepositorySpec) {
+	*out = *in
+	if in.Webhooks != nil {
+		in, out := &in.Webhooks, &out.Webhooks
+		*out = new(WebhooksSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Overrides != nil {
+		in, out := &in.Overrides, &out.Overrides
+		*out = new(GitRepoOverrides)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RepositorySpec.
+func (in *RepositorySpec) DeepCopy() *RepositorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RepositorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetrySpec) DeepCopyInto(out *RetrySpec) {
+	*out = *in
+	if in.InitialDelay != nil {
+		in, out := &in.InitialDelay, &out.InitialDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Multiplier != nil {
+		in, out := &in.Multiplier, &out.Multiplier
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetrySpec.
+func (in *RetrySpec) DeepCopy() *RetrySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetrySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Runner) DeepCopyInto(out *Runner) {
 	*out = *in
@@ -828,6 +1120,181 @@ func (in *RunnerList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPool) DeepCopyInto(out *RunnerPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPool.
+func (in *RunnerPool) DeepCopy() *RunnerPool {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RunnerPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPoolList) DeepCopyInto(out *RunnerPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]RunnerPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPoolList.
+func (in *RunnerPoolList) DeepCopy() *RunnerPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RunnerPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPoolRef) DeepCopyInto(out *RunnerPoolRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPoolRef.
+func (in *RunnerPoolRef) DeepCopy() *RunnerPoolRef {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPoolRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPoolReservation) DeepCopyInto(out *RunnerPoolReservation) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPoolReservation.
+func (in *RunnerPoolReservation) DeepCopy() *RunnerPoolReservation {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPoolReservation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPoolSpec) DeepCopyInto(out *RunnerPoolSpec) {
+	*out = *in
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make([]RunnerPoolWeightSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedNamespaces != nil {
+		in, out := &in.AllowedNamespaces, &out.AllowedNamespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPoolSpec.
+func (in *RunnerPoolSpec) DeepCopy() *RunnerPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPoolStatus) DeepCopyInto(out *RunnerPoolStatus) {
+	*out = *in
+	if in.Reservations != nil {
+		in, out := &in.Reservations, &out.Reservations
+		*out = make([]RunnerPoolReservation, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Waiting != nil {
+		in, out := &in.Waiting, &out.Waiting
+		*out = make([]RunnerPoolWaiter, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPoolStatus.
+func (in *RunnerPoolStatus) DeepCopy() *RunnerPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPoolWaiter) DeepCopyInto(out *RunnerPoolWaiter) {
+	*out = *in
+	in.LastRequestTime.DeepCopyInto(&out.LastRequestTime)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPoolWaiter.
+func (in *RunnerPoolWaiter) DeepCopy() *RunnerPoolWaiter {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPoolWaiter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RunnerPoolWeightSpec) DeepCopyInto(out *RunnerPoolWeightSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RunnerPoolWeightSpec.
+func (in *RunnerPoolWeightSpec) DeepCopy() *RunnerPoolWeightSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RunnerPoolWeightSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RunnerSpec) DeepCopyInto(out *RunnerSpec) {
 	*out = *in
@@ -844,6 +1311,26 @@ func (in *RunnerSpec) DeepCopyInto(out *RunnerSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.Pool != nil {
+		in, out := &in.Pool, &out.Pool
+		*out = new(RunnerPoolRef)
+		**out = **in
+	}
+	if in.Spread != nil {
+		in, out := &in.Spread, &out.Spread
+		*out = new(SpreadSpec)
+		**out = **in
+	}
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(RetrySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BatchSize != nil {
+		in, out := &in.BatchSize, &out.BatchSize
+		*out = new(int32)
+		**out = **in
+	}
 	if in.PodLabelTemplates != nil {
 		in, out := &in.PodLabelTemplates, &out.PodLabelTemplates
 		*out = make(map[string]string, len(*in))
@@ -914,6 +1401,114 @@ func (in *ScratchVolumeSpec) DeepCopy() *ScratchVolumeSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpreadSpec) DeepCopyInto(out *SpreadSpec) {
+	*out = *in
+	out.Window = in.Window
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpreadSpec.
+func (in *SpreadSpec) DeepCopy() *SpreadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SpreadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TriggerSpec) DeepCopyInto(out *TriggerSpec) {
+	*out = *in
+	if in.TokenSecret != nil {
+		in, out := &in.TokenSecret, &out.TokenSecret
+		*out = new(v1.SecretKeySelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TriggerSpec.
+func (in *TriggerSpec) DeepCopy() *TriggerSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TriggerSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookCommandsSpec) DeepCopyInto(out *WebhookCommandsSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookCommandsSpec.
+func (in *WebhookCommandsSpec) DeepCopy() *WebhookCommandsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookCommandsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDebounceSpec) DeepCopyInto(out *WebhookDebounceSpec) {
+	*out = *in
+	out.QuietPeriod = in.QuietPeriod
+	if in.MaxDelay != nil {
+		in, out := &in.MaxDelay, &out.MaxDelay
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDebounceSpec.
+func (in *WebhookDebounceSpec) DeepCopy() *WebhookDebounceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDebounceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookDelivery) DeepCopyInto(out *WebhookDelivery) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookDelivery.
+func (in *WebhookDelivery) DeepCopy() *WebhookDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WebhookPathFilterSpec) DeepCopyInto(out *WebhookPathFilterSpec) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookPathFilterSpec.
+func (in *WebhookPathFilterSpec) DeepCopy() *WebhookPathFilterSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(WebhookPathFilterSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WebhooksSpec) DeepCopyInto(out *WebhooksSpec) {
 	*out = *in
@@ -922,6 +1517,26 @@ func (in *WebhooksSpec) DeepCopyInto(out *WebhooksSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.Organizations != nil {
+		in, out := &in.Organizations, &out.Organizations
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Debounce != nil {
+		in, out := &in.Debounce, &out.Debounce
+		*out = new(WebhookDebounceSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PathFilter != nil {
+		in, out := &in.PathFilter, &out.PathFilter
+		*out = new(WebhookPathFilterSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Commands != nil {
+		in, out := &in.Commands, &out.Commands
+		*out = new(WebhookCommandsSpec)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhooksSpec.
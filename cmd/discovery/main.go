@@ -2,18 +2,16 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"os"
 
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
-	"github.com/thegeeklab/renovate-operator/pkg/discovery"
-	corev1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	pkgdiscovery "github.com/thegeeklab/renovate-operator/pkg/discovery"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
-	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 )
@@ -35,42 +33,36 @@ func main() {
 }
 
 func run(ctx context.Context) error {
-	d, err := discovery.New(scheme)
+	d, err := pkgdiscovery.New(scheme)
 	if err != nil {
 		return err
 	}
 
-	repos, err := os.ReadFile(d.FilePath)
+	repoData, err := os.ReadFile(d.FilePath)
 	if err != nil {
 		return err
 	}
 
+	var repos []string
+	if err := json.Unmarshal(repoData, &repos); err != nil {
+		return fmt.Errorf("failed to unmarshal discovered repositories: %w", err)
+	}
+
 	discovery := &renovatev1beta1.Discovery{}
 	if err := d.KubeClient.Get(ctx, types.NamespacedName{Name: d.Name, Namespace: d.Namespace}, discovery); err != nil {
 		return err
 	}
 
-	cm := &corev1.ConfigMap{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      fmt.Sprintf("%s-discovery", d.Name),
-			Namespace: d.Namespace,
-		},
-	}
-
-	op, err := controllerutil.CreateOrUpdate(ctx, d.KubeClient, cm, func() error {
-		if cm.Data == nil {
-			cm.Data = make(map[string]string)
-		}
-
-		cm.Data["repositories"] = string(repos)
-
-		return controllerutil.SetControllerReference(discovery, cm, scheme)
-	})
+	// Renovate only reports the repository names, the controller resolves the
+	// platform IDs of the repositories when syncing the GitRepos.
+	result, err := pkgdiscovery.WriteResult(
+		ctx, d.KubeClient, discovery, fmt.Sprintf("%s-discovery", d.Name), repos, nil, pkgdiscovery.DefaultMaxShardBytes,
+	)
 	if err != nil {
-		return fmt.Errorf("failed to reconcile configmap: %w", err)
+		return fmt.Errorf("failed to reconcile discovery result: %w", err)
 	}
 
-	logf.Log.Info("ConfigMap reconciled", "operation", op, "name", cm.Name)
+	logf.Log.Info("Discovery result reconciled", "repositories", result.Total, "shards", result.Shards)
 
 	return nil
 }
@@ -27,9 +27,13 @@ import (
 	"github.com/thegeeklab/renovate-operator/internal/logreader"
 	"github.com/thegeeklab/renovate-operator/internal/metrics"
 	"github.com/thegeeklab/renovate-operator/internal/receiver"
+	"github.com/thegeeklab/renovate-operator/internal/receiver/azure"
+	"github.com/thegeeklab/renovate-operator/internal/receiver/bitbucketserver"
+	"github.com/thegeeklab/renovate-operator/internal/receiver/distribution"
 	"github.com/thegeeklab/renovate-operator/internal/receiver/gitea"
 	"github.com/thegeeklab/renovate-operator/internal/receiver/github"
 	"github.com/thegeeklab/renovate-operator/internal/receiver/gitlab"
+	"github.com/thegeeklab/renovate-operator/internal/receiver/harbor"
 	"github.com/thegeeklab/renovate-operator/internal/telemetry"
 	webhookrenovatev1beta1 "github.com/thegeeklab/renovate-operator/internal/webhook/v1beta1"
 	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
@@ -87,6 +91,8 @@ const (
 //nolint:lll
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=mutatingwebhookconfigurations,verbs=create;delete;get;update;patch;list;watch
 // +kubebuilder:rbac:groups=admissionregistration.k8s.io,resources=validatingwebhookconfigurations,verbs=create;delete;get;update;patch;list;watch
+// +kubebuilder:rbac:groups=authentication.k8s.io,resources=tokenreviews,verbs=create
+// +kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=events.k8s.io,resources=events,verbs=create;patch;update
 
@@ -116,6 +122,8 @@ type Config struct {
 	WatchNamespace        string
 	FrontendAddr          string
 	ReceiverAddr          string
+	ReceiverDeliveryTTL   time.Duration
+	ReceiverMaxClockSkew  time.Duration
 	ExternalURL           string
 	SecureCookies         bool
 	MetricsCardinalityCap int
@@ -245,6 +253,10 @@ func parseFlags() Config {
 		"The address the web frontend endpoint binds to.")
 	flag.StringVar(&cfg.ReceiverAddr, "receiver-bind-address", "0",
 		"The address the event receiver endpoint binds to.")
+	flag.DurationVar(&cfg.ReceiverDeliveryTTL, "receiver-delivery-ttl", receiver.DefaultDeliveryTTL,
+		"How long webhook deliveries are remembered to ignore duplicate deliveries. Set to 0 to disable.")
+	flag.DurationVar(&cfg.ReceiverMaxClockSkew, "receiver-max-clock-skew", receiver.DefaultMaxClockSkew,
+		"Maximum clock skew between the signed time of a webhook event and its receipt. Set to 0 to disable.")
 	flag.StringVar(&cfg.ExternalURL, "external-url", "",
 		"The public base URL of the operator (e.g., https://operator.example.com). Required for webhooks.")
 	flag.BoolVar(&cfg.SecureCookies, "secure-cookies", true,
@@ -360,9 +372,10 @@ func setupControllers(
 	}
 
 	if err := (&discovery.Reconciler{
-		Client:  mgr.GetClient(),
-		Scheme:  mgr.GetScheme(),
-		Metrics: metricsRecorder,
+		Client:      mgr.GetClient(),
+		Scheme:      mgr.GetScheme(),
+		ExternalURL: cfg.ExternalURL,
+		Metrics:     metricsRecorder,
 	}).SetupWithManager(mgr); err != nil {
 		return fmt.Errorf("unable to create controller %s: %w", discovery.ControllerName, err)
 	}
@@ -579,11 +592,14 @@ func setupHTTPServers(
 
 		receiverConfig := receiver.DefaultServerConfig()
 		receiverConfig.Addr = cfg.ReceiverAddr
+		receiverConfig.DeliveryTTL = cfg.ReceiverDeliveryTTL
+		receiverConfig.MaxClockSkew = cfg.ReceiverMaxClockSkew
 
 		receiverServer := receiver.NewServer(
 			receiverConfig,
 			mgr.GetClient(),
 			buildReceiverFactory(),
+			buildRegistryReceiverFactory(),
 			metricsRecorder,
 		)
 
@@ -665,6 +681,26 @@ func buildReceiverFactory() receiver.ReceiverFactory {
 			return github.NewReceiver()
 		case renovatev1beta1.PlatformType_GITLAB:
 			return gitlab.NewReceiver()
+		case renovatev1beta1.PlatformType_BITBUCKET_SERVER:
+			return bitbucketserver.NewReceiver()
+		case renovatev1beta1.PlatformType_AZURE:
+			return azure.NewReceiver()
+		default:
+			return nil
+		}
+	}
+}
+
+// buildRegistryReceiverFactory returns a factory function that creates the
+// RegistryReceiver implementation based on the container registry type. The
+// GitLab container registry sends Docker Distribution notifications.
+func buildRegistryReceiverFactory() receiver.RegistryReceiverFactory {
+	return func(registryType receiver.RegistryType) receiver.RegistryReceiver {
+		switch registryType {
+		case receiver.RegistryType_HARBOR:
+			return harbor.NewReceiver()
+		case receiver.RegistryType_DISTRIBUTION, receiver.RegistryType_GITLAB:
+			return distribution.NewReceiver()
 		default:
 			return nil
 		}
@@ -7,6 +7,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	azurereceiver "github.com/thegeeklab/renovate-operator/internal/receiver/azure"
+	bitbucketserverreceiver "github.com/thegeeklab/renovate-operator/internal/receiver/bitbucketserver"
 	gitlabreceiver "github.com/thegeeklab/renovate-operator/internal/receiver/gitlab"
 )
 
@@ -21,6 +23,16 @@ var _ = Describe("buildReceiverFactory", func() {
 		Expect(platformReceiver).To(BeAssignableToTypeOf(&gitlabreceiver.Receiver{}))
 	})
 
+	It("creates a Bitbucket Server receiver", func() {
+		platformReceiver := buildReceiverFactory()(renovatev1beta1.PlatformType_BITBUCKET_SERVER)
+		Expect(platformReceiver).To(BeAssignableToTypeOf(&bitbucketserverreceiver.Receiver{}))
+	})
+
+	It("creates an Azure DevOps receiver", func() {
+		platformReceiver := buildReceiverFactory()(renovatev1beta1.PlatformType_AZURE)
+		Expect(platformReceiver).To(BeAssignableToTypeOf(&azurereceiver.Receiver{}))
+	})
+
 	It("returns nil for unsupported platforms", func() {
 		Expect(buildReceiverFactory()(renovatev1beta1.PlatformType("unsupported"))).To(BeNil())
 	})
@@ -0,0 +1,116 @@
+package discovery
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// matchFilters returns the repositories matching at least one of the filters,
+// following the semantics of the Renovate autodiscoverFilter option: a filter
+// wrapped in slashes (`/pattern/flags`) is a regular expression, any other
+// filter is a case-insensitive glob. A leading `!` negates the filter.
+func matchFilters(repos, filters []string) ([]string, error) {
+	if len(filters) == 0 {
+		return repos, nil
+	}
+
+	matchers := make([]func(string) bool, 0, len(filters))
+
+	for _, filter := range filters {
+		matcher, err := newFilterMatcher(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		matchers = append(matchers, matcher)
+	}
+
+	matched := make([]string, 0, len(repos))
+
+	for _, repo := range repos {
+		for _, matcher := range matchers {
+			if matcher(repo) {
+				matched = append(matched, repo)
+
+				break
+			}
+		}
+	}
+
+	return matched, nil
+}
+
+func newFilterMatcher(filter string) (func(string) bool, error) {
+	pattern, negate := strings.CutPrefix(filter, "!")
+
+	var (
+		re  *regexp.Regexp
+		err error
+	)
+
+	if end := strings.LastIndex(pattern, "/"); strings.HasPrefix(pattern, "/") && end > 0 {
+		re, err = compileRegexFilter(pattern[1:end], pattern[end+1:])
+	} else {
+		re, err = regexp.Compile("(?i)^" + globToRegex(pattern) + "$")
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid discovery filter %q: %w", filter, err)
+	}
+
+	return func(repo string) bool {
+		return re.MatchString(repo) != negate
+	}, nil
+}
+
+func compileRegexFilter(expr, flags string) (*regexp.Regexp, error) {
+	if strings.Contains(flags, "i") {
+		expr = "(?i)" + expr
+	}
+
+	return regexp.Compile(expr)
+}
+
+// globToRegex converts a minimatch style glob into a regular expression.
+// `**` matches across path segments, `*` and `?` match within a segment and
+// `{a,b}` matches any of the alternatives.
+func globToRegex(glob string) string {
+	var (
+		sb    strings.Builder
+		depth int
+	)
+
+	for i := 0; i < len(glob); i++ {
+		c := glob[i]
+
+		switch {
+		case c == '*' && i+1 < len(glob) && glob[i+1] == '*':
+			sb.WriteString(".*")
+
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		case c == '{':
+			sb.WriteString("(?:")
+
+			depth++
+		case c == '}' && depth > 0:
+			sb.WriteString(")")
+
+			depth--
+		case c == ',' && depth > 0:
+			sb.WriteString("|")
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	for ; depth > 0; depth-- {
+		sb.WriteString(")")
+	}
+
+	return sb.String()
+}
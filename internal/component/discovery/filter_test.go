@@ -0,0 +1,43 @@
+package discovery
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Discovery Filters", func() {
+	repos := []string{
+		"thegeeklab/renovate-operator",
+		"thegeeklab/docs",
+		"other/renovate-config",
+		"group/subgroup/project",
+	}
+
+	DescribeTable("matchFilters",
+		func(filters, expected []string) {
+			matched, err := matchFilters(repos, filters)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(matched).To(Equal(expected))
+		},
+		Entry("no filters", nil, repos),
+		Entry("glob within a segment", []string{"thegeeklab/*"},
+			[]string{"thegeeklab/renovate-operator", "thegeeklab/docs"}),
+		Entry("glob across segments", []string{"group/**"}, []string{"group/subgroup/project"}),
+		Entry("case-insensitive glob", []string{"TheGeekLab/Docs"}, []string{"thegeeklab/docs"}),
+		Entry("brace alternatives", []string{"{thegeeklab,other}/renovate-*"},
+			[]string{"thegeeklab/renovate-operator", "other/renovate-config"}),
+		Entry("regular expression", []string{"/renovate/"},
+			[]string{"thegeeklab/renovate-operator", "other/renovate-config"}),
+		Entry("case-insensitive regular expression", []string{"/^THEGEEKLAB//i"},
+			[]string{"thegeeklab/renovate-operator", "thegeeklab/docs"}),
+		Entry("negated regular expression", []string{"!/^thegeeklab//"},
+			[]string{"other/renovate-config", "group/subgroup/project"}),
+		Entry("union of multiple filters", []string{"thegeeklab/docs", "other/*"},
+			[]string{"thegeeklab/docs", "other/renovate-config"}),
+	)
+
+	It("should reject invalid regular expressions", func() {
+		_, err := matchFilters(repos, []string{"/(/"})
+		Expect(err).To(MatchError(ContainSubstring("invalid discovery filter")))
+	})
+})
@@ -1,17 +1,21 @@
 package discovery
 
 import (
+	"cmp"
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"sync"
 
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
 	"github.com/thegeeklab/renovate-operator/internal/provider"
 	"github.com/thegeeklab/renovate-operator/internal/provider/factory"
+	pkgdiscovery "github.com/thegeeklab/renovate-operator/pkg/discovery"
 	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
 	corev1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -19,82 +23,157 @@ import (
 
 var ErrPlatformTokenSecretNotConfigured = errors.New("platform token secret not configured")
 
-// reconcileGitRepos synchronizes GitRepo resources based on the discovery result ConfigMap.
-func (r *Reconciler) reconcileGitRepos(ctx context.Context) (*ctrl.Result, error) {
-	var (
-		allErrors []error
-		targetCM  *corev1.ConfigMap
-	)
+// resolvedRepoIDs caches the resolved repository IDs by Discovery. Listing all
+// repositories of the platform is only required once per discovery result.
+var resolvedRepoIDs sync.Map
 
-	log := logf.FromContext(ctx)
+// repoIDsEntry holds the repository IDs resolved for a discovery result run.
+type repoIDsEntry struct {
+	uid types.UID
+	run string
+	ids map[string]string
+}
 
-	cms := &corev1.ConfigMapList{}
-	if err := r.List(ctx, cms, client.InNamespace(r.instance.Namespace)); err != nil {
-		return &ctrl.Result{}, err
+// reconcileGitRepos synchronizes GitRepo resources based on the statically
+// configured repositories and the discovery result.
+func (r *Reconciler) reconcileGitRepos(ctx context.Context) (*ctrl.Result, error) {
+	rules, err := r.newOverrideRules(ctx)
+	if err != nil {
+		return &ctrl.Result{}, fmt.Errorf("failed to compile override rules: %w", err)
 	}
 
-	for _, cm := range cms.Items {
-		if metav1.IsControlledBy(&cm, r.instance) {
-			targetCM = &cm
+	repoMatcher := make(map[string]bool)
+	allErrors := r.syncStaticRepos(ctx, repoMatcher, rules)
 
-			break
+	if !r.instance.GetStaticMode() {
+		complete, err := r.syncDiscoveredRepos(ctx, repoMatcher, rules)
+		if err != nil {
+			allErrors = append(allErrors, err)
 		}
+
+		// Never prune based on a missing or partially read discovery result.
+		if !complete {
+			return &ctrl.Result{}, errors.Join(allErrors...)
+		}
+	}
+
+	if r.metrics != nil {
+		renovatorLabel := r.instance.Labels[renovatev1beta1.LabelRenovator]
+		r.metrics.SetDiscoveryRepositories(r.instance.Namespace, renovatorLabel, r.instance.Name, len(repoMatcher))
 	}
 
-	if targetCM == nil {
-		log.V(1).Info("No discovery result ConfigMap found, skipping GitRepo sync")
+	requeueAfter, err := r.pruneOrphanedRepos(ctx, repoMatcher)
+	if err != nil {
+		allErrors = append(allErrors, fmt.Errorf("failed to prune orphaned repos: %w", err))
+	}
 
-		return &ctrl.Result{}, nil
+	if len(allErrors) > 0 {
+		return &ctrl.Result{}, errors.Join(allErrors...)
 	}
 
-	repoData, exists := targetCM.Data["repositories"]
-	if !exists {
-		log.Error(nil, "ConfigMap does not contain 'repositories' key", "cm", targetCM.Name)
+	return &ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// syncStaticRepos creates or updates the GitRepos of the statically configured
+// repositories and adds them to repoMatcher so they are never pruned.
+func (r *Reconciler) syncStaticRepos(
+	ctx context.Context, repoMatcher map[string]bool, rules []overrideRule,
+) []error {
+	var allErrors []error
 
-		return &ctrl.Result{}, nil
+	log := logf.FromContext(ctx)
+
+	for i := range r.instance.Spec.Repositories {
+		repo := &r.instance.Spec.Repositories[i]
+
+		// Keep the GitRepo even if the sync failed, a listed repository must
+		// never be pruned.
+		repoMatcher[repo.Name] = true
+
+		name, err := gitRepoName(r.instance.Name, repo.Name)
+		if err != nil {
+			log.Error(err, "Failed to resolve GitRepo name", "repo", repo.Name)
+			allErrors = append(allErrors, err)
+
+			continue
+		}
+
+		if err := r.syncGitRepo(ctx, name, repo.Name, "", repo, rules); err != nil {
+			allErrors = append(allErrors, err)
+		}
+	}
+
+	return allErrors
+}
+
+// syncDiscoveredRepos creates or updates the GitRepos of the discovery result.
+// All shards are read before any GitRepo is synced, so an incomplete result
+// never changes the GitRepos. Repositories with a known platform ID keep their
+// GitRepo when they are renamed or transferred. It returns false if the result
+// was not read completely and orphaned GitRepos must not be pruned.
+func (r *Reconciler) syncDiscoveredRepos(
+	ctx context.Context, repoMatcher map[string]bool, rules []overrideRule,
+) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	indexCM := &corev1.ConfigMap{}
+	indexKey := client.ObjectKey{Namespace: r.instance.Namespace, Name: DiscoveryName(r.req)}
+
+	if err := r.Get(ctx, indexKey, indexCM); err != nil {
+		if api_errors.IsNotFound(err) {
+			log.V(1).Info("No discovery result ConfigMap found, skipping GitRepo sync")
+
+			return false, nil
+		}
+
+		return false, err
 	}
 
-	var discoveredRepos []string
-	if err := json.Unmarshal([]byte(repoData), &discoveredRepos); err != nil {
-		log.Error(err, "Failed to unmarshal discovery results from ConfigMap", "cm", targetCM.Name)
+	if !metav1.IsControlledBy(indexCM, r.instance) {
+		log.V(1).Info("Discovery result ConfigMap not controlled by instance, skipping GitRepo sync", "cm", indexCM.Name)
 
-		return &ctrl.Result{}, nil
+		return false, nil
 	}
 
-	filteredRepos, err := r.filterRepos(ctx, discoveredRepos)
+	index, err := pkgdiscovery.ReadResultIndex(indexCM)
 	if err != nil {
-		return &ctrl.Result{}, err
+		log.Error(err, "Failed to read discovery result index", "cm", indexCM.Name)
+
+		return false, nil
 	}
 
-	if r.metrics != nil {
-		renovatorLabel := r.instance.Labels[renovatev1beta1.LabelRenovator]
-		r.metrics.SetDiscoveryRepositories(r.instance.Namespace, renovatorLabel, r.instance.Name, len(filteredRepos))
+	result, complete, err := r.readDiscoveryResult(ctx, indexCM, index, repoMatcher)
+	if !complete {
+		return false, err
+	}
+
+	tracked, err := r.newRepoIndex(ctx, result.ids)
+	if err != nil {
+		return false, err
 	}
 
-	repoMatcher := make(map[string]bool, len(filteredRepos))
+	var allErrors []error
 
-	for _, repoName := range filteredRepos {
-		sanitizedName, err := k8s.SanitizeSubdomain(repoName)
+	for _, repoName := range result.repos {
+		repoID := result.ids[repoName]
+
+		name, previousName, err := tracked.gitRepoName(repoName, repoID, r.instance.Name)
 		if err != nil {
-			log.Error(err, "Failed to sanitize repository name", "repo", repoName)
-			allErrors = append(allErrors, fmt.Errorf("failed to sanitize repo name %s: %w", repoName, err))
+			log.Error(err, "Failed to resolve GitRepo name", "repo", repoName)
+			allErrors = append(allErrors, err)
 
 			continue
 		}
 
-		gitRepo := &renovatev1beta1.GitRepo{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      fmt.Sprintf("%s-%s", r.instance.Name, sanitizedName),
-				Namespace: r.instance.Namespace,
-			},
+		if previousName != "" {
+			log.Info("Following renamed repository", "gitRepo", name, "from", previousName, "to", repoName)
+
+			// Keep the GitRepo even if the cache still returns the previous name.
+			repoMatcher[previousName] = true
 		}
 
-		_, err = k8s.CreateOrUpdate(ctx, r.Client, gitRepo, r.instance, func() error {
-			return r.updateGitRepo(gitRepo, repoName)
-		})
-		if err != nil {
-			log.Error(err, "Failed to sync GitRepo", "repo", repoName)
-			allErrors = append(allErrors, fmt.Errorf("failed to sync GitRepo %s: %w", repoName, err))
+		if err := r.syncGitRepo(ctx, name, repoName, repoID, nil, rules); err != nil {
+			allErrors = append(allErrors, err)
 
 			continue
 		}
@@ -102,32 +181,293 @@ func (r *Reconciler) reconcileGitRepos(ctx context.Context) (*ctrl.Result, error
 		repoMatcher[repoName] = true
 	}
 
-	if err := r.pruneOrphanedRepos(ctx, repoMatcher); err != nil {
-		allErrors = append(allErrors, fmt.Errorf("failed to prune orphaned repos: %w", err))
+	if err := r.updateResultStatus(ctx, result.total, index.Shards); err != nil {
+		allErrors = append(allErrors, err)
 	}
 
-	if len(allErrors) > 0 {
-		return &ctrl.Result{}, errors.Join(allErrors...)
+	return true, errors.Join(allErrors...)
+}
+
+// discoveryResult holds the repositories of a discovery result to sync.
+type discoveryResult struct {
+	// repos lists the repositories not synced yet and not excluded by a filter.
+	repos []string
+	// ids maps repository names to their platform IDs, if known.
+	ids map[string]string
+	// total is the number of repositories of the discovery result.
+	total int
+}
+
+// readDiscoveryResult reads all shards of the discovery result. Repositories
+// already in repoMatcher, i.e. statically configured ones, and repositories
+// excluded by a filter are skipped. It returns false if the result was not
+// read completely.
+func (r *Reconciler) readDiscoveryResult(
+	ctx context.Context, indexCM *corev1.ConfigMap, index pkgdiscovery.ResultIndex, repoMatcher map[string]bool,
+) (discoveryResult, bool, error) {
+	log := logf.FromContext(ctx)
+
+	keepRepo, filterIDs, err := r.newRepoFilter(ctx, cmp.Or(index.Run, indexCM.ResourceVersion))
+	if err != nil {
+		return discoveryResult{}, false, err
+	}
+
+	result := discoveryResult{ids: make(map[string]string)}
+
+	for i := range index.Shards {
+		shardCM, err := r.getResultShard(ctx, indexCM, i)
+		if err != nil {
+			return discoveryResult{}, false, err
+		}
+
+		repos, err := pkgdiscovery.ReadShard(shardCM, index)
+		if errors.Is(err, pkgdiscovery.ErrShardOutdated) {
+			// The shards are updated by another run, the next write of the index
+			// shard triggers a new reconciliation.
+			log.V(1).Info("Discovery result is being updated, skipping GitRepo sync", "cm", shardCM.Name)
+
+			return discoveryResult{}, false, nil
+		}
+
+		if err != nil {
+			log.Error(err, "Failed to read discovery result shard", "cm", shardCM.Name)
+
+			return discoveryResult{}, false, nil
+		}
+
+		ids, err := pkgdiscovery.ReadShardIDs(shardCM)
+		if err != nil {
+			log.Error(err, "Failed to read discovery result shard", "cm", shardCM.Name)
+
+			return discoveryResult{}, false, nil
+		}
+
+		result.total += len(repos)
+
+		for _, repoName := range repos {
+			// Statically configured repositories are already synced with their overrides.
+			if repoMatcher[repoName] || !keepRepo(repoName) {
+				continue
+			}
+
+			result.repos = append(result.repos, repoName)
+
+			if id := cmp.Or(ids[repoName], filterIDs[repoName]); id != "" {
+				result.ids[repoName] = id
+			}
+		}
+	}
+
+	return result, true, nil
+}
+
+// getResultShard returns the discovery result ConfigMap holding the shard with
+// the given index.
+func (r *Reconciler) getResultShard(
+	ctx context.Context, indexCM *corev1.ConfigMap, shard int,
+) (*corev1.ConfigMap, error) {
+	if shard == 0 {
+		return indexCM, nil
+	}
+
+	name, err := pkgdiscovery.ShardName(indexCM.Name, shard)
+	if err != nil {
+		return nil, err
+	}
+
+	cm := &corev1.ConfigMap{}
+	if err := r.Get(ctx, client.ObjectKey{Namespace: indexCM.Namespace, Name: name}, cm); err != nil {
+		return nil, fmt.Errorf("failed to get discovery result shard %s: %w", name, err)
+	}
+
+	return cm, nil
+}
+
+// syncGitRepo creates or updates the GitRepo with the given name for a
+// repository and records the platform ID of the repository if known. The
+// overrides of the matching override rules and of a statically configured
+// repository are applied if set.
+func (r *Reconciler) syncGitRepo(
+	ctx context.Context,
+	name, repoName, repoID string,
+	override *renovatev1beta1.RepositorySpec,
+	rules []overrideRule,
+) error {
+	log := logf.FromContext(ctx)
+
+	gitRepo := &renovatev1beta1.GitRepo{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: r.instance.Namespace,
+		},
+	}
+
+	_, err := k8s.CreateOrUpdate(ctx, r.Client, gitRepo, r.instance, func() error {
+		if err := r.updateGitRepo(gitRepo, repoName); err != nil {
+			return err
+		}
+
+		var static *renovatev1beta1.GitRepoOverrides
+
+		if override != nil {
+			applyRepositoryOverrides(gitRepo, override)
+
+			static = override.Overrides
+		}
+
+		gitRepo.Spec.Overrides = resolveOverrides(rules, repoName, gitRepo.Labels, static)
+
+		return nil
+	})
+	if err != nil {
+		log.Error(err, "Failed to sync GitRepo", "repo", repoName)
+
+		return fmt.Errorf("failed to sync GitRepo %s: %w", repoName, err)
+	}
+
+	if repoID == "" || gitRepo.Status.RepositoryID == repoID {
+		return nil
+	}
+
+	patch := client.MergeFrom(gitRepo.DeepCopy())
+	gitRepo.Status.RepositoryID = repoID
+
+	if err := r.Status().Patch(ctx, gitRepo, patch); err != nil {
+		return fmt.Errorf("failed to patch repository ID of GitRepo %s: %w", repoName, err)
+	}
+
+	return nil
+}
+
+// gitRepoName returns the name of the GitRepo resource of a repository that is
+// derived from the Discovery name and the repository name.
+func gitRepoName(discoveryName, repoName string) (string, error) {
+	sanitizedName, err := k8s.SanitizeSubdomain(repoName)
+	if err != nil {
+		return "", fmt.Errorf("failed to sanitize repo name %s: %w", repoName, err)
 	}
 
-	return &ctrl.Result{}, nil
+	return fmt.Sprintf("%s-%s", discoveryName, sanitizedName), nil
+}
+
+// updateResultStatus exposes the size of the discovery result in the status.
+func (r *Reconciler) updateResultStatus(ctx context.Context, total, shards int) error {
+	if int(r.instance.Status.DiscoveredRepositories) == total && int(r.instance.Status.ResultShards) == shards {
+		return nil
+	}
+
+	patch := client.MergeFrom(r.instance.DeepCopy())
+
+	r.instance.Status.DiscoveredRepositories = int32(total) //nolint:gosec // G115: repository count fits into int32
+	r.instance.Status.ResultShards = int32(shards)          //nolint:gosec // G115: shard count fits into int32
+
+	if err := r.Status().Patch(ctx, r.instance, patch); err != nil {
+		return fmt.Errorf("failed to patch discovery result status: %w", err)
+	}
+
+	return nil
 }
 
-// filterRepos returns repos with forks removed when skipForks is enabled and/or
-// filtered by topics when topics are configured on the discovery instance.
+// newRepoFilter returns a predicate excluding forks when skipForks is enabled
+// and/or repositories not matching the topics configured on the discovery instance.
 // The full repository list is fetched in a single batched call per provider, with forks
 // excluded server-side (GitHub) or filtered locally (Gitea) to avoid N+1 API calls.
-func (r *Reconciler) filterRepos(ctx context.Context, repos []string) ([]string, error) {
+// The platform IDs of the listed repositories are returned by name, run identifies
+// the discovery result the IDs are resolved for.
+func (r *Reconciler) newRepoFilter(ctx context.Context, run string) (func(string) bool, map[string]string, error) {
 	log := logf.FromContext(ctx)
 
 	skipForks := r.instance.GetSkipForks()
 	topics := r.instance.GetTopics()
 	skipPending := r.instance.GetSkipPendingDeletion()
 
+	// Native discovery already applies all options when listing the repositories.
+	if r.instance.GetNativeMode() {
+		return func(string) bool { return true }, nil, nil
+	}
+
 	if !skipForks && len(topics) == 0 && !skipPending {
-		return repos, nil
+		return func(string) bool { return true }, r.resolveRepoIDs(ctx, run), nil
+	}
+
+	providerManager, err := r.newProviderManager(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	platformRepos, err := providerManager.ListRepos(ctx, provider.ListReposOptions{
+		SkipForks:           skipForks,
+		Topics:              topics,
+		SkipPendingDeletion: skipPending,
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	platformIDs := make(map[string]string, len(platformRepos))
+	for _, repo := range platformRepos {
+		platformIDs[repo.Name] = repo.ID
+	}
+
+	return func(repoName string) bool {
+		if _, ok := platformIDs[repoName]; !ok {
+			log.V(1).Info("Skipping repository excluded by filter", "repo", repoName)
+
+			return false
+		}
+
+		return true
+	}, platformIDs, nil
+}
+
+// resolveRepoIDs returns the platform IDs of the repositories by name. The
+// discovery Job only reports repository names, the IDs are required to follow
+// renamed and transferred repositories. Renamed repositories are not followed
+// if the IDs cannot be resolved. The IDs are resolved once per discovery result
+// run and cached until the result changes.
+func (r *Reconciler) resolveRepoIDs(ctx context.Context, run string) map[string]string {
+	log := logf.FromContext(ctx)
+
+	key := client.ObjectKeyFromObject(r.instance)
+	if cached, ok := resolvedRepoIDs.Load(key); ok {
+		if entry, ok := cached.(repoIDsEntry); ok && entry.uid == r.instance.UID && entry.run == run {
+			return entry.ids
+		}
+	}
+
+	providerManager, err := r.newProviderManager(ctx)
+	if err != nil {
+		log.Error(err, "Failed to initialize provider, renamed repositories are not followed")
+
+		return nil
+	}
+
+	platformRepos, err := providerManager.ListRepos(ctx, provider.ListReposOptions{})
+	if err != nil {
+		log.Error(err, "Failed to list repositories, renamed repositories are not followed")
+
+		return nil
+	}
+
+	platformIDs := make(map[string]string, len(platformRepos))
+	for _, repo := range platformRepos {
+		if repo.ID != "" {
+			platformIDs[repo.Name] = repo.ID
+		}
 	}
 
+	resolvedRepoIDs.Store(key, repoIDsEntry{uid: r.instance.UID, run: run, ids: platformIDs})
+
+	return platformIDs
+}
+
+// ForgetRepoIDs removes the cached repository IDs of a deleted Discovery.
+func ForgetRepoIDs(key client.ObjectKey) {
+	resolvedRepoIDs.Delete(key)
+}
+
+// newProviderManager initializes the provider of the platform configured in the RenovateConfig.
+func (r *Reconciler) newProviderManager(ctx context.Context) (provider.ProviderManager, error) {
 	if r.renovate.Spec.Platform.Token.SecretKeyRef == nil {
 		return nil, ErrPlatformTokenSecretNotConfigured
 	}
@@ -146,37 +486,19 @@ func (r *Reconciler) filterRepos(ctx context.Context, repos []string) ([]string,
 		Token:    string(secret.Data[r.renovate.Spec.Platform.Token.SecretKeyRef.Key]),
 	}
 
-	providerManager, err := r.providerFactory(ctx, platformConfig)
+	githubApp, err := factory.LoadGitHubApp(ctx, r.Client, r.instance.Namespace, &r.renovate.Spec.Platform)
 	if err != nil {
-		return nil, fmt.Errorf("failed to initialize provider: %w", err)
+		return nil, err
 	}
 
-	platformRepos, err := providerManager.ListRepos(ctx, provider.ListReposOptions{
-		SkipForks:           skipForks,
-		Topics:              topics,
-		SkipPendingDeletion: skipPending,
-	})
-	if err != nil {
-		return nil, fmt.Errorf("failed to list repositories: %w", err)
-	}
-
-	platformSet := make(map[string]struct{}, len(platformRepos))
-	for _, repo := range platformRepos {
-		platformSet[repo.Name] = struct{}{}
-	}
-
-	filtered := make([]string, 0, len(repos))
-	for _, repoName := range repos {
-		if _, ok := platformSet[repoName]; !ok {
-			log.V(1).Info("Skipping repository excluded by filter", "repo", repoName)
+	platformConfig.GitHubApp = githubApp
 
-			continue
-		}
-
-		filtered = append(filtered, repoName)
+	providerManager, err := r.providerFactory(ctx, platformConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize provider: %w", err)
 	}
 
-	return filtered, nil
+	return providerManager, nil
 }
 
 // updateGitRepo manages the specific spec and labels of the GitRepo resource.
@@ -193,35 +515,25 @@ func (r *Reconciler) updateGitRepo(gr *renovatev1beta1.GitRepo, repoName string)
 
 	gr.Spec.Name = repoName
 	gr.Spec.Webhooks.Enabled = r.instance.Spec.Webhooks.Enabled
+	gr.Spec.Webhooks.Debounce = r.instance.Spec.Webhooks.Debounce
+	gr.Spec.Webhooks.PathFilter = r.instance.Spec.Webhooks.PathFilter
+	gr.Spec.Webhooks.Commands = r.instance.Spec.Webhooks.Commands
+
+	// Events are delivered through the organization webhooks managed by the
+	// Discovery, the GitRepo must not register its own webhook.
+	if r.instance.GetOrganizationWebhooks() {
+		gr.Spec.Webhooks.Enabled = new(false)
+	}
 
 	return nil
 }
 
-// pruneOrphanedRepos deletes GitRepos that are no longer present in the discovery result.
-func (r *Reconciler) pruneOrphanedRepos(ctx context.Context, discovered map[string]bool) error {
-	var pruneErrors []error
-
-	log := logf.FromContext(ctx)
-
-	existingRepos := &renovatev1beta1.GitRepoList{}
-	if err := r.List(ctx, existingRepos, client.InNamespace(r.instance.Namespace)); err != nil {
-		return fmt.Errorf("failed to list existing GitRepos: %w", err)
-	}
-
-	for _, repo := range existingRepos.Items {
-		if !discovered[repo.Spec.Name] && metav1.IsControlledBy(&repo, r.instance) {
-			log.Info("Deleting orphaned GitRepo", "name", repo.Name)
+// applyRepositoryOverrides applies the per repository settings of a statically
+// configured repository to its GitRepo.
+func applyRepositoryOverrides(gr *renovatev1beta1.GitRepo, repo *renovatev1beta1.RepositorySpec) {
+	gr.Spec.ConfigRef = repo.ConfigRef
 
-			if err := r.Delete(ctx, &repo); err != nil {
-				log.Error(err, "Failed to delete orphaned GitRepo", "name", repo.Name)
-				pruneErrors = append(pruneErrors, fmt.Errorf("failed to delete GitRepo %s: %w", repo.Name, err))
-			}
-		}
-	}
-
-	if len(pruneErrors) > 0 {
-		return errors.Join(pruneErrors...)
+	if repo.Webhooks != nil {
+		gr.Spec.Webhooks = *repo.Webhooks.DeepCopy()
 	}
-
-	return nil
 }
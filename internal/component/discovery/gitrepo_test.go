@@ -13,9 +13,12 @@ import (
 	"github.com/thegeeklab/renovate-operator/internal/provider"
 	"github.com/thegeeklab/renovate-operator/internal/provider/factory"
 	"github.com/thegeeklab/renovate-operator/internal/provider/mocks"
+	pkgdiscovery "github.com/thegeeklab/renovate-operator/pkg/discovery"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -71,10 +74,37 @@ var _ = Describe("GitRepo Reconciliation", func() {
 			},
 		}
 
-		fakeClient = fake.NewClientBuilder().WithScheme(scheme).Build()
-		reconciler = &Reconciler{Client: fakeClient, scheme: scheme, instance: instance}
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(instance).
+			WithStatusSubresource(instance, &renovatev1beta1.GitRepo{}).
+			Build()
+		reconciler = &Reconciler{
+			Client:   fakeClient,
+			scheme:   scheme,
+			req:      ctrl.Request{NamespacedName: types.NamespacedName{Namespace: "default", Name: "test-discovery"}},
+			instance: instance,
+		}
 		ctx = context.Background()
 
+		ForgetRepoIDs(client.ObjectKeyFromObject(instance))
+
+		// The platform token secret is only created by tests using the provider.
+		reconciler.renovate = &renovatev1beta1.RenovateConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-config", Namespace: "default"},
+			Spec: renovatev1beta1.RenovateConfigSpec{
+				Platform: renovatev1beta1.PlatformSpec{
+					Type: "stub",
+					Token: corev1.EnvVarSource{
+						SecretKeyRef: &corev1.SecretKeySelector{
+							Key:                  "token",
+							LocalObjectReference: corev1.LocalObjectReference{Name: "platform-secret"},
+						},
+					},
+				},
+			},
+		}
+
 		mockMgr = mocks.NewProviderManager(GinkgoT())
 		reconciler.providerFactory = func(
 			context.Context, factory.PlatformConfig,
@@ -89,7 +119,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 
 	Describe("reconcileGitRepos", func() {
 		It("should successfully create GitRepos with inherited labels", func() {
-			cm := createDiscoveryCM("test-config", []string{"repo1"})
+			cm := createDiscoveryCM(DiscoveryName(reconciler.req), []string{"repo1"})
 			Expect(fakeClient.Create(ctx, cm)).To(Succeed())
 
 			_, err := reconciler.reconcileGitRepos(ctx)
@@ -105,9 +135,270 @@ var _ = Describe("GitRepo Reconciliation", func() {
 			Expect(metav1.IsControlledBy(&repo, instance)).To(BeTrue())
 		})
 
+		It("should consume all shards of the discovery result and expose the counts", func() {
+			repos := []string{"org/repo1", "org/repo2", "org/repo3", "org/repo4", "org/repo5"}
+			result, err := pkgdiscovery.WriteResult(ctx, fakeClient, instance, DiscoveryName(reconciler.req), repos, nil, 32)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(result.Shards).To(BeNumerically(">", 1))
+
+			_, err = reconciler.reconcileGitRepos(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			gitRepos := &renovatev1beta1.GitRepoList{}
+			Expect(fakeClient.List(ctx, gitRepos)).To(Succeed())
+			Expect(gitRepos.Items).To(HaveLen(len(repos)))
+
+			Expect(instance.Status.DiscoveredRepositories).To(BeEquivalentTo(len(repos)))
+			Expect(instance.Status.ResultShards).To(BeEquivalentTo(result.Shards))
+		})
+
+		It("should not prune while the shards belong to different runs", func() {
+			existing := newGitRepo("test-discovery-org-old", "org/old")
+			Expect(controllerutil.SetControllerReference(instance, existing, scheme)).To(Succeed())
+			Expect(fakeClient.Create(ctx, existing)).To(Succeed())
+
+			_, err := pkgdiscovery.WriteResult(
+				ctx, fakeClient, instance, DiscoveryName(reconciler.req), []string{"org/repo1", "org/repo2"}, nil, 16,
+			)
+			Expect(err).ToNot(HaveOccurred())
+
+			shardName, err := pkgdiscovery.ShardName(DiscoveryName(reconciler.req), 1)
+			Expect(err).ToNot(HaveOccurred())
+
+			shard := &corev1.ConfigMap{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: shardName}, shard)).To(Succeed())
+			shard.Annotations[pkgdiscovery.AnnotationResultRun] = "next-run"
+			Expect(fakeClient.Update(ctx, shard)).To(Succeed())
+
+			_, err = reconciler.reconcileGitRepos(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			gitRepos := &renovatev1beta1.GitRepoList{}
+			Expect(fakeClient.List(ctx, gitRepos)).To(Succeed())
+			Expect(gitRepos.Items).To(ContainElement(
+				HaveField("Spec.Name", "org/old"),
+			))
+			Expect(instance.Status.ResultShards).To(BeZero())
+		})
+
+		Context("with platform repository IDs", func() {
+			newTrackedGitRepo := func(name, specName, repoID string) {
+				existing := newGitRepo(name, specName)
+				Expect(controllerutil.SetControllerReference(instance, existing, scheme)).To(Succeed())
+				Expect(fakeClient.Create(ctx, existing)).To(Succeed())
+
+				existing.Status.RepositoryID = repoID
+				Expect(fakeClient.Status().Update(ctx, existing)).To(Succeed())
+			}
+
+			writeResult := func(repos []string, ids map[string]string) {
+				_, err := pkgdiscovery.WriteResult(
+					ctx, fakeClient, instance, DiscoveryName(reconciler.req), repos, ids, pkgdiscovery.DefaultMaxShardBytes,
+				)
+				Expect(err).ToNot(HaveOccurred())
+			}
+
+			It("should record the repository ID of new GitRepos", func() {
+				writeResult([]string{"org/repo1"}, map[string]string{"org/repo1": "1"})
+
+				_, err := reconciler.reconcileGitRepos(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				repo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{
+					Namespace: "default", Name: "test-discovery-org-repo1",
+				}, repo)).To(Succeed())
+				Expect(repo.Status.RepositoryID).To(Equal("1"))
+			})
+
+			It("should keep the GitRepo of a renamed repository", func() {
+				newTrackedGitRepo("test-discovery-org-old", "org/old", "42")
+				writeResult([]string{"org/new"}, map[string]string{"org/new": "42"})
+
+				_, err := reconciler.reconcileGitRepos(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				gitRepos := &renovatev1beta1.GitRepoList{}
+				Expect(fakeClient.List(ctx, gitRepos)).To(Succeed())
+				Expect(gitRepos.Items).To(HaveLen(1))
+				Expect(gitRepos.Items[0].Name).To(Equal("test-discovery-org-old"))
+				Expect(gitRepos.Items[0].Spec.Name).To(Equal("org/new"))
+				Expect(gitRepos.Items[0].Status.RepositoryID).To(Equal("42"))
+			})
+
+			It("should not take over the GitRepo of a repository renamed away from the name", func() {
+				newTrackedGitRepo("test-discovery-org-a", "org/a", "1")
+				writeResult([]string{"org/a", "org/b"}, map[string]string{"org/a": "2", "org/b": "1"})
+
+				_, err := reconciler.reconcileGitRepos(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				renamed := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{
+					Namespace: "default", Name: "test-discovery-org-a",
+				}, renamed)).To(Succeed())
+				Expect(renamed.Spec.Name).To(Equal("org/b"))
+
+				created := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{
+					Namespace: "default", Name: "test-discovery-org-a-2",
+				}, created)).To(Succeed())
+				Expect(created.Spec.Name).To(Equal("org/a"))
+				Expect(created.Status.RepositoryID).To(Equal("2"))
+			})
+
+			It("should resolve the repository IDs of a result without IDs", func() {
+				tokenSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "platform-secret", Namespace: "default"},
+					Data:       map[string][]byte{"token": []byte("test-token")},
+				}
+				Expect(fakeClient.Create(ctx, tokenSecret)).To(Succeed())
+
+				mockMgr.On("ListRepos", mock.Anything, provider.ListReposOptions{}).
+					Return([]provider.Repo{{Name: "org/new", ID: "42"}}, nil).
+					Once()
+
+				newTrackedGitRepo("test-discovery-org-old", "org/old", "42")
+				writeResult([]string{"org/new"}, nil)
+
+				_, err := reconciler.reconcileGitRepos(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				gitRepos := &renovatev1beta1.GitRepoList{}
+				Expect(fakeClient.List(ctx, gitRepos)).To(Succeed())
+				Expect(gitRepos.Items).To(HaveLen(1))
+				Expect(gitRepos.Items[0].Name).To(Equal("test-discovery-org-old"))
+				Expect(gitRepos.Items[0].Spec.Name).To(Equal("org/new"))
+			})
+
+			It("should resolve the repository IDs once per discovery result", func() {
+				tokenSecret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{Name: "platform-secret", Namespace: "default"},
+					Data:       map[string][]byte{"token": []byte("test-token")},
+				}
+				Expect(fakeClient.Create(ctx, tokenSecret)).To(Succeed())
+
+				mockMgr.On("ListRepos", mock.Anything, provider.ListReposOptions{}).
+					Return([]provider.Repo{{Name: "org/repo1", ID: "1"}, {Name: "org/repo2", ID: "2"}}, nil).
+					Twice()
+
+				writeResult([]string{"org/repo1"}, nil)
+
+				for range 2 {
+					_, err := reconciler.reconcileGitRepos(ctx)
+					Expect(err).ToNot(HaveOccurred())
+				}
+
+				mockMgr.AssertNumberOfCalls(GinkgoT(), "ListRepos", 1)
+
+				writeResult([]string{"org/repo1", "org/repo2"}, nil)
+
+				_, err := reconciler.reconcileGitRepos(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				mockMgr.AssertNumberOfCalls(GinkgoT(), "ListRepos", 2)
+
+				repo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{
+					Namespace: "default", Name: "test-discovery-org-repo2",
+				}, repo)).To(Succeed())
+				Expect(repo.Status.RepositoryID).To(Equal("2"))
+			})
+
+			It("should sync a result without IDs if the IDs cannot be resolved", func() {
+				writeResult([]string{"org/repo1"}, nil)
+
+				_, err := reconciler.reconcileGitRepos(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				repo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{
+					Namespace: "default", Name: "test-discovery-org-repo1",
+				}, repo)).To(Succeed())
+				Expect(repo.Status.RepositoryID).To(BeEmpty())
+			})
+
+			It("should reuse the GitRepo of a recreated repository", func() {
+				newTrackedGitRepo("test-discovery-org-a", "org/a", "1")
+				writeResult([]string{"org/a"}, map[string]string{"org/a": "2"})
+
+				_, err := reconciler.reconcileGitRepos(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				gitRepos := &renovatev1beta1.GitRepoList{}
+				Expect(fakeClient.List(ctx, gitRepos)).To(Succeed())
+				Expect(gitRepos.Items).To(HaveLen(1))
+				Expect(gitRepos.Items[0].Status.RepositoryID).To(Equal("2"))
+			})
+		})
+
+		Context("with statically configured repositories", func() {
+			BeforeEach(func() {
+				instance.Spec.Repositories = []renovatev1beta1.RepositorySpec{
+					{
+						Name:      "mirror/repo",
+						ConfigRef: "mirror-config",
+						Webhooks:  &renovatev1beta1.WebhooksSpec{Enabled: new(false)},
+						Overrides: &renovatev1beta1.GitRepoOverrides{Image: "renovate/renovate:full"},
+					},
+				}
+			})
+
+			It("should manage them alongside the discovered repositories", func() {
+				cm := createDiscoveryCM(DiscoveryName(reconciler.req), []string{"org/repo1"})
+				Expect(fakeClient.Create(ctx, cm)).To(Succeed())
+
+				_, err := reconciler.reconcileGitRepos(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				gitRepos := &renovatev1beta1.GitRepoList{}
+				Expect(fakeClient.List(ctx, gitRepos)).To(Succeed())
+				Expect(gitRepos.Items).To(HaveLen(2))
+
+				static := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{
+					Namespace: "default", Name: "test-discovery-mirror-repo",
+				}, static)).To(Succeed())
+				Expect(static.Spec.Name).To(Equal("mirror/repo"))
+				Expect(static.Spec.ConfigRef).To(Equal("mirror-config"))
+				Expect(static.GetWebhooksEnabled()).To(BeFalse())
+				Expect(static.Spec.Overrides).To(HaveField("Image", "renovate/renovate:full"))
+				Expect(metav1.IsControlledBy(static, instance)).To(BeTrue())
+			})
+
+			It("should sync them before the first discovery result exists", func() {
+				_, err := reconciler.reconcileGitRepos(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				gitRepos := &renovatev1beta1.GitRepoList{}
+				Expect(fakeClient.List(ctx, gitRepos)).To(Succeed())
+				Expect(gitRepos.Items).To(HaveLen(1))
+				Expect(gitRepos.Items[0].Spec.Name).To(Equal("mirror/repo"))
+			})
+
+			It("should only manage them and prune the rest in static mode", func() {
+				instance.Spec.Mode = renovatev1beta1.DiscoveryMode_STATIC
+
+				cm := createDiscoveryCM(DiscoveryName(reconciler.req), []string{"org/repo1"})
+				Expect(fakeClient.Create(ctx, cm)).To(Succeed())
+
+				previous := newGitRepo("test-discovery-org-old", "org/old")
+				Expect(controllerutil.SetControllerReference(instance, previous, scheme)).To(Succeed())
+				Expect(fakeClient.Create(ctx, previous)).To(Succeed())
+
+				_, err := reconciler.reconcileGitRepos(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				gitRepos := &renovatev1beta1.GitRepoList{}
+				Expect(fakeClient.List(ctx, gitRepos)).To(Succeed())
+				Expect(gitRepos.Items).To(HaveLen(1))
+				Expect(gitRepos.Items[0].Spec.Name).To(Equal("mirror/repo"))
+			})
+		})
+
 		It("should skip ConfigMaps not controlled by the instance", func() {
 			cm := &corev1.ConfigMap{
-				ObjectMeta: metav1.ObjectMeta{Name: "stranger-danger", Namespace: "default"},
+				ObjectMeta: metav1.ObjectMeta{Name: DiscoveryName(reconciler.req), Namespace: "default"},
 				Data:       map[string]string{"repositories": `["repo1"]`},
 			}
 			Expect(fakeClient.Create(ctx, cm)).To(Succeed())
@@ -120,7 +411,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 			Expect(gitRepos.Items).To(BeEmpty())
 		})
 
-		It("should handle errors when listing ConfigMaps", func() {
+		It("should handle errors when reading the discovery result", func() {
 			mockClient := &mockErrorClient{}
 			reconciler := &Reconciler{Client: mockClient, scheme: scheme, instance: instance}
 
@@ -129,7 +420,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 		})
 
 		It("should handle errors when creating or updating GitRepos", func() {
-			cm := createDiscoveryCM("test-config", []string{"repo1"})
+			cm := createDiscoveryCM(DiscoveryName(reconciler.req), []string{"repo1"})
 			Expect(fakeClient.Create(ctx, cm)).To(Succeed())
 
 			mockClient := &mockErrorClient{}
@@ -140,7 +431,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 		})
 
 		It("should handle errors when pruning orphaned repos", func() {
-			cm := createDiscoveryCM("test-config", []string{"repo1"})
+			cm := createDiscoveryCM(DiscoveryName(reconciler.req), []string{"repo1"})
 			Expect(fakeClient.Create(ctx, cm)).To(Succeed())
 
 			mockClient := &mockErrorClient{}
@@ -153,7 +444,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 		It("should handle invalid JSON in ConfigMap", func() {
 			cm := &corev1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-config",
+					Name:      DiscoveryName(reconciler.req),
 					Namespace: "default",
 				},
 				Data: map[string]string{"repositories": `invalid-json`},
@@ -172,7 +463,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 		It("should handle missing 'repositories' key in ConfigMap", func() {
 			cm := &corev1.ConfigMap{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "test-config",
+					Name:      DiscoveryName(reconciler.req),
 					Namespace: "default",
 				},
 				Data: map[string]string{"other-key": `value`},
@@ -224,7 +515,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 					}, nil).
 					Once()
 
-				cm := createDiscoveryCM("test-config", []string{"real-repo", "forked-repo"})
+				cm := createDiscoveryCM(DiscoveryName(reconciler.req), []string{"real-repo", "forked-repo"})
 				Expect(fakeClient.Create(ctx, cm)).To(Succeed())
 
 				_, err := reconciler.reconcileGitRepos(ctx)
@@ -238,10 +529,10 @@ var _ = Describe("GitRepo Reconciliation", func() {
 		})
 	})
 
-	Describe("filterRepos", func() {
+	Describe("newRepoFilter", func() {
 		It("should return the input unchanged when skipForks is disabled and no topics are set", func() {
 			repos := []string{"a", "b", "c"}
-			result, err := reconciler.filterRepos(ctx, repos)
+			result, err := filterRepos(ctx, reconciler, repos)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(result).To(Equal(repos))
 		})
@@ -278,7 +569,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 				}, nil).
 				Once()
 
-			result, err := reconciler.filterRepos(ctx, []string{"real", "forked", "another"})
+			result, err := filterRepos(ctx, reconciler, []string{"real", "forked", "another"})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(result).To(Equal([]string{"real", "another"}))
 		})
@@ -313,7 +604,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 				{Name: "matching-repo"},
 			}, nil).Once()
 
-			result, err := reconciler.filterRepos(ctx, []string{"matching-repo", "non-matching-repo"})
+			result, err := filterRepos(ctx, reconciler, []string{"matching-repo", "non-matching-repo"})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(result).To(Equal([]string{"matching-repo"}))
 		})
@@ -351,7 +642,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 				{Name: "matching-repo", IsFork: false},
 			}, nil).Once()
 
-			result, err := reconciler.filterRepos(ctx, []string{"matching-repo", "non-matching-repo"})
+			result, err := filterRepos(ctx, reconciler, []string{"matching-repo", "non-matching-repo"})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(result).To(Equal([]string{"matching-repo"}))
 		})
@@ -389,7 +680,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 				{Name: "active-repo"},
 			}, nil).Once()
 
-			result, err := reconciler.filterRepos(ctx, []string{"active-repo", "pending-delete-repo"})
+			result, err := filterRepos(ctx, reconciler, []string{"active-repo", "pending-delete-repo"})
 			Expect(err).ToNot(HaveOccurred())
 			Expect(result).To(Equal([]string{"active-repo"}))
 		})
@@ -427,7 +718,8 @@ var _ = Describe("GitRepo Reconciliation", func() {
 			Expect(fakeClient.Create(ctx, orphan)).To(Succeed())
 
 			discovered := map[string]bool{"keep-me": true}
-			Expect(reconciler.pruneOrphanedRepos(ctx, discovered)).To(Succeed())
+			_, err := reconciler.pruneOrphanedRepos(ctx, discovered)
+			Expect(err).ToNot(HaveOccurred())
 
 			list := &renovatev1beta1.GitRepoList{}
 			Expect(fakeClient.List(ctx, list)).To(Succeed())
@@ -439,7 +731,8 @@ var _ = Describe("GitRepo Reconciliation", func() {
 			externalRepo := newGitRepo("other-controller-repo", "some-repo")
 			Expect(fakeClient.Create(ctx, externalRepo)).To(Succeed())
 
-			Expect(reconciler.pruneOrphanedRepos(ctx, map[string]bool{})).To(Succeed())
+			_, err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{})
+			Expect(err).ToNot(HaveOccurred())
 
 			list := &renovatev1beta1.GitRepoList{}
 			Expect(fakeClient.List(ctx, list)).To(Succeed())
@@ -450,7 +743,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 			mockClient := &mockErrorClient{}
 			reconciler := &Reconciler{Client: mockClient, scheme: scheme, instance: instance}
 
-			err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{})
+			_, err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{})
 			Expect(err).To(HaveOccurred())
 		})
 
@@ -462,7 +755,7 @@ var _ = Describe("GitRepo Reconciliation", func() {
 			mockClient := &mockErrorClient{}
 			reconciler := &Reconciler{Client: mockClient, scheme: scheme, instance: instance}
 
-			err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{})
+			_, err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{})
 			Expect(err).To(HaveOccurred())
 
 			list := &renovatev1beta1.GitRepoList{}
@@ -472,6 +765,23 @@ var _ = Describe("GitRepo Reconciliation", func() {
 	})
 })
 
+func filterRepos(ctx context.Context, r *Reconciler, repos []string) ([]string, error) {
+	keepRepo, _, err := r.newRepoFilter(ctx, "test-run")
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := make([]string, 0, len(repos))
+
+	for _, repo := range repos {
+		if keepRepo(repo) {
+			filtered = append(filtered, repo)
+		}
+	}
+
+	return filtered, nil
+}
+
 type mockErrorClient struct {
 	client.Client
 }
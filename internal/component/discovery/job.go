@@ -60,6 +60,10 @@ func (r *Reconciler) reconcileJob(ctx context.Context) (*ctrl.Result, error) {
 		log.V(1).Info("Discovery is suspended: suppressing scheduled run")
 	}
 
+	if decision.Trigger == scheduler.TriggerBlackout {
+		log.V(1).Info("Discovery is in a blackout window: suppressing scheduled run", "until", decision.NextRun)
+	}
+
 	if decision.ShouldRun {
 		if err := r.runDiscoveryJob(ctx, discoveryLabels, decision.Trigger); err != nil {
 			if errors.Is(err, errJobNotCreated) {
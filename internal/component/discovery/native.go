@@ -0,0 +1,158 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/component/renovator"
+	"github.com/thegeeklab/renovate-operator/internal/metrics"
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+	"github.com/thegeeklab/renovate-operator/internal/scheduler"
+	pkgdiscovery "github.com/thegeeklab/renovate-operator/pkg/discovery"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileNativeDiscovery checks if discovery should run, lists the
+// repositories from the platform API and schedules the next run. The result is
+// written to the same ConfigMaps a discovery Job would create.
+func (r *Reconciler) reconcileNativeDiscovery(ctx context.Context) (*ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	decision, err := r.scheduler.Evaluate(r.instance, renovator.HasRenovatorOperationDiscover)
+	if err != nil {
+		return &ctrl.Result{}, fmt.Errorf("failed to evaluate schedule: %w", err)
+	}
+
+	if decision.Trigger == scheduler.TriggerSuspended {
+		log.V(1).Info("Discovery is suspended: suppressing scheduled run")
+	}
+
+	if decision.Trigger == scheduler.TriggerBlackout {
+		log.V(1).Info("Discovery is in a blackout window: suppressing scheduled run", "until", decision.NextRun)
+	}
+
+	if decision.ShouldRun {
+		log.Info("Discovery run active", "trigger", decision.Trigger, "mode", renovatev1beta1.DiscoveryMode_NATIVE)
+
+		runErr := r.runNativeDiscovery(ctx)
+
+		if err := r.updateNativeStatus(ctx, runErr); err != nil {
+			log.Error(err, "Failed to update discovery status")
+		}
+
+		if runErr != nil {
+			return &ctrl.Result{}, fmt.Errorf("failed to run native discovery: %w", runErr)
+		}
+
+		if err := r.scheduler.CompleteRun(ctx, r.instance, renovator.RemoveRenovatorOperation); err != nil {
+			return &ctrl.Result{}, fmt.Errorf("failed to complete run: %w", err)
+		}
+	}
+
+	nextDecision, err := r.scheduler.Evaluate(r.instance, renovator.HasRenovatorOperationDiscover)
+	if err != nil {
+		return &ctrl.Result{}, fmt.Errorf("failed to re-evaluate schedule: %w", err)
+	}
+
+	now := time.Now()
+	if nextDecision.NextRun.After(now) {
+		waitDuration := nextDecision.NextRun.Sub(now)
+		log.V(1).Info("Next discovery scheduled", "time", nextDecision.NextRun, "wait", waitDuration)
+
+		return &ctrl.Result{RequeueAfter: waitDuration}, nil
+	}
+
+	return &ctrl.Result{}, nil
+}
+
+// runNativeDiscovery lists the repositories matching the discovery options
+// and stores them with their platform IDs in the sharded discovery result
+// ConfigMaps.
+func (r *Reconciler) runNativeDiscovery(ctx context.Context) error {
+	providerManager, err := r.newProviderManager(ctx)
+	if err != nil {
+		return err
+	}
+
+	platformRepos, err := providerManager.ListRepos(ctx, provider.ListReposOptions{
+		SkipForks:           r.instance.GetSkipForks(),
+		Topics:              r.instance.GetTopics(),
+		SkipPendingDeletion: r.instance.GetSkipPendingDeletion(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	repos := make([]string, 0, len(platformRepos))
+	ids := make(map[string]string, len(platformRepos))
+
+	for _, repo := range platformRepos {
+		repos = append(repos, repo.Name)
+
+		if repo.ID != "" {
+			ids[repo.Name] = repo.ID
+		}
+	}
+
+	repos, err = matchFilters(repos, r.instance.Spec.Filter)
+	if err != nil {
+		return err
+	}
+
+	if _, err := pkgdiscovery.WriteResult(
+		ctx, r.Client, r.instance, DiscoveryName(r.req), repos, ids, pkgdiscovery.DefaultMaxShardBytes,
+	); err != nil {
+		return fmt.Errorf("failed to reconcile discovery result: %w", err)
+	}
+
+	return nil
+}
+
+// updateNativeStatus updates the Discovery status conditions and metrics
+// based on the outcome of a native discovery run.
+func (r *Reconciler) updateNativeStatus(ctx context.Context, runErr error) error {
+	patch := client.MergeFrom(r.instance.DeepCopy())
+	now := metav1.Now()
+	runStatus := metrics.StatusSucceeded
+
+	if runErr != nil {
+		r.instance.SetCondition(
+			renovatev1beta1.DiscoveryConditionDiscoveryFailed,
+			metav1.ConditionTrue,
+			"ListFailed", runErr.Error(),
+		)
+		r.instance.RemoveCondition(renovatev1beta1.DiscoveryConditionDiscoveryCompleted)
+
+		runStatus = metrics.StatusFailed
+	} else {
+		r.instance.SetCondition(
+			renovatev1beta1.DiscoveryConditionDiscoveryCompleted,
+			metav1.ConditionTrue,
+			"ListSucceeded", "Native discovery completed successfully",
+		)
+		r.instance.RemoveCondition(renovatev1beta1.DiscoveryConditionDiscoveryFailed)
+	}
+
+	r.instance.SetCondition(
+		renovatev1beta1.DiscoveryConditionDiscoveryRunning,
+		metav1.ConditionFalse,
+		"NoJobActive", "No discovery job is running",
+	)
+	r.instance.SetLastDiscoveryTime(&now)
+
+	if r.metrics != nil {
+		renovatorLabel := r.instance.Labels[renovatev1beta1.LabelRenovator]
+		r.metrics.RecordDiscoveryJob(r.instance.Namespace, renovatorLabel, r.instance.Name, runStatus)
+	}
+
+	if err := r.Status().Patch(ctx, r.instance, patch); err != nil {
+		return fmt.Errorf("failed to patch discovery status: %w", err)
+	}
+
+	return nil
+}
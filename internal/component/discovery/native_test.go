@@ -0,0 +1,146 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+	"github.com/thegeeklab/renovate-operator/internal/provider/factory"
+	"github.com/thegeeklab/renovate-operator/internal/provider/mocks"
+	"github.com/thegeeklab/renovate-operator/internal/scheduler"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	fakeclock "k8s.io/utils/clock/testing"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Native Discovery", func() {
+	var (
+		fakeClient client.Client
+		reconciler *Reconciler
+		instance   *renovatev1beta1.Discovery
+		ctx        context.Context
+		scheme     *runtime.Scheme
+		mockMgr    *mocks.ProviderManager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(renovatev1beta1.AddToScheme(scheme)).To(Succeed())
+
+		instance = &renovatev1beta1.Discovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-discovery",
+				Namespace: "default",
+				UID:       "test-uid",
+			},
+			Spec: renovatev1beta1.DiscoverySpec{
+				JobSpec:   renovatev1beta1.JobSpec{Schedule: "*/5 * * * *"},
+				Mode:      renovatev1beta1.DiscoveryMode_NATIVE,
+				Filter:    []string{"thegeeklab/*"},
+				SkipForks: new(true),
+				Topics:    []string{"renovate"},
+			},
+		}
+
+		tokenSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform-secret", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("test-token")},
+		}
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(instance, tokenSecret).
+			WithStatusSubresource(instance).
+			Build()
+
+		reconciler = &Reconciler{
+			Client: fakeClient,
+			scheme: scheme,
+			scheduler: scheduler.NewManager(
+				fakeClient, scheme, fakeclock.NewFakeClock(time.Date(2026, 2, 27, 15, 0, 0, 0, time.UTC)),
+			),
+			req: ctrl.Request{
+				NamespacedName: types.NamespacedName{Namespace: instance.Namespace, Name: instance.Name},
+			},
+			instance: instance,
+			renovate: &renovatev1beta1.RenovateConfig{
+				Spec: renovatev1beta1.RenovateConfigSpec{
+					Platform: renovatev1beta1.PlatformSpec{
+						Type: renovatev1beta1.PlatformType_GITHUB,
+						Token: corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								Key:                  "token",
+								LocalObjectReference: corev1.LocalObjectReference{Name: "platform-secret"},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		mockMgr = mocks.NewProviderManager(GinkgoT())
+		reconciler.providerFactory = func(
+			context.Context, factory.PlatformConfig,
+		) (provider.ProviderManager, error) {
+			return mockMgr, nil
+		}
+	})
+
+	Describe("reconcileNativeDiscovery", func() {
+		It("should write the filtered repositories to the discovery result ConfigMap", func() {
+			mockMgr.On("ListRepos", mock.Anything, provider.ListReposOptions{
+				SkipForks: true,
+				Topics:    []string{"renovate"},
+			}).Return([]provider.Repo{
+				{Name: "thegeeklab/renovate-operator"},
+				{Name: "other/project"},
+			}, nil).Once()
+
+			_, err := reconciler.reconcileNativeDiscovery(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			cm := &corev1.ConfigMap{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{
+				Namespace: "default", Name: DiscoveryName(reconciler.req),
+			}, cm)).To(Succeed())
+			Expect(cm.Data).To(HaveKeyWithValue("repositories", `["thegeeklab/renovate-operator"]`))
+			Expect(metav1.IsControlledBy(cm, instance)).To(BeTrue())
+
+			jobs := &batchv1.JobList{}
+			Expect(fakeClient.List(ctx, jobs)).To(Succeed())
+			Expect(jobs.Items).To(BeEmpty())
+
+			Expect(instance.GetLastDiscoveryTime()).NotTo(BeNil())
+			Expect(instance.GetLastScheduleTime()).NotTo(BeNil())
+			Expect(instance.GetCondition(renovatev1beta1.DiscoveryConditionDiscoveryCompleted)).NotTo(BeNil())
+		})
+
+		It("should mark the discovery as failed when listing repositories fails", func() {
+			mockMgr.On("ListRepos", mock.Anything, mock.Anything).
+				Return(nil, errors.New("api unavailable")).Once()
+
+			_, err := reconciler.reconcileNativeDiscovery(ctx)
+			Expect(err).To(MatchError(ContainSubstring("api unavailable")))
+
+			failed := instance.GetCondition(renovatev1beta1.DiscoveryConditionDiscoveryFailed)
+			Expect(failed).NotTo(BeNil())
+			Expect(failed.Status).To(Equal(metav1.ConditionTrue))
+			Expect(instance.GetLastScheduleTime()).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,177 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// overrideRule is an override rule of the discovery instance with compiled
+// match criteria.
+type overrideRule struct {
+	overrides *renovatev1beta1.GitRepoOverrides
+	filters   []func(string) bool
+	// topicRepos holds the repositories having the topics of the rule, it is
+	// nil if the rule is not restricted by topics.
+	topicRepos map[string]struct{}
+	selector   labels.Selector
+}
+
+// newOverrideRules compiles the override rules of the discovery instance. The
+// repositories matching the topics of a rule are listed from the platform once
+// per reconciliation.
+func (r *Reconciler) newOverrideRules(ctx context.Context) ([]overrideRule, error) {
+	var providerManager provider.ProviderManager
+
+	rules := make([]overrideRule, 0, len(r.instance.Spec.OverrideRules))
+
+	for i := range r.instance.Spec.OverrideRules {
+		spec := &r.instance.Spec.OverrideRules[i]
+		rule := overrideRule{overrides: &spec.Overrides}
+
+		for _, filter := range spec.Filter {
+			matcher, err := newFilterMatcher(filter)
+			if err != nil {
+				return nil, fmt.Errorf("invalid override rule %d: %w", i, err)
+			}
+
+			rule.filters = append(rule.filters, matcher)
+		}
+
+		if spec.Selector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(spec.Selector)
+			if err != nil {
+				return nil, fmt.Errorf("invalid selector of override rule %d: %w", i, err)
+			}
+
+			rule.selector = selector
+		}
+
+		if len(spec.Topics) > 0 {
+			if providerManager == nil {
+				var err error
+
+				if providerManager, err = r.newProviderManager(ctx); err != nil {
+					return nil, err
+				}
+			}
+
+			repos, err := providerManager.ListRepos(ctx, provider.ListReposOptions{Topics: spec.Topics})
+			if err != nil {
+				return nil, fmt.Errorf("failed to list repositories of override rule %d: %w", i, err)
+			}
+
+			rule.topicRepos = make(map[string]struct{}, len(repos))
+			for _, repo := range repos {
+				rule.topicRepos[repo.Name] = struct{}{}
+			}
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+// matches returns true if the repository and the labels of its GitRepo match
+// all criteria of the rule.
+func (o *overrideRule) matches(repoName string, repoLabels map[string]string) bool {
+	if len(o.filters) > 0 {
+		matched := false
+
+		for _, filter := range o.filters {
+			if filter(repoName) {
+				matched = true
+
+				break
+			}
+		}
+
+		if !matched {
+			return false
+		}
+	}
+
+	if o.topicRepos != nil {
+		if _, ok := o.topicRepos[repoName]; !ok {
+			return false
+		}
+	}
+
+	return o.selector == nil || o.selector.Matches(labels.Set(repoLabels))
+}
+
+// resolveOverrides merges the overrides of all rules matching the repository
+// in order. The overrides of a statically configured repository are applied
+// last. It returns nil if no overrides apply.
+func resolveOverrides(
+	rules []overrideRule, repoName string, repoLabels map[string]string, static *renovatev1beta1.GitRepoOverrides,
+) *renovatev1beta1.GitRepoOverrides {
+	var merged *renovatev1beta1.GitRepoOverrides
+
+	apply := func(overrides *renovatev1beta1.GitRepoOverrides) {
+		if merged == nil {
+			merged = &renovatev1beta1.GitRepoOverrides{}
+		}
+
+		mergeOverrides(merged, overrides.DeepCopy())
+	}
+
+	for i := range rules {
+		if rules[i].matches(repoName, repoLabels) {
+			apply(rules[i].overrides)
+		}
+	}
+
+	if static != nil {
+		apply(static)
+	}
+
+	return merged
+}
+
+// mergeOverrides sets all fields configured in src on dst. Environment
+// variables are appended.
+func mergeOverrides(dst, src *renovatev1beta1.GitRepoOverrides) {
+	if src.Suspend != nil {
+		dst.Suspend = src.Suspend
+	}
+
+	if src.Schedule != "" {
+		dst.Schedule = src.Schedule
+	}
+
+	if src.Timezone != "" {
+		dst.Timezone = src.Timezone
+	}
+
+	if src.Image != "" {
+		dst.Image = src.Image
+	}
+
+	if src.Resources != nil {
+		dst.Resources = src.Resources
+	}
+
+	if src.NodeSelector != nil {
+		dst.NodeSelector = src.NodeSelector
+	}
+
+	if src.Tolerations != nil {
+		dst.Tolerations = src.Tolerations
+	}
+
+	if src.Timeout != nil {
+		dst.Timeout = src.Timeout
+	}
+
+	if src.Priority != nil {
+		dst.Priority = src.Priority
+	}
+
+	dst.ExtraEnv = append(dst.ExtraEnv, src.ExtraEnv...)
+}
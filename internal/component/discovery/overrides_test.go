@@ -0,0 +1,146 @@
+package discovery
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+	"github.com/thegeeklab/renovate-operator/internal/provider/factory"
+	"github.com/thegeeklab/renovate-operator/internal/provider/mocks"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Override Rules", func() {
+	var (
+		reconciler *Reconciler
+		instance   *renovatev1beta1.Discovery
+		ctx        context.Context
+		mockMgr    *mocks.ProviderManager
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(renovatev1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		instance = &renovatev1beta1.Discovery{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-discovery", Namespace: "default"},
+		}
+
+		tokenSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform-secret", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("test-token")},
+		}
+
+		reconciler = &Reconciler{
+			Client:   fake.NewClientBuilder().WithScheme(scheme).WithObjects(tokenSecret).Build(),
+			scheme:   scheme,
+			instance: instance,
+			renovate: &renovatev1beta1.RenovateConfig{
+				Spec: renovatev1beta1.RenovateConfigSpec{
+					Platform: renovatev1beta1.PlatformSpec{
+						Type: renovatev1beta1.PlatformType_GITHUB,
+						Token: corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								Key:                  "token",
+								LocalObjectReference: corev1.LocalObjectReference{Name: "platform-secret"},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		mockMgr = mocks.NewProviderManager(GinkgoT())
+		reconciler.providerFactory = func(
+			context.Context, factory.PlatformConfig,
+		) (provider.ProviderManager, error) {
+			return mockMgr, nil
+		}
+	})
+
+	Describe("resolveOverrides", func() {
+		It("should return nil if no rule matches", func() {
+			instance.Spec.OverrideRules = []renovatev1beta1.OverrideRuleSpec{
+				{Filter: []string{"org/large-*"}, Overrides: renovatev1beta1.GitRepoOverrides{Image: "full"}},
+			}
+
+			rules, err := reconciler.newOverrideRules(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolveOverrides(rules, "org/small", nil, nil)).To(BeNil())
+		})
+
+		It("should merge the matching rules in order and apply static overrides last", func() {
+			instance.Spec.OverrideRules = []renovatev1beta1.OverrideRuleSpec{
+				{
+					Overrides: renovatev1beta1.GitRepoOverrides{
+						Image:    "renovate/renovate:slim",
+						ExtraEnv: []corev1.EnvVar{{Name: "A", Value: "a"}},
+					},
+				},
+				{
+					Filter: []string{"org/large-*"},
+					Overrides: renovatev1beta1.GitRepoOverrides{
+						Image:    "renovate/renovate:full",
+						Timeout:  &metav1.Duration{Duration: time.Hour},
+						ExtraEnv: []corev1.EnvVar{{Name: "B", Value: "b"}},
+					},
+				},
+				{
+					Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"tier": "critical"}},
+					Overrides: renovatev1beta1.GitRepoOverrides{
+						Schedule: "0 * * * *",
+						Priority: new(int32(10)),
+					},
+				},
+			}
+
+			rules, err := reconciler.newOverrideRules(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			overrides := resolveOverrides(rules, "org/large-mono", map[string]string{"tier": "critical"},
+				&renovatev1beta1.GitRepoOverrides{Suspend: new(true)})
+			Expect(overrides).NotTo(BeNil())
+			Expect(overrides.Image).To(Equal("renovate/renovate:full"))
+			Expect(overrides.Timeout.Duration).To(Equal(time.Hour))
+			Expect(overrides.Schedule).To(Equal("0 * * * *"))
+			Expect(*overrides.Priority).To(Equal(int32(10)))
+			Expect(*overrides.Suspend).To(BeTrue())
+			Expect(overrides.ExtraEnv).To(Equal([]corev1.EnvVar{{Name: "A", Value: "a"}, {Name: "B", Value: "b"}}))
+
+			Expect(instance.Spec.OverrideRules[0].Overrides.ExtraEnv).To(HaveLen(1))
+		})
+
+		It("should match rules by repository topics", func() {
+			instance.Spec.OverrideRules = []renovatev1beta1.OverrideRuleSpec{
+				{Topics: []string{"monorepo"}, Overrides: renovatev1beta1.GitRepoOverrides{Image: "full"}},
+			}
+
+			mockMgr.On("ListRepos", mock.Anything, provider.ListReposOptions{Topics: []string{"monorepo"}}).
+				Return([]provider.Repo{{Name: "org/mono"}}, nil).Once()
+
+			rules, err := reconciler.newOverrideRules(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(resolveOverrides(rules, "org/mono", nil, nil)).To(HaveField("Image", "full"))
+			Expect(resolveOverrides(rules, "org/other", nil, nil)).To(BeNil())
+		})
+
+		It("should reject invalid filters", func() {
+			instance.Spec.OverrideRules = []renovatev1beta1.OverrideRuleSpec{
+				{Filter: []string{"/[/"}},
+			}
+
+			_, err := reconciler.newOverrideRules(ctx)
+			Expect(err).To(MatchError(ContainSubstring("invalid override rule 0")))
+		})
+	})
+})
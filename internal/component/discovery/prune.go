@@ -0,0 +1,159 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/controller"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// pruneOrphanedRepos handles GitRepos that are no longer present in the discovery
+// result according to the prune policy of the discovery instance. It returns the
+// time until the next grace period of an orphaned GitRepo expires.
+func (r *Reconciler) pruneOrphanedRepos(ctx context.Context, discovered map[string]bool) (time.Duration, error) {
+	var pruneErrors []error
+
+	log := logf.FromContext(ctx)
+
+	existingRepos := &renovatev1beta1.GitRepoList{}
+	if err := r.List(ctx, existingRepos, client.InNamespace(r.instance.Namespace)); err != nil {
+		return 0, fmt.Errorf("failed to list existing GitRepos: %w", err)
+	}
+
+	managed := 0
+	orphans := make([]*renovatev1beta1.GitRepo, 0)
+
+	for i := range existingRepos.Items {
+		repo := &existingRepos.Items[i]
+
+		if !metav1.IsControlledBy(repo, r.instance) {
+			continue
+		}
+
+		managed++
+
+		if !discovered[repo.Spec.Name] {
+			orphans = append(orphans, repo)
+
+			continue
+		}
+
+		if err := r.setOrphaned(ctx, repo, false); err != nil {
+			pruneErrors = append(pruneErrors, err)
+		}
+	}
+
+	if r.pruneLimitExceeded(len(orphans), managed) {
+		message := fmt.Sprintf("Pruning %d of %d GitRepos exceeds the configured limits", len(orphans), managed)
+		log.Info("Withholding prune of orphaned GitRepos", "orphaned", len(orphans), "managed", managed)
+
+		r.instance.SetCondition(
+			renovatev1beta1.DiscoveryConditionPruneWithheld,
+			metav1.ConditionTrue,
+			renovatev1beta1.ReasonPruneWithheld, message,
+		)
+		controller.Eventf(
+			r.recorder, r.instance,
+			renovatev1beta1.EventTypeWarning,
+			renovatev1beta1.ReasonPruneWithheld,
+			renovatev1beta1.EventActionPruning,
+			"%s", message,
+		)
+
+		for _, repo := range orphans {
+			if err := r.setOrphaned(ctx, repo, true); err != nil {
+				pruneErrors = append(pruneErrors, err)
+			}
+		}
+
+		return 0, errors.Join(pruneErrors...)
+	}
+
+	r.instance.RemoveCondition(renovatev1beta1.DiscoveryConditionPruneWithheld)
+
+	policy := r.instance.GetPrunePolicy()
+	gracePeriod := r.instance.GetPruneGracePeriod()
+	now := time.Now()
+
+	var requeueAfter time.Duration
+
+	for _, repo := range orphans {
+		if policy == renovatev1beta1.PrunePolicy_ORPHAN || gracePeriod > 0 {
+			if err := r.setOrphaned(ctx, repo, true); err != nil {
+				pruneErrors = append(pruneErrors, err)
+
+				continue
+			}
+
+			if policy == renovatev1beta1.PrunePolicy_ORPHAN {
+				continue
+			}
+
+			orphanedSince := repo.GetCondition(renovatev1beta1.GitRepoConditionOrphaned).LastTransitionTime
+			if remaining := orphanedSince.Add(gracePeriod).Sub(now); remaining > 0 {
+				if requeueAfter == 0 || remaining < requeueAfter {
+					requeueAfter = remaining
+				}
+
+				continue
+			}
+		}
+
+		log.Info("Deleting orphaned GitRepo", "name", repo.Name)
+
+		if err := r.Delete(ctx, repo); err != nil {
+			log.Error(err, "Failed to delete orphaned GitRepo", "name", repo.Name)
+			pruneErrors = append(pruneErrors, fmt.Errorf("failed to delete GitRepo %s: %w", repo.Name, err))
+		}
+	}
+
+	return requeueAfter, errors.Join(pruneErrors...)
+}
+
+// pruneLimitExceeded returns true if deleting the orphaned GitRepos exceeds the
+// maximum count or percentage configured on the discovery instance.
+func (r *Reconciler) pruneLimitExceeded(orphaned, managed int) bool {
+	prune := r.instance.Spec.Prune
+
+	if prune == nil || orphaned == 0 || r.instance.GetPrunePolicy() == renovatev1beta1.PrunePolicy_ORPHAN {
+		return false
+	}
+
+	if prune.MaxCount != nil && orphaned > int(*prune.MaxCount) {
+		return true
+	}
+
+	return prune.MaxPercentage != nil && orphaned*100 > int(*prune.MaxPercentage)*managed
+}
+
+// setOrphaned adds or removes the Orphaned condition of a GitRepo. The time the
+// condition was added marks the start of the grace period.
+func (r *Reconciler) setOrphaned(ctx context.Context, repo *renovatev1beta1.GitRepo, orphaned bool) error {
+	if (repo.GetCondition(renovatev1beta1.GitRepoConditionOrphaned) != nil) == orphaned {
+		return nil
+	}
+
+	patch := client.MergeFrom(repo.DeepCopy())
+
+	if orphaned {
+		repo.SetCondition(
+			renovatev1beta1.GitRepoConditionOrphaned,
+			metav1.ConditionTrue,
+			"NotDiscovered", "Repository is missing from the discovery result",
+		)
+	} else {
+		repo.RemoveCondition(renovatev1beta1.GitRepoConditionOrphaned)
+	}
+
+	if err := r.Status().Patch(ctx, repo, patch); err != nil {
+		return fmt.Errorf("failed to patch status of GitRepo %s: %w", repo.Name, err)
+	}
+
+	return nil
+}
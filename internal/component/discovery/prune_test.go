@@ -0,0 +1,169 @@
+package discovery
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+var _ = Describe("Prune", func() {
+	var (
+		fakeClient client.Client
+		reconciler *Reconciler
+		instance   *renovatev1beta1.Discovery
+		recorder   *events.FakeRecorder
+		ctx        context.Context
+		scheme     *runtime.Scheme
+	)
+
+	createGitRepo := func(name, specName string, conditions ...metav1.Condition) {
+		repo := &renovatev1beta1.GitRepo{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       renovatev1beta1.GitRepoSpec{Name: specName},
+		}
+		Expect(controllerutil.SetControllerReference(instance, repo, scheme)).To(Succeed())
+		Expect(fakeClient.Create(ctx, repo)).To(Succeed())
+
+		if len(conditions) > 0 {
+			repo.Status.Conditions = conditions
+			Expect(fakeClient.Status().Update(ctx, repo)).To(Succeed())
+		}
+	}
+
+	getGitRepo := func(name string) *renovatev1beta1.GitRepo {
+		repo := &renovatev1beta1.GitRepo{}
+		Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, repo)).To(Succeed())
+
+		return repo
+	}
+
+	countGitRepos := func() int {
+		list := &renovatev1beta1.GitRepoList{}
+		Expect(fakeClient.List(ctx, list)).To(Succeed())
+
+		return len(list.Items)
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(renovatev1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		instance = &renovatev1beta1.Discovery{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-discovery", Namespace: "default", UID: "test-uid"},
+		}
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithStatusSubresource(&renovatev1beta1.GitRepo{}).
+			Build()
+		recorder = events.NewFakeRecorder(10)
+		reconciler = &Reconciler{Client: fakeClient, scheme: scheme, instance: instance, recorder: recorder}
+
+		createGitRepo("test-discovery-a", "org/a")
+		createGitRepo("test-discovery-b", "org/b")
+		createGitRepo("test-discovery-c", "org/c")
+		createGitRepo("test-discovery-d", "org/d")
+	})
+
+	Describe("pruneOrphanedRepos", func() {
+		It("should withhold pruning when the maximum count is exceeded", func() {
+			instance.Spec.Prune = &renovatev1beta1.PruneSpec{MaxCount: new(int32(1))}
+
+			_, err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{"org/a": true, "org/b": true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countGitRepos()).To(Equal(4))
+
+			withheld := instance.GetCondition(renovatev1beta1.DiscoveryConditionPruneWithheld)
+			Expect(withheld).NotTo(BeNil())
+			Expect(withheld.Status).To(Equal(metav1.ConditionTrue))
+			Expect(recorder.Events).To(Receive(ContainSubstring(renovatev1beta1.ReasonPruneWithheld)))
+
+			Expect(getGitRepo("test-discovery-c").GetCondition(renovatev1beta1.GitRepoConditionOrphaned)).NotTo(BeNil())
+			Expect(getGitRepo("test-discovery-a").GetCondition(renovatev1beta1.GitRepoConditionOrphaned)).To(BeNil())
+		})
+
+		It("should withhold pruning when the maximum percentage is exceeded", func() {
+			instance.Spec.Prune = &renovatev1beta1.PruneSpec{MaxPercentage: new(int32(50))}
+
+			_, err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{"org/a": true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countGitRepos()).To(Equal(4))
+			Expect(instance.GetCondition(renovatev1beta1.DiscoveryConditionPruneWithheld)).NotTo(BeNil())
+		})
+
+		It("should prune and clear the withheld condition within the limits", func() {
+			instance.Spec.Prune = &renovatev1beta1.PruneSpec{MaxCount: new(int32(2)), MaxPercentage: new(int32(50))}
+			instance.SetCondition(
+				renovatev1beta1.DiscoveryConditionPruneWithheld, metav1.ConditionTrue,
+				renovatev1beta1.ReasonPruneWithheld, "withheld",
+			)
+
+			_, err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{"org/a": true, "org/b": true})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countGitRepos()).To(Equal(2))
+			Expect(instance.GetCondition(renovatev1beta1.DiscoveryConditionPruneWithheld)).To(BeNil())
+		})
+
+		It("should only mark GitRepos as orphaned with the orphan policy", func() {
+			instance.Spec.Prune = &renovatev1beta1.PruneSpec{
+				Policy:   renovatev1beta1.PrunePolicy_ORPHAN,
+				MaxCount: new(int32(0)),
+			}
+
+			_, err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(countGitRepos()).To(Equal(4))
+			Expect(instance.GetCondition(renovatev1beta1.DiscoveryConditionPruneWithheld)).To(BeNil())
+
+			orphaned := getGitRepo("test-discovery-a").GetCondition(renovatev1beta1.GitRepoConditionOrphaned)
+			Expect(orphaned).NotTo(BeNil())
+			Expect(orphaned.Status).To(Equal(metav1.ConditionTrue))
+		})
+
+		It("should keep orphaned GitRepos until the grace period expired", func() {
+			instance.Spec.Prune = &renovatev1beta1.PruneSpec{GracePeriod: &metav1.Duration{Duration: time.Hour}}
+			createGitRepo("test-discovery-expired", "org/expired", metav1.Condition{
+				Type:               renovatev1beta1.GitRepoConditionOrphaned,
+				Status:             metav1.ConditionTrue,
+				Reason:             "NotDiscovered",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			})
+
+			requeueAfter, err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{
+				"org/a": true, "org/b": true, "org/c": true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(requeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+			Expect(countGitRepos()).To(Equal(4))
+			Expect(getGitRepo("test-discovery-d").GetCondition(renovatev1beta1.GitRepoConditionOrphaned)).NotTo(BeNil())
+		})
+
+		It("should clear the orphaned condition when a GitRepo is discovered again", func() {
+			createGitRepo("test-discovery-back", "org/back", metav1.Condition{
+				Type:               renovatev1beta1.GitRepoConditionOrphaned,
+				Status:             metav1.ConditionTrue,
+				Reason:             "NotDiscovered",
+				LastTransitionTime: metav1.Now(),
+			})
+
+			_, err := reconciler.pruneOrphanedRepos(ctx, map[string]bool{
+				"org/a": true, "org/b": true, "org/c": true, "org/d": true, "org/back": true,
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(getGitRepo("test-discovery-back").GetCondition(renovatev1beta1.GitRepoConditionOrphaned)).To(BeNil())
+		})
+	})
+})
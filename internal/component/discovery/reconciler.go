@@ -9,6 +9,7 @@ import (
 	"github.com/thegeeklab/renovate-operator/internal/scheduler"
 	"github.com/thegeeklab/renovate-operator/pkg/util/reconciler"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
 	"k8s.io/utils/clock"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -18,29 +19,35 @@ type Reconciler struct {
 	client.Client
 	scheme          *runtime.Scheme
 	scheduler       *scheduler.Manager
+	externalURL     string
 	req             ctrl.Request
 	instance        *renovatev1beta1.Discovery
 	renovate        *renovatev1beta1.RenovateConfig
 	providerFactory factory.ProviderFactory
 	metrics         metrics.Recorder
+	recorder        events.EventRecorder
 }
 
 func NewReconciler(
 	c client.Client,
 	scheme *runtime.Scheme,
+	externalURL string,
 	instance *renovatev1beta1.Discovery,
 	renovate *renovatev1beta1.RenovateConfig,
 	metricsRecorder metrics.Recorder,
+	eventRecorder events.EventRecorder,
 ) (*Reconciler, error) {
 	return &Reconciler{
 		Client:          c,
 		scheme:          scheme,
 		scheduler:       scheduler.NewManager(c, scheme, clock.RealClock{}),
+		externalURL:     externalURL,
 		req:             ctrl.Request{NamespacedName: client.ObjectKey{Namespace: instance.Namespace, Name: instance.Name}},
 		instance:        instance,
 		renovate:        renovate,
 		providerFactory: factory.DefaultProviderFactory,
 		metrics:         metricsRecorder,
+		recorder:        eventRecorder,
 	}, nil
 }
 
@@ -52,10 +59,19 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*ctrl.Result, error) {
 		r.reconcileRole,
 		r.reconcileRoleBinding,
 		r.reconcileServiceAccount,
-		r.reconcileJob,
-		r.reconcileGitRepos,
 	}
 
+	switch {
+	case r.instance.GetStaticMode():
+		// Only the statically configured repositories are managed.
+	case r.instance.GetNativeMode():
+		reconcileFuncs = append(reconcileFuncs, r.reconcileNativeDiscovery)
+	default:
+		reconcileFuncs = append(reconcileFuncs, r.reconcileJob)
+	}
+
+	reconcileFuncs = append(reconcileFuncs, r.reconcileGitRepos, r.reconcileWebhooks)
+
 	for _, reconcileFunc := range reconcileFuncs {
 		res, err := reconcileFunc(ctx)
 		if err != nil {
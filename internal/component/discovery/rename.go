@@ -0,0 +1,94 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// repoIndex tracks the GitRepos of a Discovery by the platform ID of their
+// repository to follow renamed and transferred repositories.
+type repoIndex struct {
+	// byID maps platform IDs to the GitRepo of the repository.
+	byID map[string]*renovatev1beta1.GitRepo
+	// byName maps GitRepo names to the platform ID of their repository.
+	byName map[string]string
+	// discovered is the set of platform IDs of the discovery result.
+	discovered map[string]bool
+}
+
+// newRepoIndex indexes the GitRepos controlled by the Discovery that recorded
+// the platform ID of their repository. The ids map repository names of the
+// discovery result to their platform IDs.
+func (r *Reconciler) newRepoIndex(ctx context.Context, ids map[string]string) (*repoIndex, error) {
+	repos := &renovatev1beta1.GitRepoList{}
+	if err := r.List(ctx, repos, client.InNamespace(r.instance.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list existing GitRepos: %w", err)
+	}
+
+	index := &repoIndex{
+		byID:       make(map[string]*renovatev1beta1.GitRepo),
+		byName:     make(map[string]string),
+		discovered: make(map[string]bool, len(ids)),
+	}
+
+	for _, id := range ids {
+		index.discovered[id] = true
+	}
+
+	for i := range repos.Items {
+		repo := &repos.Items[i]
+
+		id := repo.Status.RepositoryID
+		if id == "" || !metav1.IsControlledBy(repo, r.instance) {
+			continue
+		}
+
+		index.byID[id] = repo
+		index.byName[repo.Name] = id
+	}
+
+	return index, nil
+}
+
+// gitRepoName returns the name of the GitRepo of a repository. A repository
+// keeps the GitRepo of its platform ID after it was renamed or transferred, in
+// this case the previous repository name is returned as well. If the derived
+// name is taken by the GitRepo of another discovered repository, e.g. one that
+// was renamed away from this name, the platform ID is appended.
+func (idx *repoIndex) gitRepoName(repoName, repoID, discoveryName string) (string, string, error) {
+	if repo, ok := idx.byID[repoID]; ok && repoID != "" {
+		var previousName string
+		if repo.Spec.Name != repoName {
+			previousName = repo.Spec.Name
+		}
+
+		return repo.Name, previousName, nil
+	}
+
+	name, err := gitRepoName(discoveryName, repoName)
+	if err != nil {
+		return "", "", err
+	}
+
+	owner, ok := idx.byName[name]
+	if !ok || repoID == "" || owner == repoID || !idx.discovered[owner] {
+		return name, "", nil
+	}
+
+	suffix, err := k8s.SanitizeSubdomain(repoID)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to sanitize repo ID %s: %w", repoID, err)
+	}
+
+	name, err = k8s.DeterministicSubdomain(name, "-"+suffix)
+	if err != nil {
+		return "", "", err
+	}
+
+	return name, "", nil
+}
@@ -0,0 +1,324 @@
+package discovery
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
+	corev1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var ErrOrgWebhooksNotSupported = errors.New("organization webhooks are not supported by the platform")
+
+// reconcileWebhooks manages the organization webhooks of the Discovery. They
+// are removed when the Discovery is deleted or the webhook scope changes.
+func (r *Reconciler) reconcileWebhooks(ctx context.Context) (*ctrl.Result, error) {
+	if !r.instance.DeletionTimestamp.IsZero() || !r.instance.GetOrganizationWebhooks() {
+		return r.deleteWebhooks(ctx)
+	}
+
+	return r.ensureWebhooks(ctx)
+}
+
+func (r *Reconciler) ensureWebhooks(ctx context.Context) (*ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if r.externalURL == "" {
+		log.V(1).Info("External URL is not configured, skipping organization webhook creation")
+
+		return &ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(r.instance, renovatev1beta1.FinalizerDiscoveryWebhook) {
+		patch := client.MergeFrom(r.instance.DeepCopy())
+		controllerutil.AddFinalizer(r.instance, renovatev1beta1.FinalizerDiscoveryWebhook)
+
+		if err := r.Patch(ctx, r.instance, patch); err != nil {
+			return &ctrl.Result{}, err
+		}
+	}
+
+	secretString, err := r.ensureWebhookSecret(ctx)
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	orgs, err := r.webhookOrganizations(ctx)
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	orgManager, err := r.newOrgWebhookManager(ctx)
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	baseURL := strings.TrimRight(r.externalURL, "/")
+	webhookURL := fmt.Sprintf("%s/hooks/%s/discoveries/%s", baseURL, r.instance.Namespace, r.instance.Name)
+
+	var allErrors []error
+
+	webhooks := make([]renovatev1beta1.OrganizationWebhookStatus, 0, len(orgs))
+
+	for _, org := range orgs {
+		webhookID, err := orgManager.EnsureOrgWebhook(ctx, org, webhookURL, secretString)
+		if err != nil {
+			log.Error(err, "Failed to ensure organization webhook", "organization", org)
+			allErrors = append(allErrors, fmt.Errorf("failed to ensure webhook for %s: %w", org, err))
+
+			// Keep tracking the previous webhook so it can still be cleaned up.
+			if current := findWebhook(r.instance.Status.Webhooks, org); current != nil {
+				webhooks = append(webhooks, *current)
+			}
+
+			continue
+		}
+
+		webhooks = append(webhooks, renovatev1beta1.OrganizationWebhookStatus{Organization: org, ID: webhookID})
+	}
+
+	for _, current := range r.instance.Status.Webhooks {
+		if slices.Contains(orgs, current.Organization) {
+			continue
+		}
+
+		log.Info("Deleting organization webhook", "organization", current.Organization, "webhookID", current.ID)
+
+		if err := orgManager.DeleteOrgWebhook(ctx, current.Organization, current.ID); err != nil {
+			log.Error(err, "Failed to delete organization webhook", "organization", current.Organization)
+			allErrors = append(allErrors, fmt.Errorf("failed to delete webhook for %s: %w", current.Organization, err))
+			webhooks = append(webhooks, current)
+		}
+	}
+
+	if err := r.patchWebhooksStatus(ctx, webhooks); err != nil {
+		allErrors = append(allErrors, err)
+	}
+
+	return &ctrl.Result{}, errors.Join(allErrors...)
+}
+
+// deleteWebhooks removes all managed organization webhooks and their secret
+// and releases the cleanup finalizer.
+func (r *Reconciler) deleteWebhooks(ctx context.Context) (*ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	if len(r.instance.Status.Webhooks) == 0 &&
+		!controllerutil.ContainsFinalizer(r.instance, renovatev1beta1.FinalizerDiscoveryWebhook) {
+		return &ctrl.Result{}, nil
+	}
+
+	if len(r.instance.Status.Webhooks) > 0 {
+		orgManager, err := r.newOrgWebhookManager(ctx)
+		if err != nil {
+			return &ctrl.Result{}, err
+		}
+
+		var (
+			allErrors []error
+			remaining []renovatev1beta1.OrganizationWebhookStatus
+		)
+
+		for _, current := range r.instance.Status.Webhooks {
+			log.Info("Deleting organization webhook", "organization", current.Organization, "webhookID", current.ID)
+
+			if err := orgManager.DeleteOrgWebhook(ctx, current.Organization, current.ID); err != nil {
+				log.Error(err, "Failed to delete organization webhook", "organization", current.Organization)
+				allErrors = append(allErrors, fmt.Errorf("failed to delete webhook for %s: %w", current.Organization, err))
+				remaining = append(remaining, current)
+			}
+		}
+
+		if err := r.patchWebhooksStatus(ctx, remaining); err != nil {
+			allErrors = append(allErrors, err)
+		}
+
+		if len(allErrors) > 0 {
+			return &ctrl.Result{}, errors.Join(allErrors...)
+		}
+	}
+
+	if err := r.deleteWebhookSecret(ctx); err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	if controllerutil.ContainsFinalizer(r.instance, renovatev1beta1.FinalizerDiscoveryWebhook) {
+		patch := client.MergeFrom(r.instance.DeepCopy())
+		controllerutil.RemoveFinalizer(r.instance, renovatev1beta1.FinalizerDiscoveryWebhook)
+
+		if err := r.Patch(ctx, r.instance, patch); err != nil && !api_errors.IsNotFound(err) {
+			return &ctrl.Result{}, err
+		}
+	}
+
+	return &ctrl.Result{}, nil
+}
+
+// newOrgWebhookManager returns the provider of the configured platform if it
+// supports organization webhooks.
+func (r *Reconciler) newOrgWebhookManager(ctx context.Context) (provider.OrgWebhookManager, error) {
+	providerManager, err := r.newProviderManager(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orgManager, ok := providerManager.(provider.OrgWebhookManager)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrOrgWebhooksNotSupported, r.renovate.Spec.Platform.Type)
+	}
+
+	return orgManager, nil
+}
+
+// webhookOrganizations returns the sorted organizations to register webhooks
+// on. Unless configured explicitly, these are the owners of all GitRepos
+// managed by this Discovery.
+func (r *Reconciler) webhookOrganizations(ctx context.Context) ([]string, error) {
+	orgs := slices.Clone(r.instance.Spec.Webhooks.Organizations)
+
+	if len(orgs) == 0 {
+		repos := &renovatev1beta1.GitRepoList{}
+		if err := r.List(ctx, repos, client.InNamespace(r.instance.Namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list GitRepos: %w", err)
+		}
+
+		for _, repo := range repos.Items {
+			if !metav1.IsControlledBy(&repo, r.instance) {
+				continue
+			}
+
+			if owner := path.Dir(repo.Spec.Name); owner != "." && owner != "/" {
+				orgs = append(orgs, owner)
+			}
+		}
+	}
+
+	slices.Sort(orgs)
+
+	return slices.Compact(orgs), nil
+}
+
+func (r *Reconciler) patchWebhooksStatus(
+	ctx context.Context,
+	webhooks []renovatev1beta1.OrganizationWebhookStatus,
+) error {
+	if slices.Equal(r.instance.Status.Webhooks, webhooks) {
+		return nil
+	}
+
+	patch := client.MergeFrom(r.instance.DeepCopy())
+	r.instance.Status.Webhooks = webhooks
+
+	if err := r.Status().Patch(ctx, r.instance, patch); err != nil && !api_errors.IsNotFound(err) {
+		return fmt.Errorf("failed to patch organization webhooks in status: %w", err)
+	}
+
+	return nil
+}
+
+func (r *Reconciler) webhookSecretName() (string, error) {
+	return k8s.DeterministicSubdomain(r.instance.Name, "-webhook-secret")
+}
+
+// ensureWebhookSecret returns the shared secret of the organization webhooks,
+// generating it on first use.
+func (r *Reconciler) ensureWebhookSecret(ctx context.Context) (string, error) {
+	secretName, err := r.webhookSecretName()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret name: %w", err)
+	}
+
+	webhookSecret := &corev1.Secret{}
+
+	err = r.Get(ctx, client.ObjectKey{Name: secretName, Namespace: r.instance.Namespace}, webhookSecret)
+	if err == nil {
+		return string(webhookSecret.Data[renovatev1beta1.WebhookSecretDataKey]), nil
+	}
+
+	if !api_errors.IsNotFound(err) {
+		return "", fmt.Errorf("failed to fetch webhook secret: %w", err)
+	}
+
+	secretString, err := generateSecureToken()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate secure token: %w", err)
+	}
+
+	webhookSecret = &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: r.instance.Namespace,
+		},
+		Data: map[string][]byte{
+			renovatev1beta1.WebhookSecretDataKey: []byte(secretString),
+		},
+	}
+
+	if err := controllerutil.SetControllerReference(r.instance, webhookSecret, r.scheme); err != nil {
+		return "", fmt.Errorf("failed to set owner reference on secret: %w", err)
+	}
+
+	if err := r.Create(ctx, webhookSecret); err != nil {
+		return "", fmt.Errorf("failed to create webhook secret: %w", err)
+	}
+
+	return secretString, nil
+}
+
+func (r *Reconciler) deleteWebhookSecret(ctx context.Context) error {
+	secretName, err := r.webhookSecretName()
+	if err != nil {
+		return fmt.Errorf("failed to generate webhook secret name: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretName,
+			Namespace: r.instance.Namespace,
+		},
+	}
+
+	if err := r.Delete(ctx, secret); err != nil && !api_errors.IsNotFound(err) {
+		return fmt.Errorf("failed to delete webhook secret: %w", err)
+	}
+
+	return nil
+}
+
+func findWebhook(
+	webhooks []renovatev1beta1.OrganizationWebhookStatus,
+	org string,
+) *renovatev1beta1.OrganizationWebhookStatus {
+	for i := range webhooks {
+		if webhooks[i].Organization == org {
+			return &webhooks[i]
+		}
+	}
+
+	return nil
+}
+
+func generateSecureToken() (string, error) {
+	const secureTokenLength = 32
+
+	bytes := make([]byte, secureTokenLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(bytes), nil
+}
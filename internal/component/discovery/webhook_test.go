@@ -0,0 +1,186 @@
+package discovery
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/stretchr/testify/mock"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+	"github.com/thegeeklab/renovate-operator/internal/provider/factory"
+	"github.com/thegeeklab/renovate-operator/internal/provider/mocks"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// orgWebhookProvider combines the provider mocks of a platform supporting
+// organization webhooks.
+type orgWebhookProvider struct {
+	*mocks.ProviderManager
+	*mocks.OrgWebhookManager
+}
+
+var _ = Describe("Organization Webhook Reconciliation", func() {
+	const webhookURL = "https://renovate.example.com/hooks/default/discoveries/test-discovery"
+
+	var (
+		fakeClient client.Client
+		reconciler *Reconciler
+		instance   *renovatev1beta1.Discovery
+		ctx        context.Context
+		scheme     *runtime.Scheme
+		mockOrg    *mocks.OrgWebhookManager
+	)
+
+	newDiscoveredRepo := func(name, specName string) *renovatev1beta1.GitRepo {
+		repo := &renovatev1beta1.GitRepo{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       renovatev1beta1.GitRepoSpec{Name: specName},
+		}
+		Expect(controllerutil.SetControllerReference(instance, repo, scheme)).To(Succeed())
+
+		return repo
+	}
+
+	// persist stores the in-memory spec and status of the instance.
+	persist := func() {
+		status := instance.Status.DeepCopy()
+		Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+
+		instance.Status = *status
+		Expect(fakeClient.Status().Update(ctx, instance)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(renovatev1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		instance = &renovatev1beta1.Discovery{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-discovery",
+				Namespace: "default",
+				UID:       "test-uid",
+			},
+			Spec: renovatev1beta1.DiscoverySpec{
+				Webhooks: renovatev1beta1.WebhooksSpec{Scope: renovatev1beta1.WebhookScope_ORGANIZATION},
+			},
+		}
+
+		tokenSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "platform-secret", Namespace: "default"},
+			Data:       map[string][]byte{"token": []byte("test-token")},
+		}
+
+		fakeClient = fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(instance, tokenSecret).
+			WithStatusSubresource(&renovatev1beta1.Discovery{}).
+			Build()
+
+		reconciler = &Reconciler{
+			Client:      fakeClient,
+			scheme:      scheme,
+			instance:    instance,
+			externalURL: "https://renovate.example.com/",
+			renovate: &renovatev1beta1.RenovateConfig{
+				Spec: renovatev1beta1.RenovateConfigSpec{
+					Platform: renovatev1beta1.PlatformSpec{
+						Type: renovatev1beta1.PlatformType_GITHUB,
+						Token: corev1.EnvVarSource{
+							SecretKeyRef: &corev1.SecretKeySelector{
+								Key:                  "token",
+								LocalObjectReference: corev1.LocalObjectReference{Name: "platform-secret"},
+							},
+						},
+					},
+				},
+			},
+		}
+
+		mockOrg = mocks.NewOrgWebhookManager(GinkgoT())
+		reconciler.providerFactory = func(
+			context.Context, factory.PlatformConfig,
+		) (provider.ProviderManager, error) {
+			return orgWebhookProvider{mocks.NewProviderManager(GinkgoT()), mockOrg}, nil
+		}
+	})
+
+	Describe("reconcileWebhooks", func() {
+		It("should register webhooks on the owners of all discovered repositories", func() {
+			Expect(fakeClient.Create(ctx, newDiscoveredRepo("repo-a", "thegeeklab/a"))).To(Succeed())
+			Expect(fakeClient.Create(ctx, newDiscoveredRepo("repo-b", "thegeeklab/b"))).To(Succeed())
+			Expect(fakeClient.Create(ctx, newDiscoveredRepo("repo-c", "group/subgroup/c"))).To(Succeed())
+
+			mockOrg.On("EnsureOrgWebhook", mock.Anything, "group/subgroup", webhookURL, mock.Anything).
+				Return("1", nil).Once()
+			mockOrg.On("EnsureOrgWebhook", mock.Anything, "thegeeklab", webhookURL, mock.Anything).
+				Return("2", nil).Once()
+
+			_, err := reconciler.reconcileWebhooks(ctx)
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(instance.Finalizers).To(ContainElement(renovatev1beta1.FinalizerDiscoveryWebhook))
+			Expect(instance.Status.Webhooks).To(Equal([]renovatev1beta1.OrganizationWebhookStatus{
+				{Organization: "group/subgroup", ID: "1"},
+				{Organization: "thegeeklab", ID: "2"},
+			}))
+
+			secret := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{
+				Name: "test-discovery-webhook-secret", Namespace: "default",
+			}, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKey(renovatev1beta1.WebhookSecretDataKey))
+		})
+
+		It("should delete webhooks of organizations no longer configured", func() {
+			instance.Spec.Webhooks.Organizations = []string{"thegeeklab"}
+			instance.Status.Webhooks = []renovatev1beta1.OrganizationWebhookStatus{
+				{Organization: "stale", ID: "7"},
+				{Organization: "thegeeklab", ID: "2"},
+			}
+			persist()
+
+			mockOrg.On("EnsureOrgWebhook", mock.Anything, "thegeeklab", webhookURL, mock.Anything).
+				Return("2", nil).Once()
+			mockOrg.On("DeleteOrgWebhook", mock.Anything, "stale", "7").Return(nil).Once()
+
+			_, err := reconciler.reconcileWebhooks(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance.Status.Webhooks).To(Equal([]renovatev1beta1.OrganizationWebhookStatus{
+				{Organization: "thegeeklab", ID: "2"},
+			}))
+		})
+
+		It("should remove all webhooks and the finalizer when the scope changes", func() {
+			instance.Spec.Webhooks.Scope = renovatev1beta1.WebhookScope_REPOSITORY
+			instance.Status.Webhooks = []renovatev1beta1.OrganizationWebhookStatus{
+				{Organization: "thegeeklab", ID: "2"},
+			}
+			controllerutil.AddFinalizer(instance, renovatev1beta1.FinalizerDiscoveryWebhook)
+			persist()
+
+			mockOrg.On("DeleteOrgWebhook", mock.Anything, "thegeeklab", "2").Return(nil).Once()
+
+			_, err := reconciler.reconcileWebhooks(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance.Status.Webhooks).To(BeEmpty())
+			Expect(instance.Finalizers).ToNot(ContainElement(renovatev1beta1.FinalizerDiscoveryWebhook))
+		})
+
+		It("should skip webhook registration without an external URL", func() {
+			reconciler.externalURL = ""
+
+			_, err := reconciler.reconcileWebhooks(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(instance.Finalizers).To(BeEmpty())
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/thegeeklab/renovate-operator/internal/provider"
 	"github.com/thegeeklab/renovate-operator/internal/provider/factory"
 	corev1 "k8s.io/api/core/v1"
 	api_errors "k8s.io/apimachinery/pkg/api/errors"
@@ -34,6 +35,13 @@ func (r *Reconciler) reconcilePlatformInfo(ctx context.Context) (*ctrl.Result, e
 		Token:    string(secret.Data[r.renovate.Spec.Platform.Token.SecretKeyRef.Key]),
 	}
 
+	githubApp, err := factory.LoadGitHubApp(ctx, r.Client, r.instance.Namespace, &r.renovate.Spec.Platform)
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	platformConfig.GitHubApp = githubApp
+
 	providerManager, err := r.providerFactory(ctx, platformConfig)
 	if err != nil {
 		if errors.Is(err, factory.ErrNotImplemented) {
@@ -54,19 +62,34 @@ func (r *Reconciler) reconcilePlatformInfo(ctx context.Context) (*ctrl.Result, e
 		return &ctrl.Result{}, err
 	}
 
+	var defaultBranch string
+
+	// Platforms without the default branch in push payloads provide it through
+	// the API, the receiver ignores pushes to other branches.
+	if resolver, ok := providerManager.(provider.DefaultBranchResolver); ok {
+		defaultBranch, err = resolver.DefaultBranch(ctx, r.instance.Spec.Name)
+		if err != nil {
+			log.Error(err, "Failed to get default branch")
+
+			return &ctrl.Result{}, err
+		}
+	}
+
 	platform := string(r.renovate.Spec.Platform.Type)
 
-	if r.instance.Status.Platform == platform && r.instance.Status.RepoURL == repoURL {
+	if r.instance.Status.Platform == platform && r.instance.Status.RepoURL == repoURL &&
+		r.instance.Status.DefaultBranch == defaultBranch {
 		log.V(1).Info("Platform info already up to date")
 
 		return &ctrl.Result{}, nil
 	}
 
-	log.Info("Updating platform info", "platform", platform, "repoURL", repoURL)
+	log.Info("Updating platform info", "platform", platform, "repoURL", repoURL, "defaultBranch", defaultBranch)
 
 	patch := client.MergeFrom(r.instance.DeepCopy())
 	r.instance.Status.Platform = platform
 	r.instance.Status.RepoURL = repoURL
+	r.instance.Status.DefaultBranch = defaultBranch
 
 	if err := r.Status().Patch(ctx, r.instance, patch); err != nil && !api_errors.IsNotFound(err) {
 		return &ctrl.Result{}, fmt.Errorf("failed to patch platform info in status: %w", err)
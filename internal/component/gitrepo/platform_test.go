@@ -20,6 +20,13 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
+// defaultBranchProvider combines the provider mocks of a platform without the
+// default branch in push payloads.
+type defaultBranchProvider struct {
+	*mocks.ProviderManager
+	*mocks.DefaultBranchResolver
+}
+
 var _ = Describe("GitRepo Component - Platform Info Logic", func() {
 	var (
 		ctx        context.Context
@@ -202,6 +209,45 @@ var _ = Describe("GitRepo Component - Platform Info Logic", func() {
 			Expect(updated.Status.RepoURL).To(BeEmpty())
 		})
 
+		Context("with a platform resolving the default branch", func() {
+			var mockResolver *mocks.DefaultBranchResolver
+
+			BeforeEach(func() {
+				mockResolver = mocks.NewDefaultBranchResolver(GinkgoT())
+				reconciler.providerFactory = func(
+					context.Context, factory.PlatformConfig,
+				) (provider.ProviderManager, error) {
+					return defaultBranchProvider{mockMgr, mockResolver}, nil
+				}
+
+				mockMgr.On("RepoURL", mock.Anything, "org/repo").
+					Return("https://gitea.example.com/org/repo", nil).
+					Once()
+			})
+
+			It("should populate the default branch in status", func() {
+				mockResolver.On("DefaultBranch", mock.Anything, "org/repo").
+					Return("main", nil).
+					Once()
+
+				_, err := reconciler.reconcilePlatformInfo(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				updated := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(instance), updated)).To(Succeed())
+				Expect(updated.Status.DefaultBranch).To(Equal("main"))
+			})
+
+			It("should fail if DefaultBranch returns an error", func() {
+				mockResolver.On("DefaultBranch", mock.Anything, "org/repo").
+					Return("", errors.New("failed to fetch default branch")).
+					Once()
+
+				_, err := reconciler.reconcilePlatformInfo(ctx)
+				Expect(err).To(MatchError(ContainSubstring("failed to fetch default branch")))
+			})
+		})
+
 		It("should fail if RepoURL returns an error", func() {
 			mockMgr.On("RepoURL", mock.Anything, "org/repo").
 				Return("", errors.New("failed to fetch repository")).
@@ -56,6 +56,13 @@ func (r *Reconciler) createWebhook(ctx context.Context) (*ctrl.Result, error) {
 		Token:    string(secret.Data[r.renovate.Spec.Platform.Token.SecretKeyRef.Key]),
 	}
 
+	githubApp, err := factory.LoadGitHubApp(ctx, r.Client, r.instance.Namespace, &r.renovate.Spec.Platform)
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	platformConfig.GitHubApp = githubApp
+
 	providerManager, err := r.providerFactory(ctx, platformConfig)
 	if err != nil {
 		if errors.Is(err, factory.ErrNotImplemented) {
@@ -151,6 +158,13 @@ func (r *Reconciler) deleteWebhook(ctx context.Context) (*ctrl.Result, error) {
 		Token:    string(secret.Data[r.renovate.Spec.Platform.Token.SecretKeyRef.Key]),
 	}
 
+	githubApp, err := factory.LoadGitHubApp(ctx, r.Client, r.instance.Namespace, &r.renovate.Spec.Platform)
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	platformConfig.GitHubApp = githubApp
+
 	providerManager, err := r.providerFactory(ctx, platformConfig)
 	if errors.Is(err, factory.ErrNotImplemented) {
 		log.V(1).Info("Provider not implemented, skipping cleanup")
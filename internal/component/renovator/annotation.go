@@ -42,10 +42,19 @@ func RemoveRenovatorOperation(annotations map[string]string) map[string]string {
 	}
 
 	delete(annotations, renovatev1beta1.RenovatorOperation)
+	delete(annotations, renovatev1beta1.AnnotationTriggerSource)
+	delete(annotations, renovatev1beta1.AnnotationWebhookFirstEvent)
+	delete(annotations, renovatev1beta1.AnnotationWebhookLastEvent)
+	delete(annotations, renovatev1beta1.AnnotationDebugRun)
 
 	return annotations
 }
 
+// IsWebhookTriggered checks if the operation annotation was set by a platform webhook.
+func IsWebhookTriggered(annotations map[string]string) bool {
+	return annotations[renovatev1beta1.AnnotationTriggerSource] == renovatev1beta1.TriggerSourceWebhook
+}
+
 // RemoveOperation removes a specific operation from the operation annotation list.
 // If the list becomes empty after removal, the annotation key is deleted entirely.
 // Other annotations are left intact. Returns the modified annotations map.
@@ -74,3 +83,23 @@ func RemoveOperation(annotations map[string]string, operation string) map[string
 
 	return annotations
 }
+
+// AddOperation adds an operation to the operation annotation list unless it is
+// already present. Other operations and annotations are left intact. Returns the
+// modified annotations map.
+func AddOperation(annotations map[string]string, operation string) map[string]string {
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	ops := GetRenovatorOperations(annotations)
+	if slices.Contains(ops, operation) {
+		return annotations
+	}
+
+	annotations[renovatev1beta1.RenovatorOperation] = strings.Join(
+		append(ops, operation), renovatev1beta1.RenovatorOperationSeparator,
+	)
+
+	return annotations
+}
@@ -221,6 +221,41 @@ var _ = Describe("Renovator Annotation Functions", func() {
 			Expect(result["annotation2"]).To(Equal("value2"))
 		})
 
+		It("should remove the trigger source annotation", func() {
+			annotations := map[string]string{
+				renovatev1beta1.RenovatorOperation:      renovatev1beta1.OperationRenovate,
+				renovatev1beta1.AnnotationTriggerSource: renovatev1beta1.TriggerSourceWebhook,
+			}
+
+			result := RemoveRenovatorOperation(annotations)
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should remove the webhook event annotations", func() {
+			annotations := map[string]string{
+				renovatev1beta1.RenovatorOperation:          renovatev1beta1.OperationRenovate,
+				renovatev1beta1.AnnotationTriggerSource:     renovatev1beta1.TriggerSourceWebhook,
+				renovatev1beta1.AnnotationWebhookFirstEvent: "2026-02-27T15:00:00Z",
+				renovatev1beta1.AnnotationWebhookLastEvent:  "2026-02-27T15:01:00Z",
+			}
+
+			result := RemoveRenovatorOperation(annotations)
+			Expect(result).To(BeEmpty())
+		})
+
+		It("should remove the debug run annotation", func() {
+			annotations := map[string]string{
+				renovatev1beta1.RenovatorOperation:         renovatev1beta1.OperationRenovate,
+				renovatev1beta1.AnnotationDebugRun:         renovatev1beta1.ValueTrue,
+				renovatev1beta1.AnnotationSkipScheduledRun: renovatev1beta1.ValueTrue,
+			}
+
+			result := RemoveRenovatorOperation(annotations)
+			Expect(result).To(Equal(map[string]string{
+				renovatev1beta1.AnnotationSkipScheduledRun: renovatev1beta1.ValueTrue,
+			}))
+		})
+
 		It("should handle nil annotations", func() {
 			var annotations map[string]string
 
@@ -338,4 +373,54 @@ var _ = Describe("Renovator Annotation Functions", func() {
 			Expect(result).To(HaveKey(renovatev1beta1.RenovatorOperation))
 		})
 	})
+
+	Describe("IsWebhookTriggered", func() {
+		It("should return true for webhook triggered operations", func() {
+			annotations := map[string]string{
+				renovatev1beta1.RenovatorOperation:      renovatev1beta1.OperationRenovate,
+				renovatev1beta1.AnnotationTriggerSource: renovatev1beta1.TriggerSourceWebhook,
+			}
+
+			Expect(IsWebhookTriggered(annotations)).To(BeTrue())
+		})
+
+		It("should return false without trigger source", func() {
+			annotations := map[string]string{
+				renovatev1beta1.RenovatorOperation: renovatev1beta1.OperationRenovate,
+			}
+
+			Expect(IsWebhookTriggered(annotations)).To(BeFalse())
+			Expect(IsWebhookTriggered(nil)).To(BeFalse())
+		})
+	})
+
+	Describe("AddOperation", func() {
+		It("should add an operation to nil annotations", func() {
+			result := AddOperation(nil, renovatev1beta1.OperationDiscover)
+			Expect(result).To(HaveKeyWithValue(renovatev1beta1.RenovatorOperation, renovatev1beta1.OperationDiscover))
+		})
+
+		It("should append an operation while keeping others", func() {
+			annotations := map[string]string{
+				renovatev1beta1.RenovatorOperation: renovatev1beta1.OperationRenovate,
+				"other-annotation":                 "some-value",
+			}
+
+			result := AddOperation(annotations, renovatev1beta1.OperationDiscover)
+			Expect(result).To(HaveKeyWithValue(
+				renovatev1beta1.RenovatorOperation,
+				renovatev1beta1.OperationRenovate+";"+renovatev1beta1.OperationDiscover,
+			))
+			Expect(result).To(HaveKeyWithValue("other-annotation", "some-value"))
+		})
+
+		It("should not add an operation twice", func() {
+			annotations := map[string]string{
+				renovatev1beta1.RenovatorOperation: renovatev1beta1.OperationDiscover,
+			}
+
+			result := AddOperation(annotations, renovatev1beta1.OperationDiscover)
+			Expect(result).To(HaveKeyWithValue(renovatev1beta1.RenovatorOperation, renovatev1beta1.OperationDiscover))
+		})
+	})
 })
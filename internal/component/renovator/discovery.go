@@ -40,7 +40,11 @@ func (r *Reconciler) updateDiscovery(discovery *renovatev1beta1.Discovery) error
 	discoverySpec := r.instance.Spec.Discovery
 
 	discovery.Spec.ConfigRef = discoverySpec.ConfigRef
+	discovery.Spec.Mode = discoverySpec.Mode
 	discovery.Spec.Filter = discoverySpec.Filter
+	discovery.Spec.Repositories = discoverySpec.Repositories
+	discovery.Spec.OverrideRules = discoverySpec.OverrideRules
+	discovery.Spec.Prune = discoverySpec.Prune
 
 	discovery.Spec.Image = spec.Image
 	if discoverySpec.Image != "" {
@@ -92,6 +96,11 @@ func (r *Reconciler) updateDiscovery(discovery *renovatev1beta1.Discovery) error
 		discovery.Spec.TTLSecondsAfterFinished = discoverySpec.TTLSecondsAfterFinished
 	}
 
+	discovery.Spec.BlackoutWindows = spec.BlackoutWindows
+	if discoverySpec.BlackoutWindows != nil {
+		discovery.Spec.BlackoutWindows = discoverySpec.BlackoutWindows
+	}
+
 	discovery.Spec.NodeSelector = spec.NodeSelector
 	if discoverySpec.NodeSelector != nil {
 		discovery.Spec.NodeSelector = discoverySpec.NodeSelector
@@ -155,6 +164,31 @@ func (r *Reconciler) updateDiscovery(discovery *renovatev1beta1.Discovery) error
 		discovery.Spec.Webhooks.Enabled = discoverySpec.Webhooks.Enabled
 	}
 
+	discovery.Spec.Webhooks.Scope = spec.Webhooks.Scope
+	if discoverySpec.Webhooks.Scope != "" {
+		discovery.Spec.Webhooks.Scope = discoverySpec.Webhooks.Scope
+	}
+
+	discovery.Spec.Webhooks.Organizations = spec.Webhooks.Organizations
+	if discoverySpec.Webhooks.Organizations != nil {
+		discovery.Spec.Webhooks.Organizations = discoverySpec.Webhooks.Organizations
+	}
+
+	discovery.Spec.Webhooks.Debounce = spec.Webhooks.Debounce
+	if discoverySpec.Webhooks.Debounce != nil {
+		discovery.Spec.Webhooks.Debounce = discoverySpec.Webhooks.Debounce
+	}
+
+	discovery.Spec.Webhooks.PathFilter = spec.Webhooks.PathFilter
+	if discoverySpec.Webhooks.PathFilter != nil {
+		discovery.Spec.Webhooks.PathFilter = discoverySpec.Webhooks.PathFilter
+	}
+
+	discovery.Spec.Webhooks.Commands = spec.Webhooks.Commands
+	if discoverySpec.Webhooks.Commands != nil {
+		discovery.Spec.Webhooks.Commands = discoverySpec.Webhooks.Commands
+	}
+
 	logging := &spec.Logging
 	if discoverySpec.Logging != nil {
 		logging = discoverySpec.Logging
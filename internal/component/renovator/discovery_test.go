@@ -241,6 +241,13 @@ var _ = Describe("Renovator Discovery", func() {
 						ConfigRef: "test-config",
 						Filter:    []string{"test-filter"},
 						Topics:    []string{"renovate", "production"},
+						Prune:     &renovatev1beta1.PruneSpec{Policy: renovatev1beta1.PrunePolicy_ORPHAN},
+						Repositories: []renovatev1beta1.RepositorySpec{
+							{Name: "mirror/repo", ConfigRef: "mirror-config"},
+						},
+						OverrideRules: []renovatev1beta1.OverrideRuleSpec{
+							{Filter: []string{"org/large-*"}, Overrides: renovatev1beta1.GitRepoOverrides{Image: "renovate/renovate:full"}},
+						},
 					},
 				},
 			}
@@ -261,6 +268,11 @@ var _ = Describe("Renovator Discovery", func() {
 			Expect(discovery.Spec.ConfigRef).To(Equal("test-config"))
 			Expect(discovery.Spec.Filter).To(Equal([]string{"test-filter"}))
 			Expect(discovery.Spec.Topics).To(Equal([]string{"renovate", "production"}))
+			Expect(discovery.Spec.Repositories).To(Equal([]renovatev1beta1.RepositorySpec{
+				{Name: "mirror/repo", ConfigRef: "mirror-config"},
+			}))
+			Expect(discovery.Spec.Prune).To(Equal(&renovatev1beta1.PruneSpec{Policy: renovatev1beta1.PrunePolicy_ORPHAN}))
+			Expect(discovery.Spec.OverrideRules).To(HaveLen(1))
 			Expect(discovery.Spec.Image).To(Equal("renovate/renovate:36"))
 			Expect(discovery.Spec.ImagePullPolicy).To(Equal(corev1.PullIfNotPresent))
 			Expect(discovery.Spec.Logging).NotTo(BeNil())
@@ -0,0 +1,123 @@
+package renovator
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/metadata"
+	"github.com/thegeeklab/renovate-operator/internal/provider/factory"
+	"github.com/thegeeklab/renovate-operator/internal/provider/github"
+	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
+	corev1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+const (
+	GitHubAppTokenSuffix = "github-app-token"
+
+	// gitHubAppTokenRefreshMargin is the remaining lifetime at which the
+	// installation token is replaced. Installation tokens are valid for one hour,
+	// the margin leaves enough time for running jobs to pick up the new token.
+	gitHubAppTokenRefreshMargin = 15 * time.Minute
+)
+
+// TokenMinter creates installation access tokens for a GitHub App.
+type TokenMinter func(
+	ctx context.Context, endpoint string, app github.AppCredentials,
+) (*github.InstallationToken, error)
+
+// reconcileGitHubAppToken keeps the managed installation token Secret up to date
+// and requeues before the token expires.
+func (r *Reconciler) reconcileGitHubAppToken(ctx context.Context) (*ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	secret := &corev1.Secret{ObjectMeta: metadata.GenericMetadata(r.req, GitHubAppTokenSuffix)}
+	platform := &r.instance.Spec.Renovate.Platform
+
+	current := &corev1.Secret{}
+
+	err := r.Get(ctx, client.ObjectKeyFromObject(secret), current)
+	if err != nil && !api_errors.IsNotFound(err) {
+		return &ctrl.Result{}, fmt.Errorf("failed to get GitHub App token secret: %w", err)
+	}
+
+	exists := err == nil
+
+	if platform.GitHubApp == nil {
+		if exists {
+			if err := r.Delete(ctx, current); err != nil && !api_errors.IsNotFound(err) {
+				return &ctrl.Result{}, fmt.Errorf("failed to delete GitHub App token secret: %w", err)
+			}
+		}
+
+		return &ctrl.Result{}, nil
+	}
+
+	if exists {
+		if refreshIn := tokenRefreshIn(current); refreshIn > 0 {
+			log.V(1).Info("GitHub App token still valid", "refreshIn", refreshIn)
+
+			return &ctrl.Result{RequeueAfter: refreshIn}, nil
+		}
+	}
+
+	app, err := factory.LoadGitHubApp(ctx, r.Client, r.instance.Namespace, platform)
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	token, err := r.tokenMinter(ctx, platform.Endpoint, *app)
+	if err != nil {
+		return &ctrl.Result{}, fmt.Errorf("failed to mint GitHub App installation token: %w", err)
+	}
+
+	_, err = k8s.CreateOrUpdate(ctx, r.Client, secret, r.instance, func() error {
+		if secret.Annotations == nil {
+			secret.Annotations = make(map[string]string)
+		}
+
+		secret.Annotations[renovatev1beta1.AnnotationTokenExpiresAt] = token.ExpiresAt.UTC().Format(time.RFC3339)
+		secret.Data = map[string][]byte{renovatev1beta1.PlatformTokenDataKey: []byte(token.Token)}
+
+		return nil
+	})
+	if err != nil {
+		return &ctrl.Result{}, err
+	}
+
+	log.Info("Refreshed GitHub App installation token", "expiresAt", token.ExpiresAt)
+
+	return &ctrl.Result{RequeueAfter: max(time.Until(token.ExpiresAt)-gitHubAppTokenRefreshMargin, time.Minute)}, nil
+}
+
+// tokenRefreshIn returns the duration until the token stored in the secret must
+// be refreshed, or zero if it needs to be refreshed now.
+func tokenRefreshIn(secret *corev1.Secret) time.Duration {
+	if len(secret.Data[renovatev1beta1.PlatformTokenDataKey]) == 0 {
+		return 0
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, secret.Annotations[renovatev1beta1.AnnotationTokenExpiresAt])
+	if err != nil {
+		return 0
+	}
+
+	return max(time.Until(expiresAt)-gitHubAppTokenRefreshMargin, 0)
+}
+
+// gitHubAppTokenSource references the managed installation token Secret.
+func (r *Reconciler) gitHubAppTokenSource() corev1.EnvVarSource {
+	return corev1.EnvVarSource{
+		SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{
+				Name: metadata.GenericName(r.req, GitHubAppTokenSuffix),
+			},
+			Key: renovatev1beta1.PlatformTokenDataKey,
+		},
+	}
+}
@@ -0,0 +1,156 @@
+package renovator
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/provider/github"
+	corev1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Renovator GitHub App Functions", func() {
+	var (
+		ctx        context.Context
+		scheme     *runtime.Scheme
+		fakeClient client.Client
+		renovator  *renovatev1beta1.Renovator
+		minted     int
+		tokenKey   client.ObjectKey
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(renovatev1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.SchemeBuilder.AddToScheme(scheme)).To(Succeed())
+
+		fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "default"},
+			Data:       map[string][]byte{"private-key.pem": []byte("pem")},
+		}).Build()
+
+		renovator = &renovatev1beta1.Renovator{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-renovator", Namespace: "default"},
+			Spec: renovatev1beta1.RenovatorSpec{
+				Renovate: renovatev1beta1.RenovateConfigSpec{
+					Platform: renovatev1beta1.PlatformSpec{
+						Type:     renovatev1beta1.PlatformType_GITHUB,
+						Endpoint: "https://api.github.com/",
+						GitHubApp: &renovatev1beta1.GitHubAppSpec{
+							AppID:          42,
+							InstallationID: 7,
+							PrivateKey: corev1.SecretKeySelector{
+								LocalObjectReference: corev1.LocalObjectReference{Name: "github-app"},
+								Key:                  "private-key.pem",
+							},
+						},
+					},
+				},
+			},
+		}
+
+		minted = 0
+		tokenKey = client.ObjectKey{Namespace: "default", Name: "test-renovator-github-app-token"}
+	})
+
+	newReconciler := func() *Reconciler {
+		reconciler, err := NewReconciler(ctx, fakeClient, scheme, renovator)
+		Expect(err).NotTo(HaveOccurred())
+
+		reconciler.tokenMinter = func(
+			_ context.Context, endpoint string, app github.AppCredentials,
+		) (*github.InstallationToken, error) {
+			Expect(endpoint).To(Equal("https://api.github.com/"))
+			Expect(app).To(Equal(github.AppCredentials{AppID: 42, InstallationID: 7, PrivateKey: []byte("pem")}))
+
+			minted++
+
+			return &github.InstallationToken{Token: "ghs_token", ExpiresAt: time.Now().Add(time.Hour)}, nil
+		}
+
+		return reconciler
+	}
+
+	Describe("reconcileGitHubAppToken", func() {
+		It("should store a minted token and requeue before it expires", func() {
+			result, err := newReconciler().reconcileGitHubAppToken(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour-gitHubAppTokenRefreshMargin, time.Minute))
+
+			secret := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, tokenKey, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKeyWithValue(renovatev1beta1.PlatformTokenDataKey, []byte("ghs_token")))
+			Expect(secret.Annotations).To(HaveKey(renovatev1beta1.AnnotationTokenExpiresAt))
+			Expect(minted).To(Equal(1))
+		})
+
+		It("should keep a token that is still valid", func() {
+			reconciler := newReconciler()
+
+			_, err := reconciler.reconcileGitHubAppToken(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := reconciler.reconcileGitHubAppToken(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+			Expect(minted).To(Equal(1))
+		})
+
+		It("should refresh a token close to expiry", func() {
+			Expect(fakeClient.Create(ctx, &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      tokenKey.Name,
+					Namespace: tokenKey.Namespace,
+					Annotations: map[string]string{
+						renovatev1beta1.AnnotationTokenExpiresAt: time.Now().Add(time.Minute).UTC().Format(time.RFC3339),
+					},
+				},
+				Data: map[string][]byte{renovatev1beta1.PlatformTokenDataKey: []byte("ghs_old")},
+			})).To(Succeed())
+
+			_, err := newReconciler().reconcileGitHubAppToken(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			secret := &corev1.Secret{}
+			Expect(fakeClient.Get(ctx, tokenKey, secret)).To(Succeed())
+			Expect(secret.Data).To(HaveKeyWithValue(renovatev1beta1.PlatformTokenDataKey, []byte("ghs_token")))
+			Expect(minted).To(Equal(1))
+		})
+
+		It("should delete the token secret when the GitHub App is removed", func() {
+			_, err := newReconciler().reconcileGitHubAppToken(ctx)
+			Expect(err).NotTo(HaveOccurred())
+
+			renovator.Spec.Renovate.Platform.GitHubApp = nil
+
+			result, err := newReconciler().reconcileGitHubAppToken(ctx)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			err = fakeClient.Get(ctx, tokenKey, &corev1.Secret{})
+			Expect(api_errors.IsNotFound(err)).To(BeTrue())
+		})
+	})
+
+	Describe("updateRenovateConfig", func() {
+		It("should point the platform token to the managed token secret", func() {
+			renovateConfig := &renovatev1beta1.RenovateConfig{}
+			Expect(newReconciler().updateRenovateConfig(renovateConfig)).To(Succeed())
+
+			Expect(renovateConfig.Spec.Platform.GitHubApp).To(Equal(renovator.Spec.Renovate.Platform.GitHubApp))
+			Expect(renovateConfig.Spec.Platform.Token.SecretKeyRef).To(Equal(&corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: tokenKey.Name},
+				Key:                  renovatev1beta1.PlatformTokenDataKey,
+			}))
+		})
+	})
+})
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/provider/github"
 	"github.com/thegeeklab/renovate-operator/pkg/util/reconciler"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -16,6 +17,8 @@ type Reconciler struct {
 	scheme   *runtime.Scheme
 	req      ctrl.Request
 	instance *renovatev1beta1.Renovator
+
+	tokenMinter TokenMinter
 }
 
 func NewReconciler(
@@ -29,10 +32,19 @@ func NewReconciler(
 		scheme:   scheme,
 		req:      ctrl.Request{NamespacedName: client.ObjectKey{Namespace: instance.Namespace, Name: instance.Name}},
 		instance: instance,
+
+		tokenMinter: github.CreateInstallationToken,
 	}, nil
 }
 
 func (r *Reconciler) Reconcile(ctx context.Context) (*ctrl.Result, error) {
+	// The token refresh requeue is tracked separately, it must not delay the
+	// cleanup of the operation annotation below.
+	tokenResult, err := r.reconcileGitHubAppToken(ctx)
+	if err != nil {
+		return tokenResult, err
+	}
+
 	results := &reconciler.Results{}
 
 	reconcileFuncs := []func(context.Context) (*ctrl.Result, error){
@@ -69,5 +81,7 @@ func (r *Reconciler) Reconcile(ctx context.Context) (*ctrl.Result, error) {
 		}
 	}
 
-	return result, reconcileErr
+	results.Collect(tokenResult)
+
+	return results.ToResult(), reconcileErr
 }
@@ -7,6 +7,7 @@ import (
 
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
 	"github.com/thegeeklab/renovate-operator/internal/metadata"
+	"github.com/thegeeklab/renovate-operator/internal/parser"
 	"github.com/thegeeklab/renovate-operator/internal/resource/renovate"
 	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
 	corev1 "k8s.io/api/core/v1"
@@ -22,6 +23,20 @@ type RenovateConfig struct {
 	Platform      renovatev1beta1.PlatformType `json:"platform"`
 	Endpoint      string                       `json:"endpoint"`
 	AddLabels     []string                     `json:"addLabels,omitempty"`
+	LogLevelRemap []LogLevelRemap              `json:"logLevelRemap,omitempty"`
+}
+
+// LogLevelRemap changes the level Renovate logs matching messages at.
+type LogLevelRemap struct {
+	MatchMessage string                   `json:"matchMessage"`
+	NewLogLevel  renovatev1beta1.LogLevel `json:"newLogLevel"`
+}
+
+// dependencyLogRemaps raise the dependencies Renovate logs at the debug level
+// to the info level. The operator records the container images of a repository
+// from them, which registry triggers rely on.
+var dependencyLogRemaps = []LogLevelRemap{
+	{MatchMessage: parser.MsgPackageFilesWithUpdates, NewLogLevel: renovatev1beta1.LogLevel_INFO},
 }
 
 func (r *Reconciler) reconcileRenovateConfig(ctx context.Context) (*ctrl.Result, error) {
@@ -37,6 +52,10 @@ func (r *Reconciler) reconcileRenovateConfig(ctx context.Context) (*ctrl.Result,
 func (r *Reconciler) updateRenovateConfig(renovate *renovatev1beta1.RenovateConfig) error {
 	renovate.Spec = r.instance.Spec.Renovate
 
+	if renovate.Spec.Platform.GitHubApp != nil {
+		renovate.Spec.Platform.Token = r.gitHubAppTokenSource()
+	}
+
 	if renovate.Spec.Logging == nil {
 		renovate.Spec.Logging = &r.instance.Spec.Logging
 	}
@@ -70,6 +89,7 @@ func (r *Reconciler) updateConfigMap(cm *corev1.ConfigMap) error {
 		Platform:      r.instance.Spec.Renovate.Platform.Type,
 		Endpoint:      r.instance.Spec.Renovate.Platform.Endpoint,
 		AddLabels:     r.instance.Spec.Renovate.AddLabels,
+		LogLevelRemap: dependencyLogRemaps,
 	}
 
 	rc, err := json.Marshal(renovateConfig)
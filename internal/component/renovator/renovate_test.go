@@ -187,7 +187,8 @@ var _ = Describe("Renovator Renovate Functions", func() {
 			Expect(reconciler.updateConfigMap(configMap)).To(Succeed())
 			Expect(configMap.Data).To(HaveKeyWithValue(
 				"renovate.json",
-				`{"onboarding":false,"prHourlyLimit":0,"platform":"gitlab","endpoint":"https://gitlab.example.com/api/v4/"}`,
+				`{"onboarding":false,"prHourlyLimit":0,"platform":"gitlab","endpoint":"https://gitlab.example.com/api/v4/",`+
+					`"logLevelRemap":[{"matchMessage":"packageFiles with updates","newLogLevel":"info"}]}`,
 			))
 		})
 
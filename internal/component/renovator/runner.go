@@ -91,6 +91,11 @@ func (r *Reconciler) updateRunner(runner *renovatev1beta1.Runner) error {
 		runner.Spec.TTLSecondsAfterFinished = runnerSpec.TTLSecondsAfterFinished
 	}
 
+	runner.Spec.BlackoutWindows = spec.BlackoutWindows
+	if runnerSpec.BlackoutWindows != nil {
+		runner.Spec.BlackoutWindows = runnerSpec.BlackoutWindows
+	}
+
 	runner.Spec.NodeSelector = spec.NodeSelector
 	if runnerSpec.NodeSelector != nil {
 		runner.Spec.NodeSelector = runnerSpec.NodeSelector
@@ -147,6 +152,12 @@ func (r *Reconciler) updateRunner(runner *renovatev1beta1.Runner) error {
 	}
 
 	runner.Spec.MaxParallel = runnerSpec.MaxParallel
+	runner.Spec.Pool = runnerSpec.Pool
+	runner.Spec.Spread = runnerSpec.Spread
+	runner.Spec.Retry = runnerSpec.Retry
+	runner.Spec.BatchSize = runnerSpec.BatchSize
+	runner.Spec.BatchStrategy = runnerSpec.BatchStrategy
+	runner.Spec.ConcurrencyPolicy = runnerSpec.ConcurrencyPolicy
 
 	logging := &spec.Logging
 	if runnerSpec.Logging != nil {
@@ -2,6 +2,7 @@ package renovator
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -352,6 +353,29 @@ var _ = Describe("Renovator Runner", func() {
 			Expect(existingRunner.Spec.TTLSecondsAfterFinished).To(Equal(new(int32(600))))
 		})
 
+		It("should inherit blackout windows from the global spec and allow runner override", func() {
+			global := []renovatev1beta1.BlackoutWindowSpec{{Name: "freeze", End: new(metav1.Now())}}
+			renovator := &renovatev1beta1.Renovator{
+				Spec: renovatev1beta1.RenovatorSpec{
+					JobSpec: renovatev1beta1.JobSpec{BlackoutWindows: global},
+				},
+			}
+
+			reconciler, err := NewReconciler(ctx, fakeClient, scheme, renovator)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(reconciler.updateRunner(existingRunner)).To(Succeed())
+			Expect(existingRunner.Spec.BlackoutWindows).To(Equal(global))
+
+			runnerWindows := []renovatev1beta1.BlackoutWindowSpec{
+				{Name: "weekend", Schedule: "0 0 * * 6", Duration: &metav1.Duration{Duration: 48 * time.Hour}},
+			}
+			renovator.Spec.Runner.BlackoutWindows = runnerWindows
+
+			Expect(reconciler.updateRunner(existingRunner)).To(Succeed())
+			Expect(existingRunner.Spec.BlackoutWindows).To(Equal(runnerWindows))
+		})
+
 		It("should inherit ImagePullSecrets from the global spec and allow runner override", func() {
 			renovator := &renovatev1beta1.Renovator{
 				Spec: renovatev1beta1.RenovatorSpec{
@@ -0,0 +1,267 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/parser"
+	"github.com/thegeeklab/renovate-operator/internal/resource/renovate"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// batchQueue groups the pending runs into the batches processed by a single
+// renovate Job. The batches keep the dispatch order of their first GitRepo.
+// Without batching, every run is a batch of its own.
+func (r *Reconciler) batchQueue(ctx context.Context, queue []queuedRepo) [][]queuedRepo {
+	log := logf.FromContext(ctx)
+
+	size := r.instance.GetBatchSize()
+	batches := make([][]queuedRepo, 0, len(queue))
+
+	if size <= 1 {
+		for _, item := range queue {
+			batches = append(batches, []queuedRepo{item})
+		}
+
+		return batches
+	}
+
+	// open maps the grouping key to the index of the batch being filled.
+	open := make(map[string]int)
+
+	for _, item := range queue {
+		// A replaced job may still be reported as running until its deletion has
+		// been observed.
+		if item.replaced {
+			batches = append(batches, []queuedRepo{item})
+
+			continue
+		}
+
+		// Active jobs are only detected per GitRepo, a batch job must not include
+		// a GitRepo that is still processed by another job.
+		if isRepoRunning(item.repo) {
+			log.V(1).Info("Active renovate job found: skipping", "repo", item.repo.Name)
+
+			item.repo.Status.QueuePosition = 0
+
+			continue
+		}
+
+		if !isBatchable(item.repo) {
+			batches = append(batches, []queuedRepo{item})
+
+			continue
+		}
+
+		key := ""
+		if r.instance.Spec.BatchStrategy == renovatev1beta1.BatchStrategy_OWNER {
+			key = repoOwner(item.repo.Spec.Name)
+		}
+
+		if i, ok := open[key]; ok && len(batches[i]) < size {
+			batches[i] = append(batches[i], item)
+
+			continue
+		}
+
+		open[key] = len(batches)
+		batches = append(batches, []queuedRepo{item})
+	}
+
+	return batches
+}
+
+// isRepoRunning reports whether a renovate Job is active for the GitRepo.
+func isRepoRunning(repo *renovatev1beta1.GitRepo) bool {
+	cond := repo.GetCondition(renovatev1beta1.GitRepoConditionRenovateRunning)
+
+	return cond != nil && cond.Status == metav1.ConditionTrue
+}
+
+// isBatchable reports whether a GitRepo can share a renovate Job with other
+// GitRepos. GitRepos overriding the Job spec or requesting a debug run need a
+// Job of their own.
+func isBatchable(repo *renovatev1beta1.GitRepo) bool {
+	if isDebugRun(repo) {
+		return false
+	}
+
+	overrides := repo.Spec.Overrides
+	if overrides == nil {
+		return true
+	}
+
+	return overrides.Image == "" &&
+		overrides.Resources == nil &&
+		len(overrides.ExtraEnv) == 0 &&
+		overrides.NodeSelector == nil &&
+		overrides.Tolerations == nil &&
+		overrides.Timeout == nil
+}
+
+// repoOwner returns the owner or organization of a repository name, e.g.
+// `org/group` for `org/group/repo`.
+func repoOwner(name string) string {
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		return name[:i]
+	}
+
+	return ""
+}
+
+// ensureBatchJob creates a renovate job processing all GitRepos of the batch.
+// The job carries a batch label for each GitRepo to find it with the jobs of
+// the single GitRepos.
+func (r *Reconciler) ensureBatchJob(
+	ctx context.Context, batch []queuedRepo, labels map[string]string,
+) (bool, error) {
+	batchLabels := make(map[string]string, len(labels)+len(batch))
+	maps.Copy(batchLabels, labels)
+
+	repos := make([]*renovatev1beta1.GitRepo, 0, len(batch))
+
+	for _, item := range batch {
+		batchLabels[renovate.BatchLabel(item.labels[renovatev1beta1.LabelGitRepo])] = renovatev1beta1.ValueTrue
+		repos = append(repos, item.repo)
+	}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: r.instance.Name + "-batch-",
+			Namespace:    r.instance.Namespace,
+			Labels:       batchLabels,
+		},
+	}
+	if err := r.updateJob(job, repos, batchLabels); err != nil {
+		return false, fmt.Errorf("failed to update job: %w", err)
+	}
+
+	created, err := r.scheduler.EnsureJob(ctx, r.instance, job, batchLabels)
+	if err != nil {
+		return false, err
+	}
+
+	if !created {
+		return false, nil
+	}
+
+	if r.metrics != nil {
+		renovatorLabel := r.instance.Labels[renovatev1beta1.LabelRenovator]
+
+		r.metrics.RecordRunnerJob(r.instance.Namespace, renovatorLabel, r.instance.Name, "dispatched")
+	}
+
+	logf.FromContext(ctx).Info("Renovate batch job created", "job", job.Name, "repos", batchRepoNames(batch))
+
+	return true, nil
+}
+
+// batchRepoNames returns the names of the GitRepos of a batch.
+func batchRepoNames(batch []queuedRepo) []string {
+	names := make([]string, 0, len(batch))
+	for _, item := range batch {
+		names = append(names, item.repo.Name)
+	}
+
+	return names
+}
+
+// batchRepoLabels returns the labels selecting the batch jobs processing the
+// GitRepo of the given job labels.
+func batchRepoLabels(labels map[string]string) map[string]string {
+	batchLabels := maps.Clone(labels)
+	delete(batchLabels, renovatev1beta1.LabelGitRepo)
+
+	batchLabels[renovate.BatchLabel(labels[renovatev1beta1.LabelGitRepo])] = renovatev1beta1.ValueTrue
+
+	return batchLabels
+}
+
+// isBatchJob reports whether the job processes a batch of GitRepos.
+func isBatchJob(job *batchv1.Job) bool {
+	return len(renovate.BatchRepoLabels(job.Labels)) > 0
+}
+
+// isBatchLeader reports whether the GitRepo records the job level metrics of
+// a batch job. Only the first GitRepo of a batch records them to count every
+// job once.
+func isBatchLeader(job *batchv1.Job, repoLabel string) bool {
+	repoLabels := renovate.BatchRepoLabels(job.Labels)
+
+	return len(repoLabels) == 0 || repoLabels[0] == repoLabel
+}
+
+// readRepoLogs parses the logs of a finished job. For batch jobs, only the
+// entries of the given repository are considered. The logs of a batch job are
+// parsed once per reconciliation for all of its GitRepos. It returns nil if the
+// logs are unavailable.
+func (r *Reconciler) readRepoLogs(ctx context.Context, job *batchv1.Job, repoName string) *parser.ParseLogsResult {
+	if r.logReader == nil {
+		return nil
+	}
+
+	if results, ok := r.batchLogs[client.ObjectKeyFromObject(job)]; ok {
+		return results[repoName]
+	}
+
+	stream, err := r.logReader.ReadJobLogs(ctx, job.Namespace, job.Name, renovate.ContainerName, 0)
+	if err != nil {
+		logf.FromContext(ctx).V(1).Info(
+			"Failed to read job logs", "job", job.Name, "error", err,
+		)
+
+		return nil
+	}
+	defer stream.Close()
+
+	if !isBatchJob(job) {
+		res, err := parser.ParseLogs(stream, -1)
+		if err != nil {
+			logf.FromContext(ctx).V(1).Info(
+				"Failed to parse job logs", "job", job.Name, "error", err,
+			)
+
+			return nil
+		}
+
+		return res
+	}
+
+	results, err := parser.ParseLogsByRepository(stream, -1)
+	if err != nil {
+		logf.FromContext(ctx).V(1).Info(
+			"Failed to parse job logs", "job", job.Name, "error", err,
+		)
+
+		results = nil
+	}
+
+	if r.batchLogs == nil {
+		r.batchLogs = make(map[client.ObjectKey]map[string]*parser.ParseLogsResult)
+	}
+
+	r.batchLogs[client.ObjectKeyFromObject(job)] = results
+
+	return results[repoName]
+}
+
+// batchRepoSucceeded reports whether a failed batch job processed the GitRepo
+// successfully. Renovate fails the job if any repository logged errors, so the
+// outcome of a GitRepo is derived from its own log entries. The logs are only
+// parsed for a new run, later reconciliations keep the recorded outcome.
+func batchRepoSucceeded(repo *renovatev1beta1.GitRepo, res *parser.ParseLogsResult, newRun bool) bool {
+	if !newRun {
+		cond := repo.GetCondition(renovatev1beta1.GitRepoConditionRenovateCompleted)
+
+		return cond != nil && cond.Status == metav1.ConditionTrue
+	}
+
+	return res != nil && res.Finished && res.ErrorCount == 0
+}
@@ -0,0 +1,113 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/component/renovator"
+	"github.com/thegeeklab/renovate-operator/internal/scheduler"
+	batchv1 "k8s.io/api/batch/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// coalesceTrigger handles a trigger of a GitRepo with an active job according
+// to the concurrency policy of the Runner. It reports whether the triggered
+// run is dispatched right away. The status is patched by the caller.
+func (r *Reconciler) coalesceTrigger(
+	ctx context.Context, repo *renovatev1beta1.GitRepo, repoLabels map[string]string,
+) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	switch r.instance.Spec.ConcurrencyPolicy {
+	case renovatev1beta1.ConcurrencyPolicy_FORBID:
+		log.Info("Active renovate job found: dropping trigger", "repo", repo.Name)
+
+		removeRepoTrigger(repo)
+
+		return false, nil
+	case renovatev1beta1.ConcurrencyPolicy_REPLACE:
+		replaced, err := r.replaceRepoJobs(ctx, repo, repoLabels)
+		if err != nil {
+			return false, err
+		}
+
+		if replaced {
+			log.Info("Active renovate job replaced", "repo", repo.Name)
+
+			return true, nil
+		}
+	}
+
+	log.Info("Active renovate job found: queueing rerun", "repo", repo.Name)
+
+	setPendingRerun(repo)
+
+	return false, nil
+}
+
+// replaceRepoJobs deletes the active jobs of a GitRepo. Batch jobs are shared
+// with other GitRepos and are never replaced, it reports false if the GitRepo
+// is processed by a batch job.
+func (r *Reconciler) replaceRepoJobs(
+	ctx context.Context, repo *renovatev1beta1.GitRepo, repoLabels map[string]string,
+) (bool, error) {
+	batchCount, err := r.scheduler.CountActiveJobs(ctx, repo.Namespace, batchRepoLabels(repoLabels))
+	if err != nil {
+		return false, fmt.Errorf("failed to count active batch jobs: %w", err)
+	}
+
+	if batchCount > 0 {
+		return false, nil
+	}
+
+	jobList := &batchv1.JobList{}
+	if err := r.List(ctx, jobList, client.InNamespace(repo.Namespace), client.MatchingLabels(repoLabels)); err != nil {
+		return false, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	policy := metav1.DeletePropagationBackground
+
+	for i := range jobList.Items {
+		job := &jobList.Items[i]
+		if scheduler.IsJobFinished(job) {
+			continue
+		}
+
+		if err := r.Delete(ctx, job, &client.DeleteOptions{PropagationPolicy: &policy}); err != nil &&
+			!api_errors.IsNotFound(err) {
+			return false, fmt.Errorf("failed to delete job %s: %w", job.Name, err)
+		}
+	}
+
+	return true, nil
+}
+
+// setPendingRerun records a trigger of a GitRepo with an active job. Further
+// triggers are coalesced into the same rerun, which logs at the debug level if
+// any of them requested a debug run.
+func setPendingRerun(repo *renovatev1beta1.GitRepo) {
+	repo.Status.PendingRerun = true
+	repo.Status.PendingDebugRun = repo.Status.PendingDebugRun || isDebugRun(repo)
+
+	removeRepoTrigger(repo)
+}
+
+// clearPendingRerun removes the pending rerun of a GitRepo once a run was
+// dispatched.
+func clearPendingRerun(repo *renovatev1beta1.GitRepo) {
+	repo.Status.PendingRerun = false
+	repo.Status.PendingDebugRun = false
+}
+
+// removeRepoTrigger removes the renovate operation annotation of a GitRepo.
+func removeRepoTrigger(repo *renovatev1beta1.GitRepo) {
+	if !renovator.HasRenovatorOperationRenovate(repo.Annotations) {
+		return
+	}
+
+	repo.Annotations = renovator.RemoveRenovatorOperation(repo.Annotations)
+}
@@ -0,0 +1,35 @@
+package runner
+
+import (
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/component/renovator"
+)
+
+// debounceDue returns the time the debounced webhook trigger of a GitRepo is
+// due. The run starts once no further events arrived for the quiet period, but
+// not later than the maximum delay after the first event. It returns the zero
+// time if the trigger is not debounced.
+func debounceDue(repo *renovatev1beta1.GitRepo) time.Time {
+	debounce := repo.Spec.Webhooks.Debounce
+	if debounce == nil || !renovator.IsWebhookTriggered(repo.Annotations) {
+		return time.Time{}
+	}
+
+	last, err := time.Parse(time.RFC3339, repo.Annotations[renovatev1beta1.AnnotationWebhookLastEvent])
+	if err != nil {
+		return time.Time{}
+	}
+
+	due := last.Add(debounce.QuietPeriod.Duration)
+
+	if debounce.MaxDelay != nil {
+		first, err := time.Parse(time.RFC3339, repo.Annotations[renovatev1beta1.AnnotationWebhookFirstEvent])
+		if err == nil && first.Add(debounce.MaxDelay.Duration).Before(due) {
+			due = first.Add(debounce.MaxDelay.Duration)
+		}
+	}
+
+	return due
+}
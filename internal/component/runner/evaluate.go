@@ -0,0 +1,327 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/component/renovator"
+	"github.com/thegeeklab/renovate-operator/internal/scheduler"
+	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// evaluation holds the state shared by the evaluation of all GitRepos of a
+// reconciliation.
+type evaluation struct {
+	// decision is the schedule decision of the Runner.
+	decision   scheduler.DecisionResult
+	blackout   scheduler.Blackout
+	inBlackout bool
+	result     *processResult
+}
+
+// repoEvaluation is the evaluation of a GitRepo in a reconciliation. The
+// evaluation steps only change the GitRepo in memory, the changes are patched
+// once after the pending runs were dispatched.
+type repoEvaluation struct {
+	repo *renovatev1beta1.GitRepo
+	// base is the GitRepo before the evaluation the patches are computed from.
+	base     *renovatev1beta1.GitRepo
+	labels   map[string]string
+	decision scheduler.DecisionResult
+	// run is the finished job of the GitRepo observed for the first time.
+	run observedRun
+
+	// triggered is true if a manual or webhook trigger of the GitRepo is due.
+	triggered bool
+	// replaced is true if the active job of the GitRepo was deleted for the run.
+	replaced bool
+	// rerunDue is true if a trigger recorded while the previous job was active
+	// is due.
+	rerunDue bool
+	// spreadDue is true if the spread scheduled run of the GitRepo is due.
+	spreadDue bool
+	// retryDue is true if the retry of a failed run is due.
+	retryDue bool
+	// queued is true if the run is waiting in the queue for a free job slot.
+	queued bool
+}
+
+// pending reports whether a run of the GitRepo is waiting to be dispatched.
+func (e *repoEvaluation) pending() bool {
+	return e.decision.ShouldRun || e.triggered || e.rerunDue || e.spreadDue || e.retryDue || e.queued
+}
+
+// queuedRepo returns the pending run of the GitRepo to dispatch. It returns
+// false if no run is pending.
+func (e *repoEvaluation) queuedRepo() (queuedRepo, bool) {
+	if !e.pending() {
+		return queuedRepo{}, false
+	}
+
+	return queuedRepo{
+		repo:      e.repo,
+		labels:    e.labels,
+		decision:  e.decision,
+		triggered: e.triggered || e.rerunDue,
+		retry:     e.retryDue && !e.decision.ShouldRun && !e.triggered && !e.rerunDue && !e.spreadDue,
+		replaced:  e.replaced,
+	}, true
+}
+
+// evaluateRepoRun updates the job status of a GitRepo and evaluates whether a
+// run is pending. It returns nil if the GitRepo cannot be processed at all.
+func (r *Reconciler) evaluateRepoRun(
+	ctx context.Context, eval *evaluation, repo *renovatev1beta1.GitRepo, labels map[string]string,
+) (*repoEvaluation, error) {
+	log := logf.FromContext(ctx)
+
+	repoLabels := make(map[string]string, len(labels)+1)
+	maps.Copy(repoLabels, labels)
+
+	repoLabel, err := k8s.SanitizeLabel(repo.Name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sanitize repo name for label: %w", err)
+	}
+
+	repoLabels[renovatev1beta1.LabelGitRepo] = repoLabel
+
+	repoEval := &repoEvaluation{
+		repo:   repo,
+		base:   repo.DeepCopy(),
+		labels: repoLabels,
+	}
+
+	run, err := r.updateJobStatus(ctx, repo, repoLabels)
+	if err != nil {
+		log.Error(err, "Failed to update job status", "repo", repo.Name)
+	}
+
+	repoEval.run = run
+
+	for _, pruneLabels := range []map[string]string{repoLabels, batchRepoLabels(repoLabels)} {
+		if err := r.scheduler.PruneJobs(
+			ctx, repo.Namespace, pruneLabels, r.instance.GetSuccessLimit(), r.instance.GetFailedLimit(),
+		); err != nil {
+			log.Error(err, "Failed to clean up old jobs", "repo", repo.Name)
+		}
+	}
+
+	repoEval.decision, err = r.evaluateRepo(repo, eval.decision)
+	if err != nil {
+		return repoEval, fmt.Errorf("failed to evaluate schedule: %w", err)
+	}
+
+	r.evaluateSchedule(ctx, eval, repoEval)
+	r.evaluateTrigger(ctx, eval, repoEval)
+
+	if err := r.evaluateConcurrency(ctx, eval, repoEval); err != nil {
+		return repoEval, err
+	}
+
+	r.evaluateRetry(eval, repoEval)
+	r.evaluateQueue(eval, repoEval)
+
+	return repoEval, nil
+}
+
+// evaluateSchedule evaluates the scheduled run of a GitRepo. Scheduled runs
+// are deferred to their due time inside the spread window of the Runner, and
+// skipped if the GitRepo is annotated to skip its next scheduled run.
+func (r *Reconciler) evaluateSchedule(ctx context.Context, eval *evaluation, repoEval *repoEvaluation) {
+	repo := repoEval.repo
+
+	if hasRepoSchedule(repo) && !repoEval.decision.ShouldRun && repoEval.decision.Trigger != scheduler.TriggerSuspended {
+		eval.result.trackNextRun(repoEval.decision.NextRun)
+	}
+
+	if r.instance.Spec.Spread != nil && !hasRepoSchedule(repo) && repoEval.decision.Trigger == scheduler.TriggerSchedule {
+		repoEval.decision.ShouldRun = false
+
+		r.spreadRepoRun(repo)
+	}
+
+	if next := repo.Status.NextRunTime; next != nil {
+		switch {
+		case r.scheduler.Now().Before(next.Time):
+			eval.result.trackNextRun(next.Time)
+		case eval.inBlackout:
+			eval.result.trackNextRun(eval.blackout.End)
+		case !r.instance.GetSuspend():
+			repoEval.spreadDue = true
+		}
+	}
+
+	if !r.skipScheduledRun(repo, repoEval.decision, repoEval.spreadDue) {
+		return
+	}
+
+	logf.FromContext(ctx).Info("Skipped scheduled run", "repo", repo.Name)
+
+	repoEval.decision.ShouldRun = false
+	repoEval.spreadDue = false
+
+	if hasRepoSchedule(repo) {
+		if next, err := r.evaluateRepo(repo, eval.decision); err == nil {
+			eval.result.trackNextRun(next.NextRun)
+		}
+	}
+}
+
+// evaluateTrigger evaluates the manual or webhook trigger of a GitRepo.
+// Webhook triggered runs are deferred while a blackout window blocks webhooks
+// and while their events are debounced. The trigger is kept to run the
+// GitRepo once it is due.
+func (r *Reconciler) evaluateTrigger(ctx context.Context, eval *evaluation, repoEval *repoEvaluation) {
+	log := logf.FromContext(ctx)
+	repo := repoEval.repo
+
+	if !renovator.HasRenovatorOperationRenovate(repo.Annotations) {
+		return
+	}
+
+	if eval.blocksWebhooks() && renovator.IsWebhookTriggered(repo.Annotations) {
+		log.V(1).Info(
+			"Blackout window active: deferring webhook triggered run",
+			"repo", repo.Name, "window", eval.blackout.Name, "until", eval.blackout.End,
+		)
+
+		eval.result.trackNextRun(eval.blackout.End)
+
+		return
+	}
+
+	if due := debounceDue(repo); r.scheduler.Now().Before(due) {
+		log.V(1).Info("Debouncing webhook triggered run", "repo", repo.Name, "until", due)
+
+		eval.result.trackNextRun(due)
+
+		return
+	}
+
+	repoEval.triggered = true
+}
+
+// evaluateConcurrency handles the triggers of a GitRepo with an active job
+// according to the concurrency policy of the Runner instead of waiting for the
+// job to finish, and evaluates whether a rerun recorded while the job was
+// active is due.
+func (r *Reconciler) evaluateConcurrency(ctx context.Context, eval *evaluation, repoEval *repoEvaluation) error {
+	repo := repoEval.repo
+
+	manualRun := repoEval.decision.ShouldRun && repoEval.decision.Trigger == scheduler.TriggerManual
+	if (repoEval.triggered || manualRun) && isRepoRunning(repo) {
+		dispatch, err := r.coalesceTrigger(ctx, repo, repoEval.labels)
+		if err != nil {
+			return fmt.Errorf("failed to handle trigger of active job: %w", err)
+		}
+
+		if !dispatch {
+			repoEval.triggered = false
+			repoEval.decision.ShouldRun = repoEval.decision.ShouldRun && !manualRun
+		}
+
+		repoEval.replaced = dispatch
+	}
+
+	if repo.Status.PendingRerun && !isRepoRunning(repo) {
+		if eval.blocksWebhooks() {
+			eval.result.trackNextRun(eval.blackout.End)
+		} else {
+			repoEval.rerunDue = true
+		}
+	}
+
+	return nil
+}
+
+// evaluateRetry evaluates whether the retry of a failed run of a GitRepo is
+// due.
+func (r *Reconciler) evaluateRetry(eval *evaluation, repoEval *repoEvaluation) {
+	next := repoEval.repo.Status.NextRetryTime
+	if next == nil {
+		return
+	}
+
+	switch {
+	case r.scheduler.Now().Before(next.Time):
+		eval.result.trackNextRun(next.Time)
+	case eval.inBlackout:
+		eval.result.trackNextRun(eval.blackout.End)
+	case !r.instance.GetSuspend():
+		repoEval.retryDue = true
+	}
+}
+
+// evaluateQueue keeps a run waiting in the queue across reconciliations until
+// a job slot is free and counts the pending runs. GitRepos without a pending
+// run are removed from the queue.
+func (r *Reconciler) evaluateQueue(eval *evaluation, repoEval *repoEvaluation) {
+	repo := repoEval.repo
+
+	if repo.Status.QueuePosition > 0 {
+		switch {
+		case eval.inBlackout:
+			eval.result.trackNextRun(eval.blackout.End)
+		case !r.instance.GetSuspend():
+			repoEval.queued = true
+		}
+	}
+
+	if !repoEval.pending() {
+		if !eval.inBlackout {
+			repo.Status.QueuePosition = 0
+		}
+
+		return
+	}
+
+	eval.result.pending++
+
+	if isRepoRunning(repo) {
+		eval.result.running++
+	}
+}
+
+// blocksWebhooks reports whether an active blackout window suppresses webhook
+// triggered runs.
+func (e *evaluation) blocksWebhooks() bool {
+	return e.inBlackout && e.blackout.BlockWebhooks
+}
+
+// patchRepo applies the changes of the evaluation to the GitRepo with a single
+// status patch and a single patch of its annotations. The metrics of a newly
+// observed run are recorded once its status was patched.
+func (r *Reconciler) patchRepo(ctx context.Context, repoEval *repoEvaluation) error {
+	repo := repoEval.repo
+	annotations := maps.Clone(repo.Annotations)
+
+	if !equality.Semantic.DeepEqual(repoEval.base.Status, repo.Status) {
+		statusBase := repo.DeepCopy()
+		statusBase.Status = repoEval.base.Status
+
+		if err := r.Status().Patch(ctx, repo, client.MergeFrom(statusBase)); err != nil {
+			return fmt.Errorf("failed to patch status of GitRepo %s: %w", repo.Name, err)
+		}
+	}
+
+	r.recordRunMetrics(repo, repoEval.run)
+
+	if maps.Equal(repoEval.base.Annotations, annotations) {
+		return nil
+	}
+
+	annotationBase := repo.DeepCopy()
+	annotationBase.Annotations = repoEval.base.Annotations
+	repo.Annotations = annotations
+
+	if err := r.Patch(ctx, repo, client.MergeFrom(annotationBase)); err != nil {
+		return fmt.Errorf("failed to patch annotations of GitRepo %s: %w", repo.Name, err)
+	}
+
+	return nil
+}
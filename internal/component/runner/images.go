@@ -0,0 +1,19 @@
+package runner
+
+import (
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/parser"
+)
+
+// updateRepoImages records the container images found by a newly finished run
+// in the status of the GitRepo. The images are kept if the logs of the run are
+// unavailable or the run did not finish, as they might be incomplete. The
+// Renovate config of the Renovator raises the dependencies Renovate logs at the
+// debug level to the info level. The status is patched by the caller.
+func updateRepoImages(repo *renovatev1beta1.GitRepo, res *parser.ParseLogsResult) {
+	if res == nil || !res.Finished {
+		return
+	}
+
+	repo.Status.Images = res.Dependencies.Images
+}
@@ -2,8 +2,9 @@ package runner
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"maps"
+	"slices"
 	"strings"
 	"time"
 
@@ -46,8 +47,12 @@ func (r *Reconciler) reconcileJob(ctx context.Context) (*ctrl.Result, error) {
 		return &ctrl.Result{}, fmt.Errorf("failed to evaluate schedule: %w", err)
 	}
 
+	if err := r.updateBlackoutStatus(ctx); err != nil {
+		return &ctrl.Result{}, err
+	}
+
 	// Process all GitRepo resources
-	triggeredAny, runningCount, pendingCount, err := r.processGitRepos(ctx, decision.ShouldRun, runnerLabels)
+	processed, err := r.processGitRepos(ctx, decision, runnerLabels)
 	if err != nil {
 		if r.metrics != nil && decision.Trigger == scheduler.TriggerSchedule {
 			r.metrics.RecordRunnerScheduleRun(r.instance.Namespace, renovatorName, r.instance.Name, "error")
@@ -57,19 +62,23 @@ func (r *Reconciler) reconcileJob(ctx context.Context) (*ctrl.Result, error) {
 	}
 
 	if r.metrics != nil {
-		if decision.ShouldRun {
-			r.metrics.SetRunnerQueueDepth(r.instance.Namespace, renovatorName, r.instance.Name, pendingCount-runningCount)
-			r.metrics.SetRunnerRunning(r.instance.Namespace, renovatorName, r.instance.Name, runningCount)
+		if decision.ShouldRun || processed.pending > 0 {
+			r.metrics.SetRunnerQueueDepth(r.instance.Namespace, renovatorName, r.instance.Name, processed.queued)
+			r.metrics.SetRunnerRunning(r.instance.Namespace, renovatorName, r.instance.Name, processed.running)
 		} else {
 			r.metrics.SetRunnerQueueDepth(r.instance.Namespace, renovatorName, r.instance.Name, 0)
 			r.metrics.SetRunnerRunning(r.instance.Namespace, renovatorName, r.instance.Name, 0)
 		}
 	}
 
-	if decision.Trigger == scheduler.TriggerSuspended && !triggeredAny {
+	if decision.Trigger == scheduler.TriggerSuspended && !processed.triggeredAny {
 		log.V(1).Info("Runner is suspended: suppressing scheduled run")
 	}
 
+	if decision.Trigger == scheduler.TriggerBlackout {
+		log.V(1).Info("Runner is in a blackout window: suppressing scheduled run", "until", decision.NextRun)
+	}
+
 	if decision.ShouldRun {
 		log.Info("Runner run active", "trigger", decision.Trigger)
 
@@ -97,10 +106,15 @@ func (r *Reconciler) reconcileJob(ctx context.Context) (*ctrl.Result, error) {
 		)
 	}
 
+	nextRun := nextDecision.NextRun
+	if !processed.nextRun.IsZero() && processed.nextRun.Before(nextRun) {
+		nextRun = processed.nextRun
+	}
+
 	now := time.Now()
-	if nextDecision.NextRun.After(now) {
-		waitDuration := nextDecision.NextRun.Sub(now)
-		log.V(1).Info("Next runner execution scheduled", "time", nextDecision.NextRun, "wait", waitDuration)
+	if nextRun.After(now) {
+		waitDuration := nextRun.Sub(now)
+		log.V(1).Info("Next runner execution scheduled", "time", nextRun, "wait", waitDuration)
 
 		return &ctrl.Result{RequeueAfter: waitDuration}, nil
 	}
@@ -108,14 +122,34 @@ func (r *Reconciler) reconcileJob(ctx context.Context) (*ctrl.Result, error) {
 	return &ctrl.Result{}, nil
 }
 
-// processGitRepos processes each GitRepo and creates jobs if needed.
+// processResult summarizes the GitRepos processed in a reconciliation.
+type processResult struct {
+	triggeredAny bool
+	running      int
+	pending      int
+	// queued is the number of pending GitRepos waiting for a free job slot.
+	queued int
+	// nextRun is the earliest time a GitRepo needs to be evaluated again, e.g. the
+	// next run of a GitRepo with its own schedule.
+	nextRun time.Time
+}
+
+// trackNextRun records the next time a GitRepo needs to be evaluated.
+func (p *processResult) trackNextRun(nextRun time.Time) {
+	if p.nextRun.IsZero() || nextRun.Before(p.nextRun) {
+		p.nextRun = nextRun
+	}
+}
+
+// processGitRepos processes each GitRepo and creates jobs if needed. Pending
+// runs are dispatched in queue order, runs exceeding the parallel job limit
+// keep their position in the queue until a job slot is free. Every GitRepo is
+// patched once after the pending runs were dispatched.
 func (r *Reconciler) processGitRepos(
-	ctx context.Context, isGlobalTrigger bool, labels map[string]string,
-) (bool, int, int, error) {
+	ctx context.Context, decision scheduler.DecisionResult, labels map[string]string,
+) (processResult, error) {
 	log := logf.FromContext(ctx)
-	triggeredAny := false
-	runningCount := 0
-	pendingCount := 0
+	result := processResult{}
 
 	gitRepos := &renovatev1beta1.GitRepoList{}
 
@@ -129,9 +163,64 @@ func (r *Reconciler) processGitRepos(
 	}
 
 	if err := r.List(ctx, gitRepos, listOpts...); err != nil {
-		return false, 0, 0, fmt.Errorf("failed to list GitRepos: %w", err)
+		return result, fmt.Errorf("failed to list GitRepos: %w", err)
+	}
+
+	blackout, inBlackout, err := r.scheduler.ActiveBlackout(r.instance)
+	if err != nil {
+		return result, fmt.Errorf("failed to evaluate blackout windows: %w", err)
+	}
+
+	eval := &evaluation{
+		decision:   decision,
+		blackout:   blackout,
+		inBlackout: inBlackout,
+		result:     &result,
+	}
+
+	repoEvals := make([]*repoEvaluation, 0, len(gitRepos.Items))
+	queue := make([]queuedRepo, 0, len(gitRepos.Items))
+
+	for i := range gitRepos.Items {
+		repo := &gitRepos.Items[i]
+
+		repoEval, err := r.evaluateRepoRun(ctx, eval, repo, labels)
+		if repoEval != nil {
+			repoEvals = append(repoEvals, repoEval)
+		}
+
+		if err != nil {
+			log.Error(err, "Failed to evaluate GitRepo", "repo", repo.Name)
+
+			continue
+		}
+
+		if item, ok := repoEval.queuedRepo(); ok {
+			queue = append(queue, item)
+		}
+	}
+
+	// The evaluated changes are patched even if no job could be dispatched.
+	dispatchErr := r.dispatchQueue(ctx, queue, decision, labels, &result)
+
+	for _, repoEval := range repoEvals {
+		if err := r.patchRepo(ctx, repoEval); err != nil {
+			log.Error(err, "Failed to patch GitRepo", "repo", repoEval.repo.Name)
+		}
 	}
 
+	return result, dispatchErr
+}
+
+// dispatchQueue creates the jobs of the pending runs in queue order. Runs
+// exceeding the parallel job limit or the capacity of the runner pool keep
+// their position in the queue.
+func (r *Reconciler) dispatchQueue(
+	ctx context.Context, queue []queuedRepo, decision scheduler.DecisionResult, labels map[string]string,
+	result *processResult,
+) error {
+	log := logf.FromContext(ctx)
+
 	maxParallel := r.instance.GetMaxParallel()
 
 	var activeCount int
@@ -139,83 +228,101 @@ func (r *Reconciler) processGitRepos(
 	if maxParallel > 0 {
 		count, err := r.scheduler.CountActiveJobs(ctx, r.instance.Namespace, labels)
 		if err != nil {
-			return false, 0, 0, fmt.Errorf("failed to count active jobs: %w", err)
+			return fmt.Errorf("failed to count active jobs: %w", err)
 		}
 
 		activeCount = count
 	}
 
-	for _, repo := range gitRepos.Items {
-		repoLabels := make(map[string]string, len(labels)+1)
-		maps.Copy(repoLabels, labels)
+	sortQueue(queue)
 
-		repoLabel, err := k8s.SanitizeLabel(repo.Name)
-		if err != nil {
-			log.Error(err, "Failed to sanitize repo name for label", "repo", repo.Name)
+	poolFull := false
+
+	for _, batch := range r.batchQueue(ctx, queue) {
+		if poolFull || (maxParallel > 0 && activeCount >= maxParallel) {
+			for _, item := range batch {
+				queueRepo(ctx, item.repo, result)
+			}
 
 			continue
 		}
 
-		repoLabels[renovatev1beta1.LabelGitRepo] = repoLabel
-
-		if err := r.updateJobStatus(ctx, &repo, repoLabels); err != nil {
-			log.Error(err, "Failed to update job status", "repo", repo.Name)
-		}
+		var (
+			created bool
+			err     error
+		)
 
-		if err := r.scheduler.PruneJobs(
-			ctx, repo.Namespace, repoLabels, r.instance.GetSuccessLimit(), r.instance.GetFailedLimit(),
-		); err != nil {
-			log.Error(err, "Failed to clean up old jobs", "repo", repo.Name)
+		if len(batch) == 1 {
+			created, err = r.ensureRepoJob(ctx, batch[0].repo, batch[0].labels)
+		} else {
+			created, err = r.ensureBatchJob(ctx, batch, labels)
 		}
 
-		hasRepoAnnotation := renovator.HasRenovatorOperationRenovate(repo.Annotations)
-		if !isGlobalTrigger && !hasRepoAnnotation {
-			continue
-		}
+		if errors.Is(err, scheduler.ErrRunnerPoolFull) {
+			log.V(1).Info("Runner pool exhausted, queueing remaining repos", "pool", r.instance.GetRunnerPool())
 
-		pendingCount++
+			poolFull = true
 
-		if repo.GetCondition(renovatev1beta1.GitRepoConditionRenovateRunning) != nil &&
-			repo.GetCondition(renovatev1beta1.GitRepoConditionRenovateRunning).Status == metav1.ConditionTrue {
-			runningCount++
-		}
+			result.trackNextRun(r.scheduler.Now().Add(scheduler.PoolRetryInterval))
 
-		if maxParallel > 0 && activeCount >= maxParallel {
-			log.V(1).Info(
-				"Max parallel jobs reached, skipping repo",
-				"repo", repo.Name, "active", activeCount, "max", maxParallel,
-			)
+			for _, item := range batch {
+				queueRepo(ctx, item.repo, result)
+			}
 
 			continue
 		}
 
-		created, err := r.ensureRepoJob(ctx, &repo, repoLabels)
+		for _, item := range batch {
+			item.repo.Status.QueuePosition = 0
+		}
+
 		if err != nil {
-			log.Error(err, "Failed to ensure job", "repo", repo.Name)
+			log.Error(err, "Failed to ensure job", "repos", batchRepoNames(batch))
 
 			continue
 		}
 
 		if !created {
-			log.V(1).Info("Active renovate job found: skipping", "repo", repo.Name)
+			log.V(1).Info("Active renovate job found: skipping", "repos", batchRepoNames(batch))
 
 			continue
 		}
 
 		activeCount++
-		triggeredAny = true
+		result.triggeredAny = true
+
+		for _, item := range batch {
+			r.completeRepoDispatch(item, decision, result)
+		}
+	}
 
-		if hasRepoAnnotation {
-			patch := client.MergeFrom(repo.DeepCopy())
+	return nil
+}
 
-			repo.Annotations = renovator.RemoveRenovatorOperation(repo.Annotations)
-			if err := r.Patch(ctx, &repo, patch); err != nil {
-				log.Error(err, "Failed to remove annotation", "repo", repo.Name)
-			}
+// completeRepoDispatch finishes the pending run of a GitRepo once its job has
+// been created.
+func (r *Reconciler) completeRepoDispatch(item queuedRepo, decision scheduler.DecisionResult, result *processResult) {
+	repo := item.repo
+
+	if hasRepoSchedule(repo) && item.decision.Trigger == scheduler.TriggerSchedule {
+		r.completeRepoRun(repo)
+
+		if next, err := r.evaluateRepo(repo, decision); err == nil {
+			result.trackNextRun(next.NextRun)
 		}
 	}
 
-	return triggeredAny, runningCount, pendingCount, nil
+	repo.Status.NextRunTime = nil
+
+	clearRepoRetry(repo, item.retry)
+
+	// Any dispatched run covers the triggers recorded while the previous job
+	// was active.
+	clearPendingRerun(repo)
+
+	if item.triggered {
+		removeRepoTrigger(repo)
+	}
 }
 
 // ensureRepoJob creates a renovate job for the given repository when none is
@@ -245,7 +352,7 @@ func (r *Reconciler) ensureRepoJob(
 			Labels:       repoLabels,
 		},
 	}
-	if err := r.updateJob(job, repo, repoLabels); err != nil {
+	if err := r.updateJob(job, []*renovatev1beta1.GitRepo{repo}, repoLabels); err != nil {
 		return false, fmt.Errorf("failed to update job: %w", err)
 	}
 
@@ -269,9 +376,9 @@ func (r *Reconciler) ensureRepoJob(
 	return true, nil
 }
 
-// updateJob configures the job spec for a GitRepo.
+// updateJob configures the job spec for the GitRepos processed by the job.
 func (r *Reconciler) updateJob(
-	job *batchv1.Job, repo *renovatev1beta1.GitRepo, podLabels map[string]string,
+	job *batchv1.Job, repos []*renovatev1beta1.GitRepo, podLabels map[string]string,
 ) error {
 	renovateConfigCM := metadata.GenericName(r.req, renovator.ConfigMapSuffix)
 
@@ -280,11 +387,17 @@ func (r *Reconciler) updateJob(
 			"namespace": r.instance.Namespace,
 			"renovator": r.instance.Labels[renovatev1beta1.LabelRenovator],
 			"runner":    r.instance.Name,
-			"gitrepo":   repo.Name,
+			"gitrepo":   "",
 			"discovery": "",
 		}
-		if ownerRef := metav1.GetControllerOf(repo); ownerRef != nil && ownerRef.Kind == "Discovery" {
-			vars["discovery"] = ownerRef.Name
+
+		// A batch job is not associated with a single GitRepo.
+		if len(repos) == 1 {
+			vars["gitrepo"] = repos[0].Name
+
+			if ownerRef := metav1.GetControllerOf(repos[0]); ownerRef != nil && ownerRef.Kind == "Discovery" {
+				vars["discovery"] = ownerRef.Name
+			}
 		}
 
 		if err := util.MergeRenderedPodLabels(podLabels, r.instance.Spec.PodLabelTemplates, vars); err != nil {
@@ -292,19 +405,32 @@ func (r *Reconciler) updateJob(
 		}
 	}
 
-	// Set default job spec for the repository
-	renovate.DefaultJobSpec(
-		&job.Spec,
-		r.renovate,
-		renovateConfigCM,
+	repoNames := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		repoNames = append(repoNames, repo.Spec.Name)
+	}
+
+	opts := []renovate.JobOption{
 		renovate.WithRenovateJobSpec(r.instance.Spec.JobSpec),
 		renovate.WithPodLabels(podLabels),
-		renovate.WithRepository(repo.Spec.Name),
+		renovate.WithRepositories(repoNames),
 		renovate.WithImagePullSecrets(r.instance.Spec.ImagePullSecrets),
 		renovate.WithPodSpec(r.instance.Spec.PodSpec),
 		renovate.WithExtraEnv(r.instance.Spec.ExtraEnv),
 		renovate.WithExtraVolumes(containers.WithRawVolumes(r.instance.Spec.ExtraVolumes)),
-	)
+	}
+
+	// Batches only contain GitRepos without overrides for the job.
+	if len(repos) == 1 {
+		opts = append(opts, renovate.WithGitRepoOverrides(repos[0].Spec.Overrides))
+
+		if isDebugRun(repos[0]) {
+			opts = append(opts, renovate.WithLogLevel(renovatev1beta1.LogLevel_DEBUG))
+		}
+	}
+
+	// Set default job spec for the repositories
+	renovate.DefaultJobSpec(&job.Spec, r.renovate, renovateConfigCM, opts...)
 
 	// Configure job execution details
 	job.Spec.Template.Spec.ServiceAccountName = metadata.GenericMetadata(r.req).Name
@@ -312,24 +438,45 @@ func (r *Reconciler) updateJob(
 	return nil
 }
 
+// observedRun is the finished job of a GitRepo observed by a reconciliation for
+// the first time.
+type observedRun struct {
+	// job is the finished job, nil if no new run was observed.
+	job *batchv1.Job
+	// status is the outcome of the run for the GitRepo.
+	status string
+	// logs are the parsed job logs of the GitRepo, nil if unavailable.
+	logs *parser.ParseLogsResult
+}
+
 // updateJobStatus checks for jobs and updates the GitRepo's status conditions
-// and LastRenovateTime based on the most recent job state.
+// and LastRenovateTime based on the most recent job state. It returns the
+// finished job if it was not observed before. The status is patched by the
+// caller.
 func (r *Reconciler) updateJobStatus(
 	ctx context.Context, repo *renovatev1beta1.GitRepo, labels map[string]string,
-) error {
-	var jobList batchv1.JobList
+) (observedRun, error) {
+	var jobList, batchJobList batchv1.JobList
 
 	if err := r.List(ctx, &jobList, client.InNamespace(repo.Namespace), client.MatchingLabels(labels)); err != nil {
-		return fmt.Errorf("failed to list jobs: %w", err)
+		return observedRun{}, fmt.Errorf("failed to list jobs: %w", err)
+	}
+
+	if err := r.List(
+		ctx, &batchJobList, client.InNamespace(repo.Namespace), client.MatchingLabels(batchRepoLabels(labels)),
+	); err != nil {
+		return observedRun{}, fmt.Errorf("failed to list batch jobs: %w", err)
 	}
 
+	jobs := slices.Concat(jobList.Items, batchJobList.Items)
+
 	var (
 		latestFinishedJob *batchv1.Job
 		hasActiveJob      bool
 	)
 
-	for i := range jobList.Items {
-		job := &jobList.Items[i]
+	for i := range jobs {
+		job := &jobs[i]
 
 		if !scheduler.IsJobFinished(job) {
 			hasActiveJob = true
@@ -342,8 +489,6 @@ func (r *Reconciler) updateJobStatus(
 		}
 	}
 
-	patch := client.MergeFrom(repo.DeepCopy())
-
 	if hasActiveJob {
 		repo.SetCondition(
 			renovatev1beta1.GitRepoConditionRenovateRunning,
@@ -358,116 +503,145 @@ func (r *Reconciler) updateJobStatus(
 		)
 	}
 
-	var (
-		runStatus    string
-		previousLast *metav1.Time
-	)
+	if latestFinishedJob == nil {
+		return observedRun{}, nil
+	}
 
-	if latestFinishedJob != nil {
-		previousLast = repo.GetLastRenovateTime()
-
-		switch {
-		case latestFinishedJob.Status.Succeeded > 0:
-			repo.SetCondition(
-				renovatev1beta1.GitRepoConditionRenovateCompleted,
-				metav1.ConditionTrue,
-				"JobSucceeded", "Renovate job completed successfully",
-			)
-			repo.RemoveCondition(renovatev1beta1.GitRepoConditionRenovateFailed)
-
-			runStatus = metrics.StatusSucceeded
-		case latestFinishedJob.Status.Failed > 0:
-			repo.SetCondition(
-				renovatev1beta1.GitRepoConditionRenovateFailed,
-				metav1.ConditionTrue,
-				"JobFailed", "Renovate job failed",
-			)
-			repo.RemoveCondition(renovatev1beta1.GitRepoConditionRenovateCompleted)
-
-			runStatus = metrics.StatusFailed
-		default:
-			repo.RemoveCondition(renovatev1beta1.GitRepoConditionRenovateCompleted)
-			repo.RemoveCondition(renovatev1beta1.GitRepoConditionRenovateFailed)
-
-			logf.FromContext(ctx).Info(
-				"finished job has no Succeeded/Failed counters, skipping metric emission",
-				"job", latestFinishedJob.Name,
-				"namespace", latestFinishedJob.Namespace,
-				"succeeded", latestFinishedJob.Status.Succeeded,
-				"failed", latestFinishedJob.Status.Failed,
-			)
-		}
+	previousLast := repo.GetLastRenovateTime()
+
+	// newRun is true if the job has not been observed by a previous
+	// reconciliation.
+	newRun := previousLast == nil || latestFinishedJob.CreationTimestamp.After(previousLast.Time)
+
+	var logs *parser.ParseLogsResult
 
-		repo.SetLastRenovateTime(&latestFinishedJob.CreationTimestamp)
+	// The logs of a job are parsed once, when its run is observed.
+	if newRun {
+		logs = r.readRepoLogs(ctx, latestFinishedJob, repo.Spec.Name)
 	}
 
-	if err := r.Status().Patch(ctx, repo, patch); err != nil {
-		return fmt.Errorf("failed to patch job status: %w", err)
+	succeeded := latestFinishedJob.Status.Succeeded > 0
+	failed := latestFinishedJob.Status.Failed > 0
+
+	// A failed batch job may have processed the GitRepo successfully.
+	if failed && isBatchJob(latestFinishedJob) {
+		succeeded = batchRepoSucceeded(repo, logs, newRun)
+		failed = !succeeded
 	}
 
-	if r.metrics != nil && latestFinishedJob != nil && runStatus != "" &&
-		(previousLast == nil || latestFinishedJob.CreationTimestamp.After(previousLast.Time)) {
-		renovatorLabel := repo.Labels[renovatev1beta1.LabelRenovator]
-		gitrepoLabel, _ := k8s.SanitizeLabel(repo.Name)
+	var runStatus string
 
-		r.metrics.RecordGitRepoRun(
-			repo.Namespace, renovatorLabel, r.instance.Name, gitrepoLabel, runStatus,
-		)
-		r.metrics.SetRunFailed(
-			repo.Namespace, renovatorLabel, r.instance.Name, gitrepoLabel,
-			runStatus == metrics.StatusFailed,
-		)
-		r.metrics.SetLastRunTimestamp(
-			repo.Namespace, renovatorLabel, r.instance.Name, gitrepoLabel,
-			float64(latestFinishedJob.CreationTimestamp.Unix()),
+	switch {
+	case succeeded:
+		repo.SetCondition(
+			renovatev1beta1.GitRepoConditionRenovateCompleted,
+			metav1.ConditionTrue,
+			"JobSucceeded", "Renovate job completed successfully",
 		)
+		repo.RemoveCondition(renovatev1beta1.GitRepoConditionRenovateFailed)
 
-		if latestFinishedJob.Status.CompletionTime != nil {
-			duration := latestFinishedJob.Status.CompletionTime.Sub(latestFinishedJob.CreationTimestamp.Time).Seconds()
-
-			r.metrics.RecordRunnerJob(repo.Namespace, renovatorLabel, r.instance.Name, runStatus)
-			r.metrics.RecordRunnerJobDuration(repo.Namespace, renovatorLabel, r.instance.Name, runStatus, duration)
-			r.metrics.SetLastRunDuration(repo.Namespace, renovatorLabel, r.instance.Name, gitrepoLabel, duration)
+		if newRun {
+			r.updateRepoRetry(ctx, repo, latestFinishedJob, false, logs)
 		}
 
-		if runStatus == metrics.StatusFailed {
-			r.metrics.RecordRunnerJobFailure(
-				repo.Namespace, renovatorLabel, r.instance.Name, job.FailureReason(latestFinishedJob),
-			)
+		runStatus = metrics.StatusSucceeded
+	case failed:
+		repo.SetCondition(
+			renovatev1beta1.GitRepoConditionRenovateFailed,
+			metav1.ConditionTrue,
+			"JobFailed", "Renovate job failed",
+		)
+		repo.RemoveCondition(renovatev1beta1.GitRepoConditionRenovateCompleted)
+
+		if newRun {
+			r.updateRepoRetry(ctx, repo, latestFinishedJob, true, logs)
 		}
 
-		r.updateLogMetrics(ctx, latestFinishedJob, renovatorLabel, gitrepoLabel)
+		runStatus = metrics.StatusFailed
+	default:
+		repo.RemoveCondition(renovatev1beta1.GitRepoConditionRenovateCompleted)
+		repo.RemoveCondition(renovatev1beta1.GitRepoConditionRenovateFailed)
+
+		logf.FromContext(ctx).Info(
+			"finished job has no Succeeded/Failed counters, skipping metric emission",
+			"job", latestFinishedJob.Name,
+			"namespace", latestFinishedJob.Namespace,
+			"succeeded", latestFinishedJob.Status.Succeeded,
+			"failed", latestFinishedJob.Status.Failed,
+		)
+	}
+
+	if newRun {
+		updateRepoImages(repo, logs)
 	}
 
-	return nil
+	repo.SetLastRenovateTime(&latestFinishedJob.CreationTimestamp)
+
+	if !newRun || runStatus == "" {
+		return observedRun{}, nil
+	}
+
+	return observedRun{job: latestFinishedJob, status: runStatus, logs: logs}, nil
 }
 
-// updateLogMetrics parses the Renovate job logs and updates the
-// dependency_issues, log_warnings_total, log_errors_total and
-// approvals_needed gauge metrics.
-func (r *Reconciler) updateLogMetrics(
-	ctx context.Context, job *batchv1.Job, renovatorLabel, gitrepoLabel string,
-) {
-	if r.logReader == nil {
+// recordRunMetrics records the metrics of a newly observed run of a GitRepo.
+func (r *Reconciler) recordRunMetrics(repo *renovatev1beta1.GitRepo, run observedRun) {
+	if r.metrics == nil || run.job == nil {
 		return
 	}
 
-	stream, err := r.logReader.ReadJobLogs(ctx, job.Namespace, job.Name, renovate.ContainerName, 0)
-	if err != nil {
-		logf.FromContext(ctx).V(1).Info(
-			"Failed to read job logs for metrics", "job", job.Name, "error", err,
-		)
+	renovatorLabel := repo.Labels[renovatev1beta1.LabelRenovator]
+	gitrepoLabel, _ := k8s.SanitizeLabel(repo.Name)
+	finishedJob := run.job
 
-		return
+	r.metrics.RecordGitRepoRun(
+		repo.Namespace, renovatorLabel, r.instance.Name, gitrepoLabel, run.status,
+	)
+	r.metrics.SetRunFailed(
+		repo.Namespace, renovatorLabel, r.instance.Name, gitrepoLabel,
+		run.status == metrics.StatusFailed,
+	)
+	r.metrics.SetLastRunTimestamp(
+		repo.Namespace, renovatorLabel, r.instance.Name, gitrepoLabel,
+		float64(finishedJob.CreationTimestamp.Unix()),
+	)
+
+	// Job level metrics of a batch job are recorded once with the status of
+	// the job.
+	jobStatus := run.status
+	recordJob := isBatchLeader(finishedJob, gitrepoLabel)
+
+	if isBatchJob(finishedJob) && finishedJob.Status.Failed > 0 {
+		jobStatus = metrics.StatusFailed
 	}
-	defer stream.Close()
 
-	res, err := parser.ParseLogs(stream, -1)
-	if err != nil {
-		logf.FromContext(ctx).V(1).Info(
-			"Failed to parse job logs for metrics", "job", job.Name, "error", err,
+	if finishedJob.Status.CompletionTime != nil {
+		duration := finishedJob.Status.CompletionTime.Sub(finishedJob.CreationTimestamp.Time).Seconds()
+
+		if recordJob {
+			r.metrics.RecordRunnerJob(repo.Namespace, renovatorLabel, r.instance.Name, jobStatus)
+			r.metrics.RecordRunnerJobDuration(repo.Namespace, renovatorLabel, r.instance.Name, jobStatus, duration)
+		}
+
+		r.metrics.SetLastRunDuration(repo.Namespace, renovatorLabel, r.instance.Name, gitrepoLabel, duration)
+	}
+
+	if recordJob && jobStatus == metrics.StatusFailed {
+		r.metrics.RecordRunnerJobFailure(
+			repo.Namespace, renovatorLabel, r.instance.Name, job.FailureReason(finishedJob),
 		)
+	}
 
+	r.updateLogMetrics(finishedJob, run.logs, renovatorLabel, gitrepoLabel)
+}
+
+// updateLogMetrics updates the dependency_issues, log_warnings_total,
+// log_errors_total and approvals_needed gauge metrics from the parsed Renovate
+// job logs of the repository.
+func (r *Reconciler) updateLogMetrics(
+	job *batchv1.Job, res *parser.ParseLogsResult, renovatorLabel, gitrepoLabel string,
+) {
+	if res == nil {
 		return
 	}
 
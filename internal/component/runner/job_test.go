@@ -31,6 +31,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 var _ = Describe("ReconcileJob", func() {
@@ -127,7 +128,7 @@ var _ = Describe("ReconcileJob", func() {
 		fakeClient = fake.NewClientBuilder().
 			WithScheme(scheme).
 			WithObjects(instance, renovate, repo1, repo2, repo3).
-			WithStatusSubresource(instance, repo1, repo2, repo3).
+			WithStatusSubresource(instance, repo1, repo2, repo3, &renovatev1beta1.RunnerPool{}).
 			Build()
 
 		reconciler = &Reconciler{
@@ -344,6 +345,806 @@ var _ = Describe("ReconcileJob", func() {
 			})
 		})
 
+		Context("when a GitRepo suspends scheduled runs", func() {
+			BeforeEach(func() {
+				repo1.Spec.Overrides = &renovatev1beta1.GitRepoOverrides{Suspend: new(true)}
+				Expect(fakeClient.Update(ctx, repo1)).To(Succeed())
+			})
+
+			It("should only create jobs for the other matching repos", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].GenerateName).To(HavePrefix("repo-2-"))
+			})
+		})
+
+		Context("when a GitRepo has its own schedule", func() {
+			BeforeEach(func() {
+				instance.Status.LastScheduleTime = new(metav1.NewTime(now.Add(-time.Minute)))
+				Expect(fakeClient.Status().Update(ctx, instance)).To(Succeed())
+
+				repo1.Spec.Overrides = &renovatev1beta1.GitRepoOverrides{Schedule: "0 * * * *"}
+				Expect(fakeClient.Update(ctx, repo1)).To(Succeed())
+			})
+
+			It("should run the GitRepo independently of the runner schedule", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].GenerateName).To(HavePrefix("repo-1-"))
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Status.LastScheduleTime).NotTo(BeNil())
+				Expect(updatedRepo.Status.LastScheduleTime.Time).To(BeTemporally("==", now))
+			})
+		})
+
+		Context("when a GitRepo skips its next scheduled run", func() {
+			BeforeEach(func() {
+				repo1.Annotations = map[string]string{
+					renovatev1beta1.AnnotationSkipScheduledRun: renovatev1beta1.ValueTrue,
+				}
+				Expect(fakeClient.Update(ctx, repo1)).To(Succeed())
+			})
+
+			It("should skip the scheduled run once", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].GenerateName).To(HavePrefix("repo-2-"))
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Annotations).NotTo(HaveKey(renovatev1beta1.AnnotationSkipScheduledRun))
+			})
+
+			It("should not skip a triggered run", func() {
+				instance.Status.LastScheduleTime = new(metav1.NewTime(now.Add(-time.Minute)))
+				Expect(fakeClient.Status().Update(ctx, instance)).To(Succeed())
+
+				repo1.Annotations[renovatev1beta1.RenovatorOperation] = renovatev1beta1.OperationRenovate
+				Expect(fakeClient.Update(ctx, repo1)).To(Succeed())
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].GenerateName).To(HavePrefix("repo-1-"))
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Annotations).To(HaveKey(renovatev1beta1.AnnotationSkipScheduledRun))
+			})
+		})
+
+		Context("when a GitRepo requests a debug run", func() {
+			BeforeEach(func() {
+				instance.Status.LastScheduleTime = new(metav1.NewTime(now.Add(-time.Minute)))
+				Expect(fakeClient.Status().Update(ctx, instance)).To(Succeed())
+
+				repo1.Annotations = map[string]string{
+					renovatev1beta1.RenovatorOperation: renovatev1beta1.OperationRenovate,
+					renovatev1beta1.AnnotationDebugRun: renovatev1beta1.ValueTrue,
+				}
+				Expect(fakeClient.Update(ctx, repo1)).To(Succeed())
+			})
+
+			It("should log at the debug level for a single run", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+					corev1.EnvVar{Name: "RENOVATE_LOG_LEVEL", Value: string(renovatev1beta1.LogLevel_DEBUG)},
+				))
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Annotations).NotTo(HaveKey(renovatev1beta1.AnnotationDebugRun))
+			})
+		})
+
+		Context("when runs are spread across a window", func() {
+			BeforeEach(func() {
+				instance.Spec.Spread = &renovatev1beta1.SpreadSpec{Window: metav1.Duration{Duration: 2 * time.Hour}}
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+			})
+
+			It("should defer each GitRepo to its due time inside the window", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				for _, repo := range []*renovatev1beta1.GitRepo{repo1, repo2} {
+					updatedRepo := &renovatev1beta1.GitRepo{}
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo), updatedRepo)).To(Succeed())
+					Expect(updatedRepo.Status.NextRunTime).NotTo(BeNil())
+					Expect(updatedRepo.Status.NextRunTime.Time).To(BeTemporally("~",
+						now.Add(scheduler.SpreadOffset(instance.Spec.Spread, repo.Name)), time.Second))
+				}
+
+				fakeClock.Step(2 * time.Hour)
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(2))
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Status.NextRunTime).To(BeNil())
+			})
+
+			It("should patch the status of each GitRepo once per reconciliation", func() {
+				statusPatches := make(map[string]int)
+				reconciler.Client = interceptor.NewClient(fakeClient.(client.WithWatch), interceptor.Funcs{
+					SubResourcePatch: func(
+						ctx context.Context, c client.Client, subResource string, obj client.Object,
+						patch client.Patch, opts ...client.SubResourcePatchOption,
+					) error {
+						if _, ok := obj.(*renovatev1beta1.GitRepo); ok {
+							statusPatches[obj.GetName()]++
+						}
+
+						return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+					},
+				})
+
+				for range 2 {
+					clear(statusPatches)
+
+					_, err := reconciler.reconcileJob(ctx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(statusPatches).To(HaveKeyWithValue(repo1.Name, 1))
+					Expect(statusPatches).To(HaveKeyWithValue(repo2.Name, 1))
+
+					fakeClock.Step(2 * time.Hour)
+				}
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(2))
+			})
+		})
+
+		Context("when a blackout window is active", func() {
+			BeforeEach(func() {
+				instance.Spec.BlackoutWindows = []renovatev1beta1.BlackoutWindowSpec{
+					{Name: "freeze", End: new(metav1.NewTime(now.Add(time.Hour))), BlockWebhooks: true},
+				}
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+
+				repo1.Annotations = map[string]string{
+					renovatev1beta1.RenovatorOperation:      renovatev1beta1.OperationRenovate,
+					renovatev1beta1.AnnotationTriggerSource: renovatev1beta1.TriggerSourceWebhook,
+				}
+				Expect(fakeClient.Update(ctx, repo1)).To(Succeed())
+
+				repo2.Annotations = map[string]string{
+					renovatev1beta1.RenovatorOperation: renovatev1beta1.OperationRenovate,
+				}
+				Expect(fakeClient.Update(ctx, repo2)).To(Succeed())
+			})
+
+			It("should defer scheduled and webhook triggered runs but not manual triggers", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].GenerateName).To(HavePrefix("repo-2-"))
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Annotations).To(HaveKey(renovatev1beta1.RenovatorOperation))
+
+				updatedInstance := &renovatev1beta1.Runner{}
+				Expect(fakeClient.Get(ctx, reconciler.req.NamespacedName, updatedInstance)).To(Succeed())
+				Expect(updatedInstance.Status.LastScheduleTime).To(BeNil())
+
+				condition := updatedInstance.GetCondition(renovatev1beta1.RunnerConditionBlackout)
+				Expect(condition).NotTo(BeNil())
+				Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+				Expect(condition.Message).To(ContainSubstring("freeze"))
+			})
+		})
+
+		Context("when the number of parallel jobs is limited", func() {
+			BeforeEach(func() {
+				instance.Spec.MaxParallel = new(int32(1))
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+
+				repo1.Status.LastRenovateTime = new(metav1.NewTime(now.Add(-time.Hour)))
+				Expect(fakeClient.Status().Update(ctx, repo1)).To(Succeed())
+			})
+
+			It("should dispatch the most stale GitRepo first and queue the others", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].GenerateName).To(HavePrefix("repo-2-"))
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Status.QueuePosition).To(Equal(int32(1)))
+
+				finished := jobList.Items[0].DeepCopy()
+				finished.Status.Succeeded = 1
+				finished.Status.Conditions = []batchv1.JobCondition{
+					{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+				}
+				Expect(fakeClient.Status().Update(ctx, finished)).To(Succeed())
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(2))
+
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Status.QueuePosition).To(BeZero())
+			})
+
+			It("should dispatch triggered GitRepos before scheduled ones", func() {
+				repo1.Annotations = map[string]string{
+					renovatev1beta1.RenovatorOperation:      renovatev1beta1.OperationRenovate,
+					renovatev1beta1.AnnotationTriggerSource: renovatev1beta1.TriggerSourceWebhook,
+				}
+				Expect(fakeClient.Update(ctx, repo1)).To(Succeed())
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].GenerateName).To(HavePrefix("repo-1-"))
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo2), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Status.QueuePosition).To(Equal(int32(1)))
+			})
+		})
+
+		Context("when the runner pool is exhausted", func() {
+			BeforeEach(func() {
+				pool := &renovatev1beta1.RunnerPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: instance.Namespace},
+					Spec:       renovatev1beta1.RunnerPoolSpec{MaxParallel: 1},
+				}
+				Expect(fakeClient.Create(ctx, pool)).To(Succeed())
+
+				instance.Spec.Pool = &renovatev1beta1.RunnerPoolRef{Name: "shared"}
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+			})
+
+			It("should queue the remaining GitRepos", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+				Expect(jobList.Items[0].Labels).To(HaveKey(renovatev1beta1.LabelRunnerPool))
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo2), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Status.QueuePosition).To(Equal(int32(1)))
+			})
+		})
+
+		Context("when GitRepos are processed in batches", func() {
+			BeforeEach(func() {
+				instance.Spec.BatchSize = new(int32(2))
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+			})
+
+			It("should process the GitRepos in a single job", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+
+				job := &jobList.Items[0]
+				Expect(job.GenerateName).To(Equal("test-runner-batch-"))
+				Expect(job.Labels).NotTo(HaveKey(renovatev1beta1.LabelGitRepo))
+				Expect(job.Labels).To(HaveKeyWithValue(renovatev1beta1.LabelBatchGitRepoPrefix+"repo-1", "true"))
+				Expect(job.Labels).To(HaveKeyWithValue(renovatev1beta1.LabelBatchGitRepoPrefix+"repo-2", "true"))
+				Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+					Name: "RENOVATE_REPOSITORIES", Value: "test/repo-1,test/repo-2",
+				}))
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.GetCondition(renovatev1beta1.GitRepoConditionRenovateRunning).Status).
+					To(Equal(metav1.ConditionTrue))
+			})
+
+			It("should process GitRepos with job overrides alone", func() {
+				repo1.Spec.Overrides = &renovatev1beta1.GitRepoOverrides{Image: "renovate/renovate:full"}
+				Expect(fakeClient.Update(ctx, repo1)).To(Succeed())
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(2))
+
+				for _, job := range jobList.Items {
+					Expect(job.Labels).To(HaveKey(renovatev1beta1.LabelGitRepo))
+				}
+			})
+
+			It("should only group GitRepos of the same owner", func() {
+				instance.Spec.BatchStrategy = renovatev1beta1.BatchStrategy_OWNER
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+
+				repo1.Spec.Name = "other/repo-1"
+				Expect(fakeClient.Update(ctx, repo1)).To(Succeed())
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(2))
+			})
+
+			It("should derive the status of each GitRepo from a failed batch job", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(1))
+
+				failed := jobList.Items[0].DeepCopy()
+				failed.Status.Failed = 1
+				failed.Status.Conditions = []batchv1.JobCondition{
+					{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+				}
+				Expect(fakeClient.Status().Update(ctx, failed)).To(Succeed())
+
+				logReader := newLogReaderMock(strings.Join([]string{
+					`{"level":30,"msg":"Repository finished","repository":"test/repo-1","result":"done"}`,
+					`{"level":50,"msg":"Repository failed","repository":"test/repo-2"}`,
+				}, "\n"), nil)
+				reconciler.logReader = logReader
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				// The logs of the batch job are parsed once for both GitRepos.
+				logReader.AssertNumberOfCalls(GinkgoT(), "ReadJobLogs", 1)
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.GetCondition(renovatev1beta1.GitRepoConditionRenovateCompleted)).NotTo(BeNil())
+				Expect(updatedRepo.GetCondition(renovatev1beta1.GitRepoConditionRenovateFailed)).To(BeNil())
+
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo2), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.GetCondition(renovatev1beta1.GitRepoConditionRenovateFailed)).NotTo(BeNil())
+				Expect(updatedRepo.GetCondition(renovatev1beta1.GitRepoConditionRenovateCompleted)).To(BeNil())
+			})
+		})
+
+		Context("when failed runs are retried", func() {
+			failRepoJob := func(repo *renovatev1beta1.GitRepo) {
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"),
+					client.MatchingLabels{renovatev1beta1.LabelGitRepo: repo.Name})).To(Succeed())
+
+				for i := range jobList.Items {
+					job := &jobList.Items[i]
+					if scheduler.IsJobFinished(job) {
+						continue
+					}
+
+					job.CreationTimestamp = metav1.NewTime(fakeClock.Now())
+					Expect(fakeClient.Update(ctx, job)).To(Succeed())
+
+					job.Status.Failed = 1
+					job.Status.Conditions = []batchv1.JobCondition{
+						{Type: batchv1.JobFailed, Status: corev1.ConditionTrue},
+					}
+					Expect(fakeClient.Status().Update(ctx, job)).To(Succeed())
+				}
+			}
+
+			countRepoJobs := func(repo *renovatev1beta1.GitRepo) int {
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"),
+					client.MatchingLabels{renovatev1beta1.LabelGitRepo: repo.Name})).To(Succeed())
+
+				return len(jobList.Items)
+			}
+
+			BeforeEach(func() {
+				instance.Spec.Schedule = "0 0 1 1 *"
+				instance.Spec.Retry = &renovatev1beta1.RetrySpec{
+					MaxAttempts:  1,
+					InitialDelay: &metav1.Duration{Duration: 10 * time.Minute},
+				}
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+			})
+
+			It("should retry a failed run after the delay", func() {
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				failRepoJob(repo1)
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Status.RetryAttempts).To(Equal(int32(1)))
+				Expect(updatedRepo.Status.NextRetryTime).NotTo(BeNil())
+				Expect(updatedRepo.Status.NextRetryTime.Time).To(BeTemporally("~", now.Add(10*time.Minute), time.Second))
+				Expect(countRepoJobs(repo1)).To(Equal(1))
+
+				fakeClock.Step(10 * time.Minute)
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(countRepoJobs(repo1)).To(Equal(2))
+				Expect(countRepoJobs(repo2)).To(Equal(1))
+
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Status.RetryAttempts).To(Equal(int32(1)))
+				Expect(updatedRepo.Status.NextRetryTime).To(BeNil())
+
+				failRepoJob(repo1)
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Status.NextRetryTime).To(BeNil())
+			})
+
+			DescribeTable("should only retry transient failures if configured",
+				func(result string, retried bool) {
+					instance.Spec.Retry.TransientOnly = true
+					Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+
+					_, err := reconciler.reconcileJob(ctx)
+					Expect(err).NotTo(HaveOccurred())
+
+					failRepoJob(repo1)
+
+					reconciler.logReader = newLogReaderMock(
+						`{"level":30,"msg":"Repository finished","result":"`+result+`"}`, nil,
+					)
+
+					_, err = reconciler.reconcileJob(ctx)
+					Expect(err).NotTo(HaveOccurred())
+
+					updatedRepo := &renovatev1beta1.GitRepo{}
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+					Expect(updatedRepo.Status.NextRetryTime != nil).To(Equal(retried))
+				},
+				Entry("transient error", "external-host-error", true),
+				Entry("permanent error", "unknown-error", false),
+			)
+		})
+
+		Context("when container images are recorded", func() {
+			finishRepoJob := func(repo *renovatev1beta1.GitRepo) {
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"),
+					client.MatchingLabels{renovatev1beta1.LabelGitRepo: repo.Name})).To(Succeed())
+
+				for i := range jobList.Items {
+					job := &jobList.Items[i]
+					if scheduler.IsJobFinished(job) {
+						continue
+					}
+
+					job.Status.Succeeded = 1
+					job.Status.Conditions = []batchv1.JobCondition{
+						{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+					}
+					Expect(fakeClient.Status().Update(ctx, job)).To(Succeed())
+				}
+			}
+
+			BeforeEach(func() {
+				instance.Spec.Schedule = "0 0 1 1 *"
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+
+				repo1.Status.Images = []string{"registry.example.com/old"}
+				Expect(fakeClient.Status().Update(ctx, repo1)).To(Succeed())
+			})
+
+			DescribeTable("should record the images of a finished run",
+				func(logs string, expected []string) {
+					_, err := reconciler.reconcileJob(ctx)
+					Expect(err).NotTo(HaveOccurred())
+
+					finishRepoJob(repo1)
+
+					reconciler.logReader = newLogReaderMock(logs, nil)
+
+					_, err = reconciler.reconcileJob(ctx)
+					Expect(err).NotTo(HaveOccurred())
+
+					updatedRepo := &renovatev1beta1.GitRepo{}
+					Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+					Expect(updatedRepo.Status.Images).To(Equal(expected))
+				},
+				Entry("finished run",
+					strings.Join([]string{
+						`{"level":20,"msg":"packageFiles with updates","config":{"dockerfile":[{"deps":[` +
+							`{"depName":"node","datasource":"docker"},{"depName":"alpine","datasource":"docker"}]}]}}`,
+						`{"level":30,"msg":"Repository finished","result":"done"}`,
+					}, "\n"),
+					[]string{"alpine", "node"},
+				),
+				Entry("unfinished run",
+					`{"level":20,"msg":"packageFiles with updates","config":{"dockerfile":[{"deps":[`+
+						`{"depName":"node","datasource":"docker"}]}]}}`,
+					[]string{"registry.example.com/old"},
+				),
+			)
+		})
+
+		Context("when webhook events are debounced", func() {
+			triggerRepo := func(first, last time.Time) {
+				repo1.Spec.Webhooks.Debounce = &renovatev1beta1.WebhookDebounceSpec{
+					QuietPeriod: metav1.Duration{Duration: time.Minute},
+					MaxDelay:    &metav1.Duration{Duration: 5 * time.Minute},
+				}
+				repo1.Annotations = map[string]string{
+					renovatev1beta1.RenovatorOperation:          renovatev1beta1.OperationRenovate,
+					renovatev1beta1.AnnotationTriggerSource:     renovatev1beta1.TriggerSourceWebhook,
+					renovatev1beta1.AnnotationWebhookFirstEvent: first.Format(time.RFC3339),
+					renovatev1beta1.AnnotationWebhookLastEvent:  last.Format(time.RFC3339),
+				}
+				Expect(fakeClient.Update(ctx, repo1)).To(Succeed())
+			}
+
+			countJobs := func() int {
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"))).To(Succeed())
+
+				return len(jobList.Items)
+			}
+
+			BeforeEach(func() {
+				instance.Spec.Suspend = new(true)
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+			})
+
+			It("should start the run once the quiet period has passed", func() {
+				triggerRepo(now.Add(-30*time.Second), now.Add(-30*time.Second))
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(countJobs()).To(BeZero())
+
+				fakeClock.Step(30 * time.Second)
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(countJobs()).To(Equal(1))
+
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo1), updatedRepo)).To(Succeed())
+				Expect(updatedRepo.Annotations).To(BeEmpty())
+			})
+
+			It("should not defer the run beyond the maximum delay", func() {
+				triggerRepo(now.Add(-5*time.Minute), now)
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(countJobs()).To(Equal(1))
+			})
+		})
+
+		Context("when a GitRepo is triggered while its job is active", func() {
+			triggerRepo := func(repo *renovatev1beta1.GitRepo) {
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo), updatedRepo)).To(Succeed())
+
+				updatedRepo.Annotations = map[string]string{
+					"renovate.thegeeklab.de/operation": "renovate",
+				}
+				Expect(fakeClient.Update(ctx, updatedRepo)).To(Succeed())
+			}
+
+			completeRepoJob := func(repo *renovatev1beta1.GitRepo) {
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"),
+					client.MatchingLabels{renovatev1beta1.LabelGitRepo: repo.Name})).To(Succeed())
+
+				for i := range jobList.Items {
+					job := &jobList.Items[i]
+					if scheduler.IsJobFinished(job) {
+						continue
+					}
+
+					job.Status.Succeeded = 1
+					job.Status.Conditions = []batchv1.JobCondition{
+						{Type: batchv1.JobComplete, Status: corev1.ConditionTrue},
+					}
+					Expect(fakeClient.Status().Update(ctx, job)).To(Succeed())
+				}
+			}
+
+			repoJobNames := func(repo *renovatev1beta1.GitRepo) []string {
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"),
+					client.MatchingLabels{renovatev1beta1.LabelGitRepo: repo.Name})).To(Succeed())
+
+				names := make([]string, 0, len(jobList.Items))
+				for _, job := range jobList.Items {
+					names = append(names, job.Name)
+				}
+
+				return names
+			}
+
+			getRepo := func(repo *renovatev1beta1.GitRepo) *renovatev1beta1.GitRepo {
+				updatedRepo := &renovatev1beta1.GitRepo{}
+				Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(repo), updatedRepo)).To(Succeed())
+
+				return updatedRepo
+			}
+
+			BeforeEach(func() {
+				instance.Spec.Schedule = "0 0 1 1 *"
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(repoJobNames(repo1)).To(HaveLen(1))
+			})
+
+			It("should coalesce the triggers into a single rerun after the job has finished", func() {
+				for range 2 {
+					triggerRepo(repo1)
+
+					_, err := reconciler.reconcileJob(ctx)
+					Expect(err).NotTo(HaveOccurred())
+
+					updatedRepo := getRepo(repo1)
+					Expect(updatedRepo.Annotations).NotTo(HaveKey("renovate.thegeeklab.de/operation"))
+					Expect(updatedRepo.Status.PendingRerun).To(BeTrue())
+					Expect(repoJobNames(repo1)).To(HaveLen(1))
+				}
+
+				completeRepoJob(repo1)
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(getRepo(repo1).Status.PendingRerun).To(BeFalse())
+				Expect(repoJobNames(repo1)).To(HaveLen(2))
+				Expect(repoJobNames(repo2)).To(HaveLen(1))
+
+				completeRepoJob(repo1)
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repoJobNames(repo1)).To(HaveLen(2))
+			})
+
+			It("should keep a debug request with the pending rerun", func() {
+				updatedRepo := getRepo(repo1)
+				updatedRepo.Annotations = map[string]string{
+					renovatev1beta1.RenovatorOperation: renovatev1beta1.OperationRenovate,
+					renovatev1beta1.AnnotationDebugRun: renovatev1beta1.ValueTrue,
+				}
+				Expect(fakeClient.Update(ctx, updatedRepo)).To(Succeed())
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				updatedRepo = getRepo(repo1)
+				Expect(updatedRepo.Annotations).NotTo(HaveKey(renovatev1beta1.AnnotationDebugRun))
+				Expect(updatedRepo.Status.PendingRerun).To(BeTrue())
+				Expect(updatedRepo.Status.PendingDebugRun).To(BeTrue())
+
+				activeJobs := repoJobNames(repo1)
+
+				completeRepoJob(repo1)
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				jobList := &batchv1.JobList{}
+				Expect(fakeClient.List(ctx, jobList, client.InNamespace("default"),
+					client.MatchingLabels{renovatev1beta1.LabelGitRepo: repo1.Name})).To(Succeed())
+				Expect(jobList.Items).To(HaveLen(2))
+
+				for _, job := range jobList.Items {
+					if job.Name == activeJobs[0] {
+						continue
+					}
+
+					Expect(job.Spec.Template.Spec.Containers[0].Env).To(ContainElement(
+						corev1.EnvVar{Name: "RENOVATE_LOG_LEVEL", Value: string(renovatev1beta1.LogLevel_DEBUG)},
+					))
+				}
+
+				updatedRepo = getRepo(repo1)
+				Expect(updatedRepo.Status.PendingRerun).To(BeFalse())
+				Expect(updatedRepo.Status.PendingDebugRun).To(BeFalse())
+			})
+
+			It("should drop the trigger with the Forbid policy", func() {
+				instance.Spec.ConcurrencyPolicy = renovatev1beta1.ConcurrencyPolicy_FORBID
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+
+				triggerRepo(repo1)
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				updatedRepo := getRepo(repo1)
+				Expect(updatedRepo.Annotations).NotTo(HaveKey("renovate.thegeeklab.de/operation"))
+				Expect(updatedRepo.Status.PendingRerun).To(BeFalse())
+
+				completeRepoJob(repo1)
+
+				_, err = reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(repoJobNames(repo1)).To(HaveLen(1))
+			})
+
+			It("should replace the active job with the Replace policy", func() {
+				instance.Spec.ConcurrencyPolicy = renovatev1beta1.ConcurrencyPolicy_REPLACE
+				Expect(fakeClient.Update(ctx, instance)).To(Succeed())
+
+				activeJobs := repoJobNames(repo1)
+
+				triggerRepo(repo1)
+
+				_, err := reconciler.reconcileJob(ctx)
+				Expect(err).NotTo(HaveOccurred())
+
+				updatedRepo := getRepo(repo1)
+				Expect(updatedRepo.Annotations).NotTo(HaveKey("renovate.thegeeklab.de/operation"))
+				Expect(updatedRepo.Status.PendingRerun).To(BeFalse())
+
+				jobNames := repoJobNames(repo1)
+				Expect(jobNames).To(HaveLen(1))
+				Expect(jobNames).NotTo(ContainElement(activeJobs[0]))
+			})
+		})
+
 		Context("when jobs were created in a previous reconciliation", func() {
 			It("should update GitRepo status to show running jobs", func() {
 				_, err := reconciler.reconcileJob(ctx)
@@ -449,7 +1250,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			Expect(job.Spec.Template.Spec.Containers).To(HaveLen(1))
 			mainContainer := job.Spec.Template.Spec.Containers[0]
@@ -471,7 +1272,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			Expect(job.Spec.Template.Spec.ImagePullSecrets).To(HaveLen(1))
 			Expect(job.Spec.Template.Spec.ImagePullSecrets[0].Name).To(Equal("runner-registry-secret"))
@@ -486,7 +1287,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			Expect(job.Spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("disktype", "ssd"))
 		})
@@ -510,7 +1311,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			Expect(job.Spec.Template.Spec.Affinity).NotTo(BeNil())
 			Expect(job.Spec.Template.Spec.Affinity.NodeAffinity).NotTo(BeNil())
@@ -527,7 +1328,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			Expect(job.Spec.Template.Spec.Tolerations).To(HaveLen(1))
 			Expect(job.Spec.Template.Spec.Tolerations[0].Key).To(Equal("key1"))
@@ -544,7 +1345,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			Expect(job.Spec.Template.Spec.TopologySpreadConstraints).To(HaveLen(1))
 			Expect(job.Spec.Template.Spec.TopologySpreadConstraints[0].TopologyKey).To(Equal("zone"))
@@ -566,7 +1367,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			mainContainer := job.Spec.Template.Spec.Containers[0]
 			Expect(mainContainer.Resources.Requests).To(HaveKeyWithValue(corev1.ResourceCPU, resource.MustParse("100m")))
@@ -584,7 +1385,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			mainContainer := job.Spec.Template.Spec.Containers[0]
 			Expect(mainContainer.SecurityContext).NotTo(BeNil())
@@ -602,13 +1403,39 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			env := job.Spec.Template.Spec.Containers[0].Env
 			Expect(env).To(ContainElement(HaveField("Name", "CUSTOM_VAR")))
 			Expect(env).To(ContainElement(HaveField("Value", "custom_value")))
 		})
 
+		It("should merge GitRepo overrides over the runner configuration", func() {
+			instance.Spec.NodeSelector = map[string]string{"pool": "default"}
+			instance.Spec.ExtraEnv = []corev1.EnvVar{{Name: "RUNNER_VAR", Value: "runner"}}
+			repo1.Spec.Overrides = &renovatev1beta1.GitRepoOverrides{
+				Image:        "renovate/renovate:full",
+				NodeSelector: map[string]string{"pool": "large"},
+				ExtraEnv:     []corev1.EnvVar{{Name: "REPO_VAR", Value: "repo"}},
+				Timeout:      &metav1.Duration{Duration: time.Hour},
+			}
+
+			job := &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-job",
+					Namespace: "default",
+				},
+			}
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
+
+			mainContainer := job.Spec.Template.Spec.Containers[0]
+			Expect(mainContainer.Image).To(Equal("renovate/renovate:full"))
+			Expect(mainContainer.Env).To(ContainElement(HaveField("Name", "RUNNER_VAR")))
+			Expect(mainContainer.Env).To(ContainElement(HaveField("Name", "REPO_VAR")))
+			Expect(job.Spec.Template.Spec.NodeSelector).To(Equal(map[string]string{"pool": "large"}))
+			Expect(job.Spec.ActiveDeadlineSeconds).To(Equal(new(int64(3600))))
+		})
+
 		It("should propagate ExtraVolumes to the job pod spec", func() {
 			instance.Spec.ExtraVolumes = []corev1.Volume{
 				{
@@ -625,7 +1452,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			Expect(job.Spec.Template.Spec.Volumes).To(ContainElement(HaveField("Name", "extra-vol")))
 		})
@@ -639,7 +1466,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			Expect(job.Spec.Template.Spec.RuntimeClassName).NotTo(BeNil())
 			Expect(*job.Spec.Template.Spec.RuntimeClassName).To(Equal("gvisor"))
@@ -654,7 +1481,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			Expect(job.Spec.Template.Annotations).To(HaveKeyWithValue("prometheus.io/scrape", "true"))
 		})
@@ -673,7 +1500,7 @@ var _ = Describe("ReconcileJob", func() {
 			podLabels := map[string]string{
 				"existing": "label",
 			}
-			Expect(reconciler.updateJob(job, repo1, podLabels)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, podLabels)).To(Succeed())
 
 			Expect(job.Spec.Template.Labels["cost-center"]).To(Equal("ns-default-run-test-runner-repo-repo-1"))
 		})
@@ -700,7 +1527,7 @@ var _ = Describe("ReconcileJob", func() {
 				},
 			}
 			podLabels := map[string]string{}
-			Expect(reconciler.updateJob(job, repo1, podLabels)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, podLabels)).To(Succeed())
 
 			Expect(job.Spec.Template.Labels["discovery-name"]).To(Equal("my-discovery"))
 		})
@@ -717,7 +1544,7 @@ var _ = Describe("ReconcileJob", func() {
 				},
 			}
 			podLabels := map[string]string{}
-			Expect(reconciler.updateJob(job, repo1, podLabels)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, podLabels)).To(Succeed())
 
 			Expect(job.Spec.Template.Labels["discovery-name"]).To(Equal(""))
 		})
@@ -736,7 +1563,7 @@ var _ = Describe("ReconcileJob", func() {
 					Namespace: "default",
 				},
 			}
-			Expect(reconciler.updateJob(job, repo1, nil)).To(Succeed())
+			Expect(reconciler.updateJob(job, []*renovatev1beta1.GitRepo{repo1}, nil)).To(Succeed())
 
 			var scratchVol *corev1.Volume
 
@@ -762,6 +1589,19 @@ var _ = Describe("ReconcileJob", func() {
 	Describe("updateJobStatus", func() {
 		var metricsRecorder metrics.Recorder
 
+		// updateJobStatus records the metrics of the observed run like the
+		// patch of the GitRepo in a reconciliation.
+		updateJobStatus := func(repo *renovatev1beta1.GitRepo, labels map[string]string) error {
+			run, err := reconciler.updateJobStatus(ctx, repo, labels)
+			if err != nil {
+				return err
+			}
+
+			reconciler.recordRunMetrics(repo, run)
+
+			return nil
+		}
+
 		BeforeEach(func() {
 			reg := prometheus.NewRegistry()
 			metricsRecorder = metrics.New(reg, reg, 5000)
@@ -795,7 +1635,7 @@ var _ = Describe("ReconcileJob", func() {
 			}
 			Expect(fakeClient.Create(ctx, finishedJob)).To(Succeed())
 
-			err := reconciler.updateJobStatus(ctx, repo1, map[string]string{
+			err := updateJobStatus(repo1, map[string]string{
 				renovatev1beta1.LabelRenovator: "renovator-id",
 				renovatev1beta1.LabelGitRepo:   "repo-1",
 			})
@@ -834,7 +1674,7 @@ var _ = Describe("ReconcileJob", func() {
 			}
 			Expect(fakeClient.Create(ctx, failedJob)).To(Succeed())
 
-			err := reconciler.updateJobStatus(ctx, repo2, map[string]string{
+			err := updateJobStatus(repo2, map[string]string{
 				renovatev1beta1.LabelRenovator: "renovator-id",
 				renovatev1beta1.LabelGitRepo:   "repo-2",
 			})
@@ -874,7 +1714,7 @@ var _ = Describe("ReconcileJob", func() {
 			Expect(fakeClient.Create(ctx, finishedJob)).To(Succeed())
 
 			// First reconcile
-			err := reconciler.updateJobStatus(ctx, repo1, map[string]string{
+			err := updateJobStatus(repo1, map[string]string{
 				renovatev1beta1.LabelRenovator: "renovator-id",
 				renovatev1beta1.LabelGitRepo:   "repo-1",
 			})
@@ -885,7 +1725,7 @@ var _ = Describe("ReconcileJob", func() {
 			Expect(err).NotTo(HaveOccurred())
 
 			// Second reconcile (same job, should not increment)
-			err = reconciler.updateJobStatus(ctx, repo1, map[string]string{
+			err = updateJobStatus(repo1, map[string]string{
 				renovatev1beta1.LabelRenovator: "renovator-id",
 				renovatev1beta1.LabelGitRepo:   "repo-1",
 			})
@@ -924,7 +1764,7 @@ var _ = Describe("ReconcileJob", func() {
 			}
 			Expect(fakeClient.Create(ctx, job1)).To(Succeed())
 
-			err := reconciler.updateJobStatus(ctx, repo1, map[string]string{
+			err := updateJobStatus(repo1, map[string]string{
 				renovatev1beta1.LabelRenovator: "renovator-id",
 				renovatev1beta1.LabelGitRepo:   "repo-1",
 			})
@@ -974,7 +1814,7 @@ var _ = Describe("ReconcileJob", func() {
 			}
 			Expect(fakeClient.Create(ctx, job2)).To(Succeed())
 
-			err = reconciler.updateJobStatus(ctx, repo1, map[string]string{
+			err = updateJobStatus(repo1, map[string]string{
 				renovatev1beta1.LabelRenovator: "renovator-id",
 				renovatev1beta1.LabelGitRepo:   "repo-1",
 			})
@@ -1026,7 +1866,7 @@ var _ = Describe("ReconcileJob", func() {
 			}
 			Expect(fakeClient.Create(ctx, job1)).To(Succeed())
 
-			err := reconciler.updateJobStatus(ctx, repo1, map[string]string{
+			err := updateJobStatus(repo1, map[string]string{
 				renovatev1beta1.LabelRenovator: "renovator-id",
 				renovatev1beta1.LabelGitRepo:   "repo-1",
 			})
@@ -1072,7 +1912,7 @@ var _ = Describe("ReconcileJob", func() {
 			}
 			Expect(fakeClient.Create(ctx, job2)).To(Succeed())
 
-			err = reconciler.updateJobStatus(ctx, repo1, map[string]string{
+			err = updateJobStatus(repo1, map[string]string{
 				renovatev1beta1.LabelRenovator: "renovator-id",
 				renovatev1beta1.LabelGitRepo:   "repo-1",
 			})
@@ -1130,7 +1970,7 @@ var _ = Describe("ReconcileJob", func() {
 			}
 			Expect(fakeClient.Create(ctx, finishedJob)).To(Succeed())
 
-			err := reconciler.updateJobStatus(ctx, longRepo, map[string]string{
+			err := updateJobStatus(longRepo, map[string]string{
 				renovatev1beta1.LabelRenovator: "renovator-id",
 				renovatev1beta1.LabelGitRepo:   "very-long-repository-name-that-exceeds-the-kubernetes-label",
 			})
@@ -1196,7 +2036,7 @@ var _ = Describe("ReconcileJob", func() {
 		It("sets dependency_issues=1 when logs have warnings", func() {
 			reconciler.logReader = newLogReaderMock(`{"level":40,"msg":"Config warning"}`, nil)
 
-			reconciler.updateLogMetrics(ctx, testJob, "renovator-1", "repo-1")
+			reconciler.updateLogMetrics(testJob, reconciler.readRepoLogs(ctx, testJob, "org/repo-1"), "renovator-1", "repo-1")
 
 			metricFamilies, err := metricsRecorder.Gatherer().Gather()
 			Expect(err).NotTo(HaveOccurred())
@@ -1217,7 +2057,7 @@ var _ = Describe("ReconcileJob", func() {
 		It("sets dependency_issues=0 when logs are clean", func() {
 			reconciler.logReader = newLogReaderMock(`{"level":30,"msg":"Repository finished","result":"done"}`, nil)
 
-			reconciler.updateLogMetrics(ctx, testJob, "renovator-1", "repo-1")
+			reconciler.updateLogMetrics(testJob, reconciler.readRepoLogs(ctx, testJob, "org/repo-1"), "renovator-1", "repo-1")
 
 			metricFamilies, err := metricsRecorder.Gatherer().Gather()
 			Expect(err).NotTo(HaveOccurred())
@@ -1241,7 +2081,7 @@ var _ = Describe("ReconcileJob", func() {
 			bi := `{"level":30,"msg":"branches info extended","branchesInformation":[` + ba + `,` + bb + `]}`
 			reconciler.logReader = newLogReaderMock(bi, nil)
 
-			reconciler.updateLogMetrics(ctx, testJob, "renovator-1", "repo-1")
+			reconciler.updateLogMetrics(testJob, reconciler.readRepoLogs(ctx, testJob, "org/repo-1"), "renovator-1", "repo-1")
 
 			metricFamilies, err := metricsRecorder.Gatherer().Gather()
 			Expect(err).NotTo(HaveOccurred())
@@ -1262,7 +2102,7 @@ var _ = Describe("ReconcileJob", func() {
 		It("does nothing when logReader is nil", func() {
 			reconciler.logReader = nil
 
-			reconciler.updateLogMetrics(ctx, testJob, "renovator-1", "repo-1")
+			reconciler.updateLogMetrics(testJob, reconciler.readRepoLogs(ctx, testJob, "org/repo-1"), "renovator-1", "repo-1")
 
 			metricFamilies, err := metricsRecorder.Gatherer().Gather()
 			Expect(err).NotTo(HaveOccurred())
@@ -1282,7 +2122,7 @@ var _ = Describe("ReconcileJob", func() {
 			reconciler.logReader = newLogReaderMock("", errors.New("pod not found"))
 
 			Expect(func() {
-				reconciler.updateLogMetrics(ctx, testJob, "renovator-1", "repo-1")
+				reconciler.updateLogMetrics(testJob, reconciler.readRepoLogs(ctx, testJob, "org/repo-1"), "renovator-1", "repo-1")
 			}).NotTo(Panic())
 		})
 
@@ -1293,7 +2133,7 @@ var _ = Describe("ReconcileJob", func() {
 
 			reconciler.logReader = newLogReaderMock(errLog+"\n"+bi, nil)
 
-			reconciler.updateLogMetrics(ctx, testJob, "renovator-1", "repo-1")
+			reconciler.updateLogMetrics(testJob, reconciler.readRepoLogs(ctx, testJob, "org/repo-1"), "renovator-1", "repo-1")
 
 			metricFamilies, err := metricsRecorder.Gatherer().Gather()
 			Expect(err).NotTo(HaveOccurred())
@@ -1324,7 +2164,7 @@ var _ = Describe("ReconcileJob", func() {
 
 			reconciler.logReader = newLogReaderMock(strings.Join([]string{warnLog, warnLog, errLog, infoLog}, "\n"), nil)
 
-			reconciler.updateLogMetrics(ctx, testJob, "renovator-1", "repo-1")
+			reconciler.updateLogMetrics(testJob, reconciler.readRepoLogs(ctx, testJob, "org/repo-1"), "renovator-1", "repo-1")
 
 			metricFamilies, err := metricsRecorder.Gatherer().Gather()
 			Expect(err).NotTo(HaveOccurred())
@@ -0,0 +1,88 @@
+package runner
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/scheduler"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// queuedRepo is a GitRepo with a pending run waiting to be dispatched.
+type queuedRepo struct {
+	repo     *renovatev1beta1.GitRepo
+	labels   map[string]string
+	decision scheduler.DecisionResult
+	// triggered is true if the run was requested by a manual or webhook trigger.
+	triggered bool
+	// retry is true if the run only retries a failed run.
+	retry bool
+	// replaced is true if the active job of the GitRepo was deleted for the run.
+	replaced bool
+}
+
+// sortQueue orders the pending runs for dispatch. Triggered runs are dispatched
+// first, followed by the GitRepos that have not run for the longest time.
+// GitRepos that never ran are the most stale. Equally stale GitRepos are
+// ordered by their priority.
+func sortQueue(queue []queuedRepo) {
+	slices.SortStableFunc(queue, func(a, b queuedRepo) int {
+		if a.triggered != b.triggered {
+			if a.triggered {
+				return -1
+			}
+
+			return 1
+		}
+
+		if c := compareLastRun(a.repo, b.repo); c != 0 {
+			return c
+		}
+
+		if c := cmp.Compare(repoPriority(b.repo), repoPriority(a.repo)); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.repo.Name, b.repo.Name)
+	})
+}
+
+// compareLastRun compares the last renovate times of two GitRepos.
+func compareLastRun(a, b *renovatev1beta1.GitRepo) int {
+	lastA, lastB := a.GetLastRenovateTime(), b.GetLastRenovateTime()
+
+	switch {
+	case lastA == nil && lastB == nil:
+		return 0
+	case lastA == nil:
+		return -1
+	case lastB == nil:
+		return 1
+	}
+
+	return lastA.Compare(lastB.Time)
+}
+
+// repoPriority returns the dispatch priority of a GitRepo.
+func repoPriority(repo *renovatev1beta1.GitRepo) int32 {
+	if repo.Spec.Overrides == nil || repo.Spec.Overrides.Priority == nil {
+		return 0
+	}
+
+	return *repo.Spec.Overrides.Priority
+}
+
+// queueRepo appends a GitRepo waiting for a free job slot to the queue and
+// records its position in the status.
+func queueRepo(ctx context.Context, repo *renovatev1beta1.GitRepo, result *processResult) {
+	result.queued++
+
+	logf.FromContext(ctx).V(1).Info(
+		"No free job slot, queueing repo", "repo", repo.Name, "position", result.queued,
+	)
+
+	repo.Status.QueuePosition = int32(result.queued) //nolint:gosec
+}
@@ -0,0 +1,52 @@
+package runner
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("sortQueue", func() {
+	now := time.Date(2026, 2, 27, 15, 0, 0, 0, time.UTC)
+
+	newRepo := func(name string, lastRun *time.Time, priority *int32) *renovatev1beta1.GitRepo {
+		repo := &renovatev1beta1.GitRepo{ObjectMeta: metav1.ObjectMeta{Name: name}}
+
+		if lastRun != nil {
+			repo.Status.LastRenovateTime = new(metav1.NewTime(*lastRun))
+		}
+
+		if priority != nil {
+			repo.Spec.Overrides = &renovatev1beta1.GitRepoOverrides{Priority: priority}
+		}
+
+		return repo
+	}
+
+	names := func(queue []queuedRepo) []string {
+		result := make([]string, 0, len(queue))
+		for _, item := range queue {
+			result = append(result, item.repo.Name)
+		}
+
+		return result
+	}
+
+	It("should order by trigger, staleness, priority and name", func() {
+		queue := []queuedRepo{
+			{repo: newRepo("recent", new(now.Add(-time.Minute)), nil)},
+			{repo: newRepo("low", new(now.Add(-time.Hour)), nil)},
+			{repo: newRepo("high", new(now.Add(-time.Hour)), new(int32(10)))},
+			{repo: newRepo("b-never", nil, nil)},
+			{repo: newRepo("a-never", nil, nil)},
+			{repo: newRepo("webhook", new(now), nil), triggered: true},
+		}
+
+		sortQueue(queue)
+
+		Expect(names(queue)).To(Equal([]string{"webhook", "a-never", "b-never", "high", "low", "recent"}))
+	})
+})
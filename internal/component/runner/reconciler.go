@@ -8,6 +8,7 @@ import (
 	"github.com/thegeeklab/renovate-operator/internal/frontend"
 	"github.com/thegeeklab/renovate-operator/internal/logreader"
 	"github.com/thegeeklab/renovate-operator/internal/metrics"
+	"github.com/thegeeklab/renovate-operator/internal/parser"
 	"github.com/thegeeklab/renovate-operator/internal/scheduler"
 	"github.com/thegeeklab/renovate-operator/pkg/util/reconciler"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -28,6 +29,8 @@ type Reconciler struct {
 	renovate  *renovatev1beta1.RenovateConfig
 	metrics   metrics.Recorder
 	logReader logreader.Reader
+	// batchLogs caches the parsed logs of finished batch jobs.
+	batchLogs map[client.ObjectKey]map[string]*parser.ParseLogsResult
 }
 
 type JobData struct {
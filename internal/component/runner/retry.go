@@ -0,0 +1,84 @@
+package runner
+
+import (
+	"context"
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/parser"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// maxRetryDelay caps the delay between the retries of a failed run.
+const maxRetryDelay = 24 * time.Hour
+
+// retryDelay returns the delay before the given retry attempt. The initial
+// delay grows by the multiplier with every further attempt.
+func retryDelay(policy *renovatev1beta1.RetrySpec, attempt int32) time.Duration {
+	delay := policy.GetInitialDelay()
+	multiplier := time.Duration(policy.GetMultiplier())
+
+	for range attempt - 1 {
+		if delay >= maxRetryDelay/multiplier {
+			return maxRetryDelay
+		}
+
+		delay *= multiplier
+	}
+
+	return min(delay, maxRetryDelay)
+}
+
+// updateRepoRetry records the retry of a newly finished failed run of a GitRepo
+// in its status. A successful run resets the retry state. The parsed logs of
+// the run decide whether a failure is transient. The status is patched by the
+// caller.
+func (r *Reconciler) updateRepoRetry(
+	ctx context.Context, repo *renovatev1beta1.GitRepo, job *batchv1.Job, failed bool, res *parser.ParseLogsResult,
+) {
+	if !failed {
+		repo.Status.RetryAttempts = 0
+		repo.Status.NextRetryTime = nil
+
+		return
+	}
+
+	repo.Status.NextRetryTime = nil
+
+	policy := r.instance.Spec.Retry
+	if policy == nil || repo.Status.RetryAttempts >= policy.MaxAttempts {
+		return
+	}
+
+	log := logf.FromContext(ctx)
+
+	if policy.TransientOnly {
+		if res == nil || !res.IsTransientFailure() {
+			log.V(1).Info("Run failed without a transient error: skipping retry", "repo", repo.Name, "job", job.Name)
+
+			return
+		}
+	}
+
+	repo.Status.RetryAttempts++
+
+	next := r.scheduler.Now().Add(retryDelay(policy, repo.Status.RetryAttempts))
+	repo.Status.NextRetryTime = new(metav1.NewTime(next))
+
+	log.Info(
+		"Failed run scheduled for retry",
+		"repo", repo.Name, "attempt", repo.Status.RetryAttempts, "time", next,
+	)
+}
+
+// clearRepoRetry removes the pending retry of a GitRepo once a run was
+// dispatched. The attempts are only kept if the dispatched run is a retry.
+func clearRepoRetry(repo *renovatev1beta1.GitRepo, retry bool) {
+	repo.Status.NextRetryTime = nil
+
+	if !retry {
+		repo.Status.RetryAttempts = 0
+	}
+}
@@ -0,0 +1,30 @@
+package runner
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("retryDelay", func() {
+	DescribeTable("should grow the delay exponentially",
+		func(policy *renovatev1beta1.RetrySpec, attempt int32, expected time.Duration) {
+			Expect(retryDelay(policy, attempt)).To(Equal(expected))
+		},
+		Entry("first attempt with defaults",
+			&renovatev1beta1.RetrySpec{MaxAttempts: 3}, int32(1), 10*time.Minute),
+		Entry("third attempt with defaults",
+			&renovatev1beta1.RetrySpec{MaxAttempts: 3}, int32(3), 40*time.Minute),
+		Entry("custom delay and multiplier",
+			&renovatev1beta1.RetrySpec{
+				MaxAttempts:  3,
+				InitialDelay: &metav1.Duration{Duration: time.Minute},
+				Multiplier:   new(int32(3)),
+			}, int32(3), 9*time.Minute),
+		Entry("capped delay",
+			&renovatev1beta1.RetrySpec{MaxAttempts: 100}, int32(100), maxRetryDelay),
+	)
+})
@@ -0,0 +1,169 @@
+package runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/scheduler"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// repoSchedule evaluates the schedule override of a GitRepo. Settings not
+// overridden by the GitRepo are inherited from the Runner.
+type repoSchedule struct {
+	*renovatev1beta1.GitRepo
+	runner *renovatev1beta1.Runner
+}
+
+func (s *repoSchedule) GetSchedule() string {
+	return s.Spec.Overrides.Schedule
+}
+
+func (s *repoSchedule) GetTimezone() string {
+	if s.Spec.Overrides.Timezone != "" {
+		return s.Spec.Overrides.Timezone
+	}
+
+	return s.runner.GetTimezone()
+}
+
+func (s *repoSchedule) GetSuspend() bool {
+	if s.Spec.Overrides.Suspend != nil {
+		return *s.Spec.Overrides.Suspend
+	}
+
+	return s.runner.GetSuspend()
+}
+
+func (s *repoSchedule) GetBlackoutWindows() []renovatev1beta1.BlackoutWindowSpec {
+	return s.runner.GetBlackoutWindows()
+}
+
+func (s *repoSchedule) GetLastScheduleTime() *metav1.Time {
+	return s.Status.LastScheduleTime
+}
+
+func (s *repoSchedule) SetLastScheduleTime(t *metav1.Time) {
+	s.Status.LastScheduleTime = t
+}
+
+func (s *repoSchedule) GetSuccessLimit() int {
+	return s.runner.GetSuccessLimit()
+}
+
+func (s *repoSchedule) GetFailedLimit() int {
+	return s.runner.GetFailedLimit()
+}
+
+// hasRepoSchedule returns true if the GitRepo runs on its own schedule.
+func hasRepoSchedule(repo *renovatev1beta1.GitRepo) bool {
+	return repo.Spec.Overrides != nil && repo.Spec.Overrides.Schedule != ""
+}
+
+// evaluateRepo decides whether a run of the GitRepo is due. GitRepos without
+// their own schedule follow the decision of the Runner, a suspend override
+// only suppresses scheduled runs. A manual trigger of the Runner runs all
+// GitRepos regardless of their overrides.
+func (r *Reconciler) evaluateRepo(
+	repo *renovatev1beta1.GitRepo, decision scheduler.DecisionResult,
+) (scheduler.DecisionResult, error) {
+	if decision.Trigger == scheduler.TriggerManual {
+		return decision, nil
+	}
+
+	if hasRepoSchedule(repo) {
+		return r.scheduler.Evaluate(&repoSchedule{GitRepo: repo, runner: r.instance}, nil)
+	}
+
+	if decision.Trigger == scheduler.TriggerSchedule &&
+		repo.Spec.Overrides != nil && repo.Spec.Overrides.Suspend != nil && *repo.Spec.Overrides.Suspend {
+		return scheduler.DecisionResult{ShouldRun: false, NextRun: decision.NextRun, Trigger: scheduler.TriggerSuspended}, nil
+	}
+
+	return decision, nil
+}
+
+// completeRepoRun records the scheduled run of a GitRepo with its own schedule.
+func (r *Reconciler) completeRepoRun(repo *renovatev1beta1.GitRepo) {
+	repo.Status.LastScheduleTime = new(metav1.NewTime(r.scheduler.Now()))
+}
+
+// skipScheduledRun skips the due scheduled run of a GitRepo annotated to skip
+// its next scheduled run. The run is recorded as completed and the annotation
+// is removed. It reports whether the run was skipped, triggered runs are never
+// skipped.
+func (r *Reconciler) skipScheduledRun(
+	repo *renovatev1beta1.GitRepo, decision scheduler.DecisionResult, spreadDue bool,
+) bool {
+	if repo.Annotations[renovatev1beta1.AnnotationSkipScheduledRun] != renovatev1beta1.ValueTrue {
+		return false
+	}
+
+	scheduled := decision.ShouldRun && decision.Trigger == scheduler.TriggerSchedule
+	if !scheduled && !spreadDue {
+		return false
+	}
+
+	if scheduled && hasRepoSchedule(repo) {
+		r.completeRepoRun(repo)
+	}
+
+	if spreadDue {
+		repo.Status.NextRunTime = nil
+	}
+
+	delete(repo.Annotations, renovatev1beta1.AnnotationSkipScheduledRun)
+
+	return true
+}
+
+// isDebugRun reports whether the next triggered run of the GitRepo logs at the
+// debug level. A debug run requested while a job was active is kept with the
+// pending rerun.
+func isDebugRun(repo *renovatev1beta1.GitRepo) bool {
+	return repo.Annotations[renovatev1beta1.AnnotationDebugRun] == renovatev1beta1.ValueTrue ||
+		(repo.Status.PendingRerun && repo.Status.PendingDebugRun)
+}
+
+// spreadRepoRun records the due time of a scheduled run of a GitRepo inside the
+// spread window of the Runner. A pending due time is kept so that a run is not
+// postponed by the next execution of the Runner.
+func (r *Reconciler) spreadRepoRun(repo *renovatev1beta1.GitRepo) {
+	if repo.Status.NextRunTime != nil {
+		return
+	}
+
+	offset := scheduler.SpreadOffset(r.instance.Spec.Spread, repo.Name)
+	repo.Status.NextRunTime = new(metav1.NewTime(r.scheduler.Now().Add(offset)))
+}
+
+// updateBlackoutStatus reflects the active blackout window of the Runner in its
+// status conditions.
+func (r *Reconciler) updateBlackoutStatus(ctx context.Context) error {
+	blackout, inBlackout, err := r.scheduler.ActiveBlackout(r.instance)
+	if err != nil {
+		return fmt.Errorf("failed to evaluate blackout windows: %w", err)
+	}
+
+	patch := client.MergeFrom(r.instance.DeepCopy())
+
+	if inBlackout {
+		r.instance.SetCondition(
+			renovatev1beta1.RunnerConditionBlackout,
+			metav1.ConditionTrue,
+			renovatev1beta1.ReasonBlackoutActive,
+			fmt.Sprintf("Blackout window %q is active until %s", blackout.Name, blackout.End.Format(time.RFC3339)),
+		)
+	} else {
+		r.instance.RemoveCondition(renovatev1beta1.RunnerConditionBlackout)
+	}
+
+	if err := r.Status().Patch(ctx, r.instance, patch); err != nil {
+		return fmt.Errorf("failed to patch blackout status: %w", err)
+	}
+
+	return nil
+}
@@ -23,6 +23,8 @@ import (
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
 	"github.com/thegeeklab/renovate-operator/internal/controller"
 	"github.com/thegeeklab/renovate-operator/internal/frontend/auth"
+	auth_azure "github.com/thegeeklab/renovate-operator/internal/frontend/auth/azure"
+	auth_bitbucketserver "github.com/thegeeklab/renovate-operator/internal/frontend/auth/bitbucketserver"
 	auth_gitea "github.com/thegeeklab/renovate-operator/internal/frontend/auth/gitea"
 	auth_github "github.com/thegeeklab/renovate-operator/internal/frontend/auth/github"
 	auth_gitlab "github.com/thegeeklab/renovate-operator/internal/frontend/auth/gitlab"
@@ -302,6 +304,10 @@ func (r *Reconciler) createAuthProvider(
 		return auth_github.NewGitHubProvider(ctx, cfg)
 	case renovatev1beta1.PlatformType_GITLAB:
 		return auth_gitlab.NewGitLabProvider(ctx, cfg)
+	case renovatev1beta1.PlatformType_BITBUCKET_SERVER:
+		return auth_bitbucketserver.NewBitbucketServerProvider(ctx, cfg)
+	case renovatev1beta1.PlatformType_AZURE:
+		return auth_azure.NewAzureProvider(ctx, cfg)
 	default:
 		return nil, fmt.Errorf("%w: %s", errUnsupportedType, ap.Spec.Type)
 	}
@@ -30,6 +30,7 @@ type Reconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
 	EventRecorder events.EventRecorder
+	ExternalURL   string
 	Metrics       metrics.Recorder
 }
 
@@ -38,11 +39,13 @@ type Reconciler struct {
 // +kubebuilder:rbac:groups=batch,resources=cronjobs,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=core,resources=configmaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=core,resources=secrets,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=rolebindings,verbs=get;list;watch;create;update;patch;delete
 
 // +kubebuilder:rbac:groups=renovate.thegeeklab.de,resources=discoveries,verbs=get;list;watch
 // +kubebuilder:rbac:groups=renovate.thegeeklab.de,resources=discoveries/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=renovate.thegeeklab.de,resources=discoveries/finalizers,verbs=update
 // +kubebuilder:rbac:groups=renovate.thegeeklab.de,resources=gitrepos,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=renovate.thegeeklab.de,resources=gitrepos/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=renovate.thegeeklab.de,resources=gitrepos/finalizers,verbs=update
@@ -56,6 +59,8 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	rd := &renovatev1beta1.Discovery{}
 	if err := r.Get(ctx, req.NamespacedName, rd); err != nil {
 		if api_errors.IsNotFound(err) {
+			discovery.ForgetRepoIDs(req.NamespacedName)
+
 			return ctrl.Result{}, nil
 		}
 
@@ -103,7 +108,9 @@ func (r *Reconciler) reconcile(
 		return controller.Outcome{Err: err}
 	}
 
-	componentReconciler, err := discovery.NewReconciler(r.Client, r.Scheme, rd, rc, r.Metrics)
+	componentReconciler, err := discovery.NewReconciler(
+		r.Client, r.Scheme, r.ExternalURL, rd, rc, r.Metrics, r.EventRecorder,
+	)
 	if err != nil {
 		return controller.Outcome{Err: err}
 	}
@@ -32,10 +32,12 @@ type RenovatorDetails struct {
 }
 
 type RunnerInfo struct {
-	Name         string    `json:"name"`
-	Namespace    string    `json:"namespace"`
-	CreatedAt    time.Time `json:"createdAt"`
-	RenovatorUID string    `json:"renovatorUid"`
+	Name           string    `json:"name"`
+	Namespace      string    `json:"namespace"`
+	CreatedAt      time.Time `json:"createdAt"`
+	RenovatorUID   string    `json:"renovatorUid"`
+	BlackoutWindow string    `json:"blackoutWindow,omitempty"`
+	BlackoutUntil  time.Time `json:"blackoutUntil,omitzero"`
 }
 
 type DiscoveryInfo struct {
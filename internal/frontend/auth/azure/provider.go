@@ -0,0 +1,363 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/thegeeklab/renovate-operator/internal/frontend/auth"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// defaultAuthURL is the multi-tenant Entra ID authorization endpoint for work
+	// and school accounts. Set AuthURL to a tenant-specific endpoint to restrict
+	// sign-in to a single tenant.
+	defaultAuthURL = "https://login.microsoftonline.com/organizations/oauth2/v2.0/authorize"
+
+	// devOpsScope requests a token for the Azure DevOps resource application.
+	devOpsScope = "499b84ac-1321-427f-aa17-267ca6975798/.default"
+
+	apiVersion         = "7.1"
+	defaultHTTPTimeout = 30 * time.Second
+	maxFetchTimeout    = 2 * time.Minute
+	repoCheckTimeout   = 10 * time.Second
+)
+
+var (
+	errNoRefreshToken   = errors.New("no refresh_token in token response")
+	errUnexpectedStatus = errors.New("unexpected status code")
+	errInvalidURL       = errors.New("invalid Azure DevOps URL")
+	errUserNotFound     = errors.New("authenticated user not found")
+)
+
+// AzureProvider implements Entra ID OAuth 2.0 sign-in for Azure DevOps, personal
+// access token validation, and repository authorization.
+type AzureProvider struct {
+	name         string
+	displayName  string
+	iconURL      string
+	apiURL       string
+	oauth2Config *oauth2.Config
+	httpClient   *http.Client
+}
+
+var _ auth.AuthProvider = (*AzureProvider)(nil)
+
+// NewAzureProvider creates an auth provider for an Azure DevOps organization.
+// The endpoint is the organization URL, e.g. `https://dev.azure.com/<org>`.
+func NewAzureProvider(ctx context.Context, cfg auth.ProviderConfig) (*AzureProvider, error) {
+	webURL := strings.TrimRight(strings.TrimSpace(cfg.Endpoint), "/")
+
+	apiURL := strings.TrimRight(strings.TrimSpace(cfg.ForgeURL), "/")
+	if apiURL == "" {
+		apiURL = webURL
+	}
+
+	for _, rawURL := range []string{webURL, apiURL} {
+		parsed, err := url.ParseRequestURI(rawURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return nil, fmt.Errorf("%w: %s", errInvalidURL, rawURL)
+		}
+	}
+
+	displayName := cfg.DisplayName
+	if displayName == "" {
+		displayName = displayNameFromURL(webURL)
+	}
+
+	iconURL := cfg.IconURL
+	if iconURL == "" {
+		iconURL = faviconURL(webURL)
+	}
+
+	authURL := defaultAuthURL
+	if cfg.AuthURL != "" {
+		authURL = cfg.AuthURL
+	}
+
+	httpClient := &http.Client{
+		Timeout:   defaultHTTPTimeout,
+		Transport: &http.Transport{TLSClientConfig: auth.NewTLSConfig(cfg.Insecure, cfg.CACert)},
+	}
+
+	return &AzureProvider{
+		name:        cfg.Name,
+		displayName: displayName,
+		iconURL:     iconURL,
+		apiURL:      apiURL,
+		oauth2Config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  authURL,
+				TokenURL: strings.TrimSuffix(authURL, "/authorize") + "/token",
+			},
+			Scopes: []string{devOpsScope, "offline_access"},
+		},
+		httpClient: httpClient,
+	}, nil
+}
+
+func (p *AzureProvider) Type() string        { return auth.ProviderTypeAzure }
+func (p *AzureProvider) Name() string        { return p.name }
+func (p *AzureProvider) DisplayName() string { return p.displayName }
+func (p *AzureProvider) IconURL() string     { return p.iconURL }
+func (p *AzureProvider) LoginURL(state, verifier string) string {
+	return p.oauth2Config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+}
+
+func (p *AzureProvider) HandleCallback(
+	ctx context.Context, code, verifier string,
+) (*auth.AuthenticatedUser, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+
+	token, err := p.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange token: %w", err)
+	}
+
+	return p.getUserFromToken(ctx, token)
+}
+
+func (p *AzureProvider) RefreshToken(
+	ctx context.Context, refreshToken string,
+) (*auth.AuthenticatedUser, error) {
+	if refreshToken == "" {
+		return nil, errNoRefreshToken
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	tokenSource := p.oauth2Config.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken})
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	return p.getUserFromToken(ctx, token)
+}
+
+type azureUser struct {
+	ID                  string `json:"id"`
+	ProviderDisplayName string `json:"providerDisplayName"`
+	Properties          struct {
+		Account struct {
+			Value string `json:"$value"` //nolint:tagliatelle // Azure DevOps property bag.
+		} `json:"Account"` //nolint:tagliatelle // Azure DevOps property bag.
+	} `json:"properties"`
+}
+
+type connectionData struct {
+	AuthenticatedUser azureUser `json:"authenticatedUser"`
+}
+
+func (p *AzureProvider) getUserFromToken(
+	ctx context.Context,
+	token *oauth2.Token,
+) (*auth.AuthenticatedUser, error) {
+	user, err := p.fetchUser(ctx, p.oauth2Config.Client(ctx, token), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	return p.authenticatedUser(user, token.AccessToken, token.RefreshToken, token.Expiry), nil
+}
+
+// fetchUser resolves the token owner from the organization connection data.
+// Personal access tokens are sent as basic auth password.
+func (p *AzureProvider) fetchUser(
+	ctx context.Context,
+	client *http.Client,
+	pat string,
+) (*azureUser, error) {
+	req, err := p.newRequest(ctx, "/_apis/connectionData")
+	if err != nil {
+		return nil, err
+	}
+
+	if pat != "" {
+		req.SetBasicAuth("", pat)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return nil, fmt.Errorf("%w: %d", errUnexpectedStatus, resp.StatusCode)
+	}
+
+	data := &connectionData{}
+	if err := json.NewDecoder(resp.Body).Decode(data); err != nil {
+		return nil, fmt.Errorf("failed to decode user: %w", err)
+	}
+
+	if data.AuthenticatedUser.ID == "" || data.AuthenticatedUser.Properties.Account.Value == "" {
+		return nil, errUserNotFound
+	}
+
+	return &data.AuthenticatedUser, nil
+}
+
+func (p *AzureProvider) authenticatedUser(
+	user *azureUser,
+	accessToken, refreshToken string,
+	expiry time.Time,
+) *auth.AuthenticatedUser {
+	name := user.ProviderDisplayName
+	if name == "" {
+		name = user.Properties.Account.Value
+	}
+
+	return &auth.AuthenticatedUser{
+		Email:        user.Properties.Account.Value,
+		Name:         name,
+		Subject:      user.ID,
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenExpiry:  expiry,
+		Provider:     p.name,
+	}
+}
+
+func (p *AzureProvider) ValidateToken(ctx context.Context, token string) (*auth.AuthenticatedUser, error) {
+	if token == "" {
+		return nil, auth.ErrInvalidToken
+	}
+
+	user, err := p.fetchUser(ctx, p.httpClient, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate token: %w", err)
+	}
+
+	return p.authenticatedUser(user, token, "", time.Time{}), nil
+}
+
+type azureRepo struct {
+	Name    string `json:"name"`
+	Project struct {
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+// GetUserRepos returns all repositories of the organization readable by the
+// user. Azure DevOps does not expose effective Git permissions in repository
+// listings, so read access is used as authorization criterion.
+func (p *AzureProvider) GetUserRepos(ctx context.Context, client *http.Client) (map[string]bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, maxFetchTimeout)
+	defer cancel()
+
+	req, err := p.newRequest(ctx, "/_apis/git/repositories")
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch repos: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return nil, fmt.Errorf("%w: %d", errUnexpectedStatus, resp.StatusCode)
+	}
+
+	repos := &struct {
+		Value []azureRepo `json:"value"`
+	}{}
+	if err := json.NewDecoder(resp.Body).Decode(repos); err != nil {
+		return nil, fmt.Errorf("failed to decode repos: %w", err)
+	}
+
+	result := make(map[string]bool, len(repos.Value))
+
+	for _, repo := range repos.Value {
+		if repo.Project.Name != "" && repo.Name != "" {
+			result[repo.Project.Name+"/"+repo.Name] = true
+		}
+	}
+
+	return result, nil
+}
+
+func (p *AzureProvider) IsUserRepo(ctx context.Context, client *http.Client, fullName string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, repoCheckTimeout)
+	defer cancel()
+
+	project, repo, found := strings.Cut(fullName, "/")
+	if !found || project == "" || repo == "" {
+		return false, nil
+	}
+
+	req, err := p.newRequest(ctx,
+		fmt.Sprintf("/%s/_apis/git/repositories/%s", url.PathEscape(project), url.PathEscape(repo)))
+	if err != nil {
+		return false, err
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to check repository access: %w", err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return true, nil
+	case http.StatusUnauthorized, http.StatusForbidden, http.StatusNotFound:
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w: %d", errUnexpectedStatus, resp.StatusCode)
+	}
+}
+
+func (p *AzureProvider) newRequest(ctx context.Context, path string) (*http.Request, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+path+"?api-version="+apiVersion, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	return req, nil
+}
+
+// displayNameFromURL returns the organization name for dev.azure.com URLs and
+// the host for legacy visualstudio.com and on-premises URLs.
+func displayNameFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	if org := strings.Trim(parsed.Path, "/"); parsed.Host == "dev.azure.com" && org != "" {
+		return org
+	}
+
+	return parsed.Host
+}
+
+func faviconURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s://%s/favicon.ico", parsed.Scheme, parsed.Host)
+}
@@ -0,0 +1,204 @@
+package azure
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/frontend/auth"
+	"golang.org/x/oauth2"
+)
+
+const connectionDataJSON = `{"authenticatedUser":{
+	"id":"user-id","providerDisplayName":"Test User",
+	"properties":{"Account":{"$type":"System.String","$value":"test@example.com"}}
+}}`
+
+var _ = Describe("AzureProvider", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	It("configures Entra ID endpoints, scopes, display, icon, and insecure TLS", func() {
+		provider, err := NewAzureProvider(ctx, auth.ProviderConfig{
+			Name:         "work",
+			Endpoint:     "https://dev.azure.com/example/",
+			ClientID:     "client",
+			ClientSecret: "secret",
+			RedirectURL:  "https://operator.example/callback",
+			Insecure:     true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.Type()).To(Equal(auth.ProviderTypeAzure))
+		Expect(provider.DisplayName()).To(Equal("example"))
+		Expect(provider.IconURL()).To(Equal("https://dev.azure.com/favicon.ico"))
+		Expect(provider.apiURL).To(Equal("https://dev.azure.com/example"))
+		Expect(provider.oauth2Config.Endpoint.AuthURL).To(Equal(defaultAuthURL))
+		Expect(provider.oauth2Config.Endpoint.TokenURL).To(
+			Equal("https://login.microsoftonline.com/organizations/oauth2/v2.0/token"),
+		)
+		Expect(provider.oauth2Config.Scopes).To(Equal([]string{devOpsScope, "offline_access"}))
+
+		transport, ok := provider.httpClient.Transport.(*http.Transport)
+		Expect(ok).To(BeTrue())
+		Expect(transport.TLSClientConfig.InsecureSkipVerify).To(BeTrue())
+	})
+
+	It("derives the token URL from a tenant-specific authorization URL", func() {
+		provider, err := NewAzureProvider(ctx, auth.ProviderConfig{
+			Endpoint: "https://example.visualstudio.com",
+			AuthURL:  "https://login.microsoftonline.com/tenant-id/oauth2/v2.0/authorize",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.DisplayName()).To(Equal("example.visualstudio.com"))
+		Expect(provider.oauth2Config.Endpoint.TokenURL).To(
+			Equal("https://login.microsoftonline.com/tenant-id/oauth2/v2.0/token"),
+		)
+	})
+
+	It("rejects an invalid endpoint", func() {
+		_, err := NewAzureProvider(ctx, auth.ProviderConfig{Endpoint: "not a url"})
+		Expect(err).To(MatchError(errInvalidURL))
+	})
+
+	It("creates a login URL with state and PKCE challenge", func() {
+		provider, err := NewAzureProvider(ctx, auth.ProviderConfig{
+			Endpoint:    "https://dev.azure.com/example",
+			ClientID:    "client",
+			RedirectURL: "https://operator.example/callback",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		loginURL := provider.LoginURL("state-token", oauth2.GenerateVerifier())
+		Expect(loginURL).To(HavePrefix(defaultAuthURL))
+		Expect(loginURL).To(ContainSubstring("state=state-token"))
+		Expect(loginURL).To(ContainSubstring("code_challenge_method=S256"))
+	})
+
+	It("exchanges a code and maps connection data user fields", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/oauth2/v2.0/token":
+				w.Header().Set("Content-Type", "application/json")
+				_, _ = fmt.Fprint(w, `{
+					"access_token":"access-token","token_type":"Bearer",
+					"refresh_token":"refresh-token","expires_in":3600
+				}`)
+			case "/example/_apis/connectionData":
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer access-token"))
+
+				_, _ = fmt.Fprint(w, connectionDataJSON)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+		defer server.Close()
+
+		provider, err := NewAzureProvider(ctx, auth.ProviderConfig{
+			Name:     "azure",
+			Endpoint: server.URL + "/example",
+			AuthURL:  server.URL + "/oauth2/v2.0/authorize",
+			ClientID: "client",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		provider.httpClient = server.Client()
+
+		user, err := provider.HandleCallback(ctx, "code", oauth2.GenerateVerifier())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(user.Subject).To(Equal("user-id"))
+		Expect(user.Name).To(Equal("Test User"))
+		Expect(user.Email).To(Equal("test@example.com"))
+		Expect(user.RefreshToken).To(Equal("refresh-token"))
+		Expect(user.Provider).To(Equal("azure"))
+	})
+
+	It("rejects refresh without a token", func() {
+		user, err := (&AzureProvider{}).RefreshToken(ctx, "")
+		Expect(err).To(MatchError(errNoRefreshToken))
+		Expect(user).To(BeNil())
+	})
+
+	Describe("ValidateToken", func() {
+		It("maps a valid PAT user sent as basic auth", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, password, ok := r.BasicAuth()
+				Expect(ok).To(BeTrue())
+				Expect(password).To(Equal("pat-token"))
+
+				_, _ = fmt.Fprint(w, connectionDataJSON)
+			}))
+			defer server.Close()
+
+			provider := &AzureProvider{name: "azure", apiURL: server.URL, httpClient: server.Client()}
+			user, err := provider.ValidateToken(ctx, "pat-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Email).To(Equal("test@example.com"))
+			Expect(user.AccessToken).To(Equal("pat-token"))
+		})
+
+		It("rejects an empty PAT", func() {
+			user, err := (&AzureProvider{}).ValidateToken(ctx, "")
+			Expect(err).To(MatchError(auth.ErrInvalidToken))
+			Expect(user).To(BeNil())
+		})
+
+		It("rejects anonymous connection data", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprint(w, `{"authenticatedUser":{"id":"anonymous"}}`)
+			}))
+			defer server.Close()
+
+			provider := &AzureProvider{apiURL: server.URL, httpClient: server.Client()}
+			user, err := provider.ValidateToken(ctx, "invalid")
+			Expect(err).To(MatchError(errUserNotFound))
+			Expect(user).To(BeNil())
+		})
+	})
+
+	It("keys organization repositories by project and name", func() {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Expect(r.URL.Path).To(Equal("/_apis/git/repositories"))
+
+			_, _ = fmt.Fprint(w, `{"value":[
+				{"name":"repo","project":{"name":"Project"}},
+				{"name":"other","project":{"name":"Other Project"}}
+			]}`)
+		}))
+		defer server.Close()
+
+		repos, err := (&AzureProvider{apiURL: server.URL}).GetUserRepos(ctx, server.Client())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(repos).To(Equal(map[string]bool{"Project/repo": true, "Other Project/other": true}))
+	})
+
+	DescribeTable(
+		"checks individual repository access",
+		func(status int, expected, expectError bool) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.EscapedPath()).To(Equal("/My%20Project/_apis/git/repositories/repo"))
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			accessible, err := (&AzureProvider{apiURL: server.URL}).IsUserRepo(ctx, server.Client(), "My Project/repo")
+			if expectError {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(accessible).To(Equal(expected))
+		},
+		Entry("readable", http.StatusOK, true, false),
+		Entry("forbidden", http.StatusForbidden, false, false),
+		Entry("missing", http.StatusNotFound, false, false),
+		Entry("server error", http.StatusInternalServerError, false, true),
+	)
+})
@@ -0,0 +1,524 @@
+package bitbucketserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cenkalti/backoff/v7"
+	"github.com/thegeeklab/renovate-operator/internal/frontend/auth"
+	"golang.org/x/oauth2"
+)
+
+const (
+	defaultPageSize    = 50
+	defaultHTTPTimeout = 30 * time.Second
+	maxFetchTimeout    = 2 * time.Minute
+	repoCheckTimeout   = 10 * time.Second
+	maxRepoPages       = 200
+
+	backoffInitial    = 200 * time.Millisecond
+	backoffMax        = 10 * time.Second
+	backoffMultiplier = 2.0
+	backoffMaxTries   = 3
+)
+
+var (
+	errNoRefreshToken   = errors.New("no refresh_token in token response")
+	errUnexpectedStatus = errors.New("unexpected status code")
+	errServerError      = errors.New("server error")
+	errRateLimited      = errors.New("rate limited")
+	errInvalidURL       = errors.New("invalid Bitbucket Server URL")
+	errMaxRepoPages     = errors.New("maximum repository page limit reached")
+	errUserNotFound     = errors.New("authenticated user not found")
+)
+
+// BitbucketServerProvider implements Bitbucket Server and Data Center OAuth 2.0,
+// HTTP access token validation, and repository authorization.
+type BitbucketServerProvider struct {
+	name         string
+	displayName  string
+	iconURL      string
+	webURL       string
+	apiURL       string
+	oauth2Config *oauth2.Config
+	httpClient   *http.Client
+}
+
+var _ auth.AuthProvider = (*BitbucketServerProvider)(nil)
+
+// NewBitbucketServerProvider creates an auth provider for a Bitbucket Data Center
+// instance with an incoming OAuth 2.0 application link.
+func NewBitbucketServerProvider(ctx context.Context, cfg auth.ProviderConfig) (*BitbucketServerProvider, error) {
+	webURL, apiURL, err := deriveURLs(cfg.Endpoint, cfg.ForgeURL)
+	if err != nil {
+		return nil, err
+	}
+
+	displayName := cfg.DisplayName
+	if displayName == "" {
+		displayName = hostFromURL(webURL)
+	}
+
+	iconURL := cfg.IconURL
+	if iconURL == "" {
+		iconURL = faviconURL(webURL)
+	}
+
+	authURL := webURL + "/rest/oauth2/latest/authorize"
+	if cfg.AuthURL != "" {
+		authURL = cfg.AuthURL
+	}
+
+	httpClient := &http.Client{
+		Timeout:   defaultHTTPTimeout,
+		Transport: &http.Transport{TLSClientConfig: auth.NewTLSConfig(cfg.Insecure, cfg.CACert)},
+	}
+
+	return &BitbucketServerProvider{
+		name:        cfg.Name,
+		displayName: displayName,
+		iconURL:     iconURL,
+		webURL:      webURL,
+		apiURL:      apiURL,
+		oauth2Config: &oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  authURL,
+				TokenURL: webURL + "/rest/oauth2/latest/token",
+			},
+			Scopes: []string{"PUBLIC_REPOS", "REPO_READ"},
+		},
+		httpClient: httpClient,
+	}, nil
+}
+
+func (p *BitbucketServerProvider) Type() string        { return auth.ProviderTypeBitbucketServer }
+func (p *BitbucketServerProvider) Name() string        { return p.name }
+func (p *BitbucketServerProvider) DisplayName() string { return p.displayName }
+func (p *BitbucketServerProvider) IconURL() string     { return p.iconURL }
+func (p *BitbucketServerProvider) LoginURL(state, verifier string) string {
+	return p.oauth2Config.AuthCodeURL(state, oauth2.S256ChallengeOption(verifier))
+}
+
+func (p *BitbucketServerProvider) HandleCallback(
+	ctx context.Context, code, verifier string,
+) (*auth.AuthenticatedUser, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+
+	token, err := p.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(verifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange token: %w", err)
+	}
+
+	return p.getUserFromToken(ctx, token)
+}
+
+func (p *BitbucketServerProvider) RefreshToken(
+	ctx context.Context, refreshToken string,
+) (*auth.AuthenticatedUser, error) {
+	if refreshToken == "" {
+		return nil, errNoRefreshToken
+	}
+
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+	tokenSource := p.oauth2Config.TokenSource(ctx, &oauth2.Token{RefreshToken: refreshToken})
+
+	token, err := tokenSource.Token()
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh token: %w", err)
+	}
+
+	return p.getUserFromToken(ctx, token)
+}
+
+type bitbucketUser struct {
+	ID           int64  `json:"id"`
+	Name         string `json:"name"`
+	Slug         string `json:"slug"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+type bitbucketPage[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+func (p *BitbucketServerProvider) getUserFromToken(
+	ctx context.Context,
+	token *oauth2.Token,
+) (*auth.AuthenticatedUser, error) {
+	user, err := p.fetchUser(ctx, p.oauth2Config.Client(ctx, token), "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+
+	return p.authenticatedUser(user, token.AccessToken, token.RefreshToken, token.Expiry), nil
+}
+
+// fetchUser resolves the username of the token owner via the whoami servlet
+// and looks up the full user record, since Bitbucket Server has no
+// "current user" REST resource.
+func (p *BitbucketServerProvider) fetchUser(
+	ctx context.Context,
+	client *http.Client,
+	bearerToken string,
+) (*bitbucketUser, error) {
+	body, err := p.get(ctx, client, bearerToken, p.webURL+"/plugins/servlet/applinks/whoami")
+	if err != nil {
+		return nil, err
+	}
+
+	username := strings.TrimSpace(string(body))
+	if username == "" {
+		return nil, errUserNotFound
+	}
+
+	query := url.Values{"filter": []string{username}}
+
+	body, err = p.get(ctx, client, bearerToken, p.apiURL+"/users?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	users := &bitbucketPage[bitbucketUser]{}
+	if err := json.Unmarshal(body, users); err != nil {
+		return nil, fmt.Errorf("failed to decode user: %w", err)
+	}
+
+	for i := range users.Values {
+		if users.Values[i].Name == username {
+			return &users.Values[i], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: %s", errUserNotFound, username)
+}
+
+func (p *BitbucketServerProvider) get(
+	ctx context.Context,
+	client *http.Client,
+	bearerToken, rawURL string,
+) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return nil, fmt.Errorf("%w: %d", errUnexpectedStatus, resp.StatusCode)
+	}
+
+	return io.ReadAll(resp.Body)
+}
+
+func (p *BitbucketServerProvider) authenticatedUser(
+	user *bitbucketUser,
+	accessToken, refreshToken string,
+	expiry time.Time,
+) *auth.AuthenticatedUser {
+	name := user.DisplayName
+	if name == "" {
+		name = user.Name
+	}
+
+	return &auth.AuthenticatedUser{
+		Email:        user.EmailAddress,
+		Name:         name,
+		Subject:      strconv.FormatInt(user.ID, 10),
+		AvatarURL:    fmt.Sprintf("%s/users/%s/avatar.png", p.webURL, url.PathEscape(user.Slug)),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		TokenExpiry:  expiry,
+		Provider:     p.name,
+	}
+}
+
+func (p *BitbucketServerProvider) ValidateToken(ctx context.Context, token string) (*auth.AuthenticatedUser, error) {
+	if token == "" {
+		return nil, auth.ErrInvalidToken
+	}
+
+	user, err := p.fetchUser(ctx, p.httpClient, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate token: %w", err)
+	}
+
+	return p.authenticatedUser(user, token, "", time.Time{}), nil
+}
+
+type bitbucketRepo struct {
+	Slug    string `json:"slug"`
+	Project struct {
+		Key string `json:"key"`
+	} `json:"project"`
+}
+
+func (r bitbucketRepo) fullName() string {
+	if r.Project.Key == "" || r.Slug == "" {
+		return ""
+	}
+
+	return r.Project.Key + "/" + r.Slug
+}
+
+func (p *BitbucketServerProvider) GetUserRepos(ctx context.Context, client *http.Client) (map[string]bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, maxFetchTimeout)
+	defer cancel()
+
+	result := make(map[string]bool)
+	query := url.Values{"permission": []string{"REPO_WRITE"}}
+	start := 0
+	completed := false
+
+	for range maxRepoPages {
+		if err := ctx.Err(); err != nil {
+			if len(result) > 0 {
+				return result, fmt.Errorf("fetch repos cancelled with partial results: %w", err)
+			}
+
+			return result, fmt.Errorf("fetch repos cancelled: %w", err)
+		}
+
+		repos, err := p.fetchPageWithRetry(ctx, client, query, start)
+		if err != nil {
+			if len(result) > 0 {
+				return result, fmt.Errorf("fetch failed with partial results: %w", err)
+			}
+
+			return result, err
+		}
+
+		for _, repo := range repos.Values {
+			if name := repo.fullName(); name != "" {
+				result[name] = true
+			}
+		}
+
+		if repos.IsLastPage {
+			completed = true
+
+			break
+		}
+
+		start = repos.NextPageStart
+	}
+
+	if !completed {
+		return result, fmt.Errorf("fetch stopped with partial results: %w", errMaxRepoPages)
+	}
+
+	return result, nil
+}
+
+// IsUserRepo reports whether the user can write to the repository. Bitbucket
+// Server exposes no per-repository permission field, so the write-filtered
+// repository listing of the owning project is searched instead.
+func (p *BitbucketServerProvider) IsUserRepo(ctx context.Context, client *http.Client, fullName string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, repoCheckTimeout)
+	defer cancel()
+
+	projectKey, slug, found := strings.Cut(fullName, "/")
+	if !found || projectKey == "" || slug == "" {
+		return false, nil
+	}
+
+	query := url.Values{
+		"permission": []string{"REPO_WRITE"},
+		"projectkey": []string{projectKey},
+	}
+	start := 0
+
+	for range maxRepoPages {
+		repos, statusCode, _, err := p.fetchPage(ctx, client, query, start)
+		if err != nil {
+			return false, fmt.Errorf("failed to check repository access: %w", err)
+		}
+
+		switch statusCode {
+		case http.StatusOK:
+		case http.StatusForbidden, http.StatusNotFound:
+			return false, nil
+		default:
+			return false, fmt.Errorf("%w: %d", errUnexpectedStatus, statusCode)
+		}
+
+		for _, repo := range repos.Values {
+			if repo.fullName() == fullName {
+				return true, nil
+			}
+		}
+
+		if repos.IsLastPage {
+			return false, nil
+		}
+
+		start = repos.NextPageStart
+	}
+
+	return false, errMaxRepoPages
+}
+
+func (p *BitbucketServerProvider) fetchPageWithRetry(
+	ctx context.Context,
+	client *http.Client,
+	query url.Values,
+	start int,
+) (*bitbucketPage[bitbucketRepo], error) {
+	bo := backoff.NewExponentialBackOff()
+	bo.InitialInterval = backoffInitial
+	bo.MaxInterval = backoffMax
+	bo.Multiplier = backoffMultiplier
+
+	return backoff.Retry(ctx, func() (*bitbucketPage[bitbucketRepo], error) {
+		repos, statusCode, retryAfter, err := p.fetchPage(ctx, client, query, start)
+		if err != nil {
+			return nil, err
+		}
+
+		if statusCode == http.StatusOK {
+			return repos, nil
+		}
+
+		if statusCode != http.StatusTooManyRequests && statusCode < http.StatusInternalServerError {
+			return nil, backoff.Permanent(fmt.Errorf("%w: %d", errUnexpectedStatus, statusCode))
+		}
+
+		if statusCode == http.StatusTooManyRequests {
+			if retryAfter > 0 {
+				return nil, backoff.RetryAfter(retryAfter, fmt.Errorf("%w: %d", errRateLimited, statusCode))
+			}
+
+			return nil, fmt.Errorf("%w: %d", errRateLimited, statusCode)
+		}
+
+		return nil, fmt.Errorf("%w: %d", errServerError, statusCode)
+	}, backoff.WithBackOff(bo), backoff.WithMaxTries(backoffMaxTries))
+}
+
+func (p *BitbucketServerProvider) fetchPage(
+	ctx context.Context,
+	client *http.Client,
+	query url.Values,
+	start int,
+) (*bitbucketPage[bitbucketRepo], int, time.Duration, error) {
+	pageQuery := url.Values{}
+	for key, values := range query {
+		pageQuery[key] = values
+	}
+
+	pageQuery.Set("start", strconv.Itoa(start))
+	pageQuery.Set("limit", strconv.Itoa(defaultPageSize))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.apiURL+"/repos?"+pageQuery.Encode(), nil)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("failed to fetch repos: %w", err)
+	}
+	defer resp.Body.Close()
+
+	retryAfter := parseRetryAfter(resp)
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return nil, resp.StatusCode, retryAfter, nil
+	}
+
+	repos := &bitbucketPage[bitbucketRepo]{}
+	if err := json.NewDecoder(resp.Body).Decode(repos); err != nil {
+		return nil, resp.StatusCode, 0, fmt.Errorf("failed to decode repos: %w", err)
+	}
+
+	return repos, resp.StatusCode, 0, nil
+}
+
+func parseRetryAfter(resp *http.Response) time.Duration {
+	retryAfter := resp.Header.Get("Retry-After")
+	if retryAfter == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	if retryAt, err := http.ParseTime(retryAfter); err == nil {
+		return time.Until(retryAt)
+	}
+
+	return 0
+}
+
+// deriveURLs returns the web root and the REST API root. Both the web root
+// and an API root ending in /rest/api/1.0 or /rest/api/latest are accepted.
+func deriveURLs(endpoint, forgeURL string) (string, string, error) {
+	endpoint = strings.TrimRight(strings.TrimSpace(endpoint), "/")
+	forgeURL = strings.TrimRight(strings.TrimSpace(forgeURL), "/")
+
+	webURL := trimAPISuffix(endpoint)
+	apiURL := webURL + "/rest/api/latest"
+
+	if forgeURL != "" && trimAPISuffix(forgeURL) != webURL {
+		apiURL = trimAPISuffix(forgeURL) + "/rest/api/latest"
+	}
+
+	for _, rawURL := range []string{webURL, apiURL} {
+		parsed, err := url.ParseRequestURI(rawURL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return "", "", fmt.Errorf("%w: %s", errInvalidURL, rawURL)
+		}
+	}
+
+	return webURL, apiURL, nil
+}
+
+func trimAPISuffix(rawURL string) string {
+	rawURL = strings.TrimSuffix(rawURL, "/rest/api/1.0")
+
+	return strings.TrimSuffix(rawURL, "/rest/api/latest")
+}
+
+func hostFromURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	return parsed.Host
+}
+
+func faviconURL(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("%s://%s/favicon.ico", parsed.Scheme, parsed.Host)
+}
@@ -0,0 +1,292 @@
+package bitbucketserver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/frontend/auth"
+	"golang.org/x/oauth2"
+)
+
+var _ = Describe("BitbucketServerProvider", func() {
+	var ctx context.Context
+
+	BeforeEach(func() {
+		ctx = context.Background()
+	})
+
+	DescribeTable(
+		"derives web and API URLs",
+		func(endpoint, forgeURL, expectedWeb, expectedAPI string) {
+			webURL, apiURL, err := deriveURLs(endpoint, forgeURL)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(webURL).To(Equal(expectedWeb))
+			Expect(apiURL).To(Equal(expectedAPI))
+		},
+		Entry(
+			"web endpoint",
+			"https://bitbucket.example.com/",
+			"",
+			"https://bitbucket.example.com",
+			"https://bitbucket.example.com/rest/api/latest",
+		),
+		Entry(
+			"endpoint with REST API 1.0 suffix",
+			"https://bitbucket.example.com/rest/api/1.0/",
+			"",
+			"https://bitbucket.example.com",
+			"https://bitbucket.example.com/rest/api/latest",
+		),
+		Entry(
+			"endpoint with context path",
+			"https://example.com/bitbucket/rest/api/latest",
+			"",
+			"https://example.com/bitbucket",
+			"https://example.com/bitbucket/rest/api/latest",
+		),
+		Entry(
+			"explicit forge URL",
+			"https://bitbucket.example.com",
+			"https://api.example.com/rest/api/1.0",
+			"https://bitbucket.example.com",
+			"https://api.example.com/rest/api/latest",
+		),
+	)
+
+	It("rejects an invalid endpoint", func() {
+		_, err := NewBitbucketServerProvider(ctx, auth.ProviderConfig{Endpoint: "not a url"})
+		Expect(err).To(MatchError(errInvalidURL))
+	})
+
+	It("configures OAuth endpoints, scopes, display, icon, and insecure TLS", func() {
+		provider, err := NewBitbucketServerProvider(ctx, auth.ProviderConfig{
+			Name:         "work",
+			Endpoint:     "https://bitbucket.example.com",
+			ClientID:     "client",
+			ClientSecret: "secret",
+			RedirectURL:  "https://operator.example/callback",
+			Insecure:     true,
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(provider.Type()).To(Equal(auth.ProviderTypeBitbucketServer))
+		Expect(provider.DisplayName()).To(Equal("bitbucket.example.com"))
+		Expect(provider.IconURL()).To(Equal("https://bitbucket.example.com/favicon.ico"))
+		Expect(provider.oauth2Config.Endpoint.AuthURL).To(
+			Equal("https://bitbucket.example.com/rest/oauth2/latest/authorize"),
+		)
+		Expect(provider.oauth2Config.Endpoint.TokenURL).To(
+			Equal("https://bitbucket.example.com/rest/oauth2/latest/token"),
+		)
+		Expect(provider.oauth2Config.Scopes).To(Equal([]string{"PUBLIC_REPOS", "REPO_READ"}))
+
+		transport, ok := provider.httpClient.Transport.(*http.Transport)
+		Expect(ok).To(BeTrue())
+		Expect(transport.TLSClientConfig.InsecureSkipVerify).To(BeTrue())
+	})
+
+	It("creates a login URL with state and PKCE challenge", func() {
+		provider, err := NewBitbucketServerProvider(ctx, auth.ProviderConfig{
+			Endpoint:    "https://bitbucket.example.com",
+			ClientID:    "client",
+			RedirectURL: "https://operator.example/callback",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		loginURL := provider.LoginURL("state-token", oauth2.GenerateVerifier())
+		Expect(loginURL).To(ContainSubstring("state=state-token"))
+		Expect(loginURL).To(ContainSubstring("code_challenge_method=S256"))
+		Expect(loginURL).To(ContainSubstring("scope=PUBLIC_REPOS+REPO_READ"))
+	})
+
+	It("exchanges a code and maps Bitbucket user fields", func() {
+		server := bitbucketOAuthServer()
+		defer server.Close()
+
+		provider, err := NewBitbucketServerProvider(ctx, auth.ProviderConfig{
+			Name:         "bitbucket",
+			Endpoint:     server.URL,
+			ClientID:     "client",
+			ClientSecret: "secret",
+			RedirectURL:  "https://operator.example/callback",
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		provider.httpClient = server.Client()
+
+		user, err := provider.HandleCallback(ctx, "code", oauth2.GenerateVerifier())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(user.Subject).To(Equal("42"))
+		Expect(user.Name).To(Equal("Test User"))
+		Expect(user.Email).To(Equal("test@example.com"))
+		Expect(user.AvatarURL).To(Equal(server.URL + "/users/tuser/avatar.png"))
+		Expect(user.AccessToken).To(Equal("access-token"))
+		Expect(user.RefreshToken).To(Equal("refresh-token"))
+		Expect(user.Provider).To(Equal("bitbucket"))
+	})
+
+	It("rejects refresh without a token", func() {
+		user, err := (&BitbucketServerProvider{}).RefreshToken(ctx, "")
+		Expect(err).To(MatchError(errNoRefreshToken))
+		Expect(user).To(BeNil())
+	})
+
+	Describe("ValidateToken", func() {
+		It("maps a valid HTTP access token user", func() {
+			server := bitbucketOAuthServer()
+			defer server.Close()
+
+			provider := &BitbucketServerProvider{
+				name:       "bitbucket",
+				webURL:     server.URL,
+				apiURL:     server.URL + "/rest/api/latest",
+				httpClient: server.Client(),
+			}
+
+			user, err := provider.ValidateToken(ctx, "access-token")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(user.Name).To(Equal("Test User"))
+			Expect(user.AccessToken).To(Equal("access-token"))
+		})
+
+		It("rejects an empty token", func() {
+			user, err := (&BitbucketServerProvider{}).ValidateToken(ctx, "")
+			Expect(err).To(MatchError(auth.ErrInvalidToken))
+			Expect(user).To(BeNil())
+		})
+
+		It("rejects anonymous whoami responses", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				_, _ = fmt.Fprint(w, "")
+			}))
+			defer server.Close()
+
+			provider := &BitbucketServerProvider{webURL: server.URL, httpClient: server.Client()}
+			user, err := provider.ValidateToken(ctx, "invalid")
+			Expect(err).To(MatchError(errUserNotFound))
+			Expect(user).To(BeNil())
+		})
+	})
+
+	Describe("GetUserRepos", func() {
+		It("paginates and keys repositories by project and slug", func() {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query := r.URL.Query()
+				Expect(query.Get("permission")).To(Equal("REPO_WRITE"))
+
+				if query.Get("start") == "0" {
+					repos := make([]string, defaultPageSize)
+					for i := range repos {
+						repos[i] = fmt.Sprintf(`{"slug":"repo-%d","project":{"key":"PROJ"}}`, i)
+					}
+
+					_, _ = fmt.Fprintf(w, `{"values":[%s],"isLastPage":false,"nextPageStart":%d}`,
+						strings.Join(repos, ","), defaultPageSize)
+
+					return
+				}
+
+				_, _ = fmt.Fprint(w, `{"values":[{"slug":"other","project":{"key":"OPS"}}],"isLastPage":true}`)
+			}))
+			defer server.Close()
+
+			provider := &BitbucketServerProvider{apiURL: server.URL}
+			repos, err := provider.GetUserRepos(ctx, server.Client())
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repos).To(HaveLen(defaultPageSize + 1))
+			Expect(repos).To(HaveKeyWithValue("OPS/other", true))
+		})
+
+		It("returns partial results when a later page fails", func() {
+			var requests atomic.Int32
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Query().Get("start") == "0" {
+					_, _ = fmt.Fprint(w, `{"values":[{"slug":"repo","project":{"key":"PROJ"}}],`+
+						`"isLastPage":false,"nextPageStart":1}`)
+
+					return
+				}
+
+				requests.Add(1)
+				w.WriteHeader(http.StatusForbidden)
+			}))
+			defer server.Close()
+
+			provider := &BitbucketServerProvider{apiURL: server.URL}
+			repos, err := provider.GetUserRepos(ctx, server.Client())
+			Expect(err).To(MatchError(ContainSubstring("partial results")))
+			Expect(repos).To(HaveLen(1))
+			Expect(requests.Load()).To(Equal(int32(1)))
+		})
+	})
+
+	DescribeTable(
+		"checks individual repository access",
+		func(fullName string, status int, payload string, expected, expectError bool) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Query().Get("projectkey")).To(Equal("PROJ"))
+				Expect(r.URL.Query().Get("permission")).To(Equal("REPO_WRITE"))
+				w.WriteHeader(status)
+				_, _ = fmt.Fprint(w, payload)
+			}))
+			defer server.Close()
+
+			provider := &BitbucketServerProvider{apiURL: server.URL}
+
+			accessible, err := provider.IsUserRepo(ctx, server.Client(), fullName)
+			if expectError {
+				Expect(err).To(HaveOccurred())
+			} else {
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(accessible).To(Equal(expected))
+		},
+		Entry("writable", "PROJ/repo", http.StatusOK,
+			`{"values":[{"slug":"repo","project":{"key":"PROJ"}}],"isLastPage":true}`, true, false),
+		Entry("not writable", "PROJ/repo", http.StatusOK,
+			`{"values":[{"slug":"other","project":{"key":"PROJ"}}],"isLastPage":true}`, false, false),
+		Entry("forbidden", "PROJ/repo", http.StatusForbidden, `{}`, false, false),
+		Entry("missing project", "PROJ/repo", http.StatusNotFound, `{}`, false, false),
+		Entry("server error", "PROJ/repo", http.StatusInternalServerError, `{}`, false, true),
+	)
+
+	It("rejects malformed repository names without a request", func() {
+		accessible, err := (&BitbucketServerProvider{}).IsUserRepo(ctx, http.DefaultClient, "repo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(accessible).To(BeFalse())
+	})
+})
+
+func bitbucketOAuthServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/oauth2/latest/token":
+			w.Header().Set("Content-Type", "application/json")
+			_, _ = fmt.Fprint(w, `{
+				"access_token":"access-token","token_type":"Bearer",
+				"refresh_token":"refresh-token","expires_in":3600
+			}`)
+		case "/plugins/servlet/applinks/whoami":
+			Expect(r.Header.Get("Authorization")).To(Equal("Bearer access-token"))
+
+			_, _ = fmt.Fprint(w, "tuser")
+		case "/rest/api/latest/users":
+			Expect(r.URL.Query().Get("filter")).To(Equal("tuser"))
+
+			_, _ = fmt.Fprint(w, `{"values":[
+				{"id":7,"name":"tuser2","slug":"tuser2"},
+				{"id":42,"name":"tuser","slug":"tuser","displayName":"Test User","emailAddress":"test@example.com"}
+			],"isLastPage":true}`)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+}
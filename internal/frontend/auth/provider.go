@@ -15,9 +15,11 @@ import (
 )
 
 const (
-	ProviderTypeGitea  = "gitea"
-	ProviderTypeGitHub = "github"
-	ProviderTypeGitLab = "gitlab"
+	ProviderTypeGitea           = "gitea"
+	ProviderTypeGitHub          = "github"
+	ProviderTypeGitLab          = "gitlab"
+	ProviderTypeBitbucketServer = "bitbucket-server"
+	ProviderTypeAzure           = "azure"
 )
 
 var (
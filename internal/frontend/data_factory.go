@@ -17,6 +17,7 @@ import (
 	"github.com/thegeeklab/renovate-operator/internal/logreader"
 	"github.com/thegeeklab/renovate-operator/internal/parser"
 	"github.com/thegeeklab/renovate-operator/internal/resource/renovate"
+	"github.com/thegeeklab/renovate-operator/internal/scheduler"
 	"github.com/thegeeklab/renovate-operator/pkg/util"
 	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
 	"golang.org/x/sync/errgroup"
@@ -338,6 +339,32 @@ func (df *DataFactory) GetGitRepo(ctx context.Context, namespace, name string) (
 	return &info, nil
 }
 
+// GetWebhookDeliveries fetches the webhook delivery history of a GitRepo,
+// newest first.
+func (df *DataFactory) GetWebhookDeliveries(
+	ctx context.Context, namespace, name string,
+) ([]viewmodel.WebhookDeliveryInfo, error) {
+	var gitrepo renovatev1beta1.GitRepo
+	if err := df.client.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, &gitrepo); err != nil {
+		return nil, err
+	}
+
+	result := make([]viewmodel.WebhookDeliveryInfo, 0, len(gitrepo.Status.WebhookDeliveries))
+
+	for _, delivery := range gitrepo.Status.WebhookDeliveries {
+		result = append(result, viewmodel.WebhookDeliveryInfo{
+			ID:         delivery.ID,
+			Event:      delivery.Event,
+			Sender:     delivery.Sender,
+			Decision:   viewmodel.WebhookDecision(delivery.Decision),
+			Reason:     delivery.Reason,
+			ReceivedAt: delivery.Time.UTC(),
+		})
+	}
+
+	return result, nil
+}
+
 // GetGitRepos fetches GitRepo resources with optional filtering.
 func (df *DataFactory) GetGitRepos(ctx context.Context, opts ...ListOptions) ([]viewmodel.GitRepoInfo, error) {
 	opt := getListOptions(opts)
@@ -411,6 +438,7 @@ func gitRepoToInfo(gitrepo *renovatev1beta1.GitRepo) viewmodel.GitRepoInfo {
 		LastRenovateStatus: lastStatus,
 		CreatedAt:          gitrepo.CreationTimestamp.Time,
 		RenovatorUID:       extractRenovatorUID(gitrepo.Labels),
+		QueuePosition:      gitrepo.Status.QueuePosition,
 	}
 }
 
@@ -462,13 +490,22 @@ func (df *DataFactory) GetRunners(ctx context.Context, opts ...ListOptions) ([]R
 
 	var result []RunnerInfo
 
+	now := time.Now()
+
 	for _, runner := range list.Items {
-		result = append(result, RunnerInfo{
+		info := RunnerInfo{
 			Name:         runner.Name,
 			Namespace:    runner.Namespace,
 			CreatedAt:    runner.CreationTimestamp.Time,
 			RenovatorUID: extractRenovatorUID(runner.Labels),
-		})
+		}
+
+		if blackout, active, err := scheduler.ActiveBlackoutAt(&runner, now); err == nil && active {
+			info.BlackoutWindow = blackout.Name
+			info.BlackoutUntil = blackout.End
+		}
+
+		result = append(result, info)
 	}
 
 	// Apply authorization filtering when auth is enabled
@@ -682,7 +719,7 @@ func (df *DataFactory) computePerRepoActivity(
 		}
 
 		g.Go(func() error {
-			sample := df.parseJobPRActivity(ctx, repo.Namespace, job)
+			sample := df.parseJobPRActivity(ctx, repo.Namespace, repo.FullName, job)
 
 			sample.repoLabel = repoLabel
 			results <- sample
@@ -794,17 +831,19 @@ func (df *DataFactory) findLatestTerminalJobsByRepo(
 				continue
 			}
 
-			repoName := job.Labels[renovatev1beta1.LabelGitRepo]
-			if repoName == "" {
-				continue
+			repoNames := renovate.BatchRepoLabels(job.Labels)
+			if repoName := job.Labels[renovatev1beta1.LabelGitRepo]; repoName != "" {
+				repoNames = append(repoNames, repoName)
 			}
 
-			if existing, ok := latest[repoName]; ok {
-				if job.CreationTimestamp.After(existing.CreationTimestamp.Time) {
+			for _, repoName := range repoNames {
+				if existing, ok := latest[repoName]; ok {
+					if job.CreationTimestamp.After(existing.CreationTimestamp.Time) {
+						latest[repoName] = job
+					}
+				} else {
 					latest[repoName] = job
 				}
-			} else {
-				latest[repoName] = job
 			}
 		}
 
@@ -820,10 +859,11 @@ func (df *DataFactory) findLatestTerminalJobsByRepo(
 
 // parseJobPRActivity streams a Job's pod logs through parser.ParseLogs
 // and returns the parsed open-PR counts. Line-by-line parsing keeps memory
-// bounded by per-line allocations rather than the full log buffer.
+// bounded by per-line allocations rather than the full log buffer. For batch
+// Jobs, only the log entries of the given repository are considered.
 func (df *DataFactory) parseJobPRActivity(
 	ctx context.Context,
-	namespace string,
+	namespace, repoName string,
 	job *batchv1.Job,
 ) prJobSample {
 	stream, err := df.GetJobLogs(ctx, namespace, job.Name, 0)
@@ -835,7 +875,17 @@ func (df *DataFactory) parseJobPRActivity(
 	}
 	defer stream.Close()
 
-	res, err := parser.ParseLogs(stream, -1)
+	var res *parser.ParseLogsResult
+
+	if len(renovate.BatchRepoLabels(job.Labels)) > 0 {
+		var results map[string]*parser.ParseLogsResult
+
+		results, err = parser.ParseLogsByRepository(stream, -1)
+		res = results[repoName]
+	} else {
+		res, err = parser.ParseLogs(stream, -1)
+	}
+
 	if err != nil {
 		frontendLog.Error(err, "Failed to parse PR activity from job logs",
 			"namespace", namespace, "job", job.Name)
@@ -843,6 +893,10 @@ func (df *DataFactory) parseJobPRActivity(
 		return prJobSample{}
 	}
 
+	if res == nil {
+		return prJobSample{}
+	}
+
 	activity := res.PRActivity
 
 	return prJobSample{
@@ -894,17 +948,28 @@ func (df *DataFactory) GetJobsForRepo(
 		client.MatchingLabels{renovatev1beta1.LabelGitRepo: repoLabel},
 	}
 
+	// Batch jobs process the GitRepo together with other GitRepos.
+	batchListOpts := []client.ListOption{
+		client.MatchingLabels{renovate.BatchLabel(repoLabel): renovatev1beta1.ValueTrue},
+	}
+
 	if opt.Namespace != "" {
 		listOpts = append(listOpts, client.InNamespace(opt.Namespace))
+		batchListOpts = append(batchListOpts, client.InNamespace(opt.Namespace))
 	}
 
 	if err := df.client.List(ctx, &jobList, listOpts...); err != nil {
 		return nil, err
 	}
 
+	var batchJobList batchv1.JobList
+	if err := df.client.List(ctx, &batchJobList, batchListOpts...); err != nil {
+		return nil, err
+	}
+
 	var result []viewmodel.JobInfo
 
-	for _, job := range jobList.Items {
+	for _, job := range slices.Concat(jobList.Items, batchJobList.Items) {
 		status := viewmodel.StatusRunning
 
 		if job.Status.CompletionTime != nil {
@@ -299,6 +299,45 @@ var _ = Describe("DataFactory", func() {
 		})
 	})
 
+	Describe("GetWebhookDeliveries", func() {
+		It("should return the webhook deliveries of the git repo", func() {
+			receivedAt := time.Date(2026, 2, 27, 15, 0, 0, 0, time.UTC)
+			repo := &renovatev1beta1.GitRepo{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "delivery-repo",
+					Namespace: "test-namespace",
+				},
+				Status: renovatev1beta1.GitRepoStatus{
+					WebhookDeliveries: []renovatev1beta1.WebhookDelivery{{
+						ID:       "delivery-1",
+						Event:    "push",
+						Sender:   "renovate-bot",
+						Decision: renovatev1beta1.WebhookDeliveryDecision_FILTERED,
+						Reason:   "no changed file matches the path filter",
+						Time:     metav1.NewTime(receivedAt),
+					}},
+				},
+			}
+			Expect(fakeClient.Create(context.Background(), repo)).To(Succeed())
+
+			deliveries, err := dataFactory.GetWebhookDeliveries(context.Background(), "test-namespace", "delivery-repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deliveries).To(Equal([]viewmodel.WebhookDeliveryInfo{{
+				ID:         "delivery-1",
+				Event:      "push",
+				Sender:     "renovate-bot",
+				Decision:   viewmodel.WebhookDecisionFiltered,
+				Reason:     "no changed file matches the path filter",
+				ReceivedAt: receivedAt,
+			}}))
+		})
+
+		It("should return error when repo does not exist", func() {
+			_, err := dataFactory.GetWebhookDeliveries(context.Background(), "test-namespace", "nonexistent")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
 	Describe("GetRunners", func() {
 		It("should correctly filter runners by Renovator label", func() {
 			opts := ListOptions{Renovator: "test-renovator"}
@@ -524,6 +563,30 @@ var _ = Describe("DataFactory", func() {
 			Expect(latest["repo-b"].Name).To(Equal("repo-b-only"))
 		})
 
+		It("returns batch jobs for each of their repos", func() {
+			now := metav1.NewTime(time.Now().Add(-1 * time.Minute))
+			Expect(fakeClient.Create(context.Background(), &batchv1.Job{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "batch-job",
+					Namespace: "test-namespace",
+					Labels: map[string]string{
+						renovatev1beta1.LabelRenovator:                     "test-renovator",
+						renovatev1beta1.LabelBatchGitRepoPrefix + "repo-a": renovatev1beta1.ValueTrue,
+						renovatev1beta1.LabelBatchGitRepoPrefix + "repo-b": renovatev1beta1.ValueTrue,
+					},
+				},
+				Status: batchv1.JobStatus{CompletionTime: &now},
+			})).To(Succeed())
+
+			latest, err := dataFactory.findLatestTerminalJobsByRepo(
+				context.Background(), "test-namespace", "test-renovator",
+			)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(latest).To(HaveLen(2))
+			Expect(latest["repo-a"].Name).To(Equal("batch-job"))
+			Expect(latest["repo-b"].Name).To(Equal("batch-job"))
+		})
+
 		It("keys the map by the same label value the runner writes (truncated/hashed for long names)", func() {
 			// Reproduces the bug where jobs are labeled with k8s.SanitizeLabel(repo.Name)
 			// (a 63-char DNS-1035 normalization) but the aggregator was looking them
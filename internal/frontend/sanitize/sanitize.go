@@ -50,6 +50,11 @@ func GitrepoURL(namespace, name string) string {
 		"&name=" + QueryEscape(name)
 }
 
+// GitrepoTabURL builds a /gitrepo URL opening the given tab of the detail view.
+func GitrepoTabURL(namespace, name, tab string) string {
+	return GitrepoURL(namespace, name) + "&tab=" + QueryEscape(tab)
+}
+
 // JobLogsURL builds a /joblogs URL with safely escaped query parameters.
 // When all is true, the URL requests the full log instead of the default
 // display tail.
@@ -61,6 +61,17 @@ var _ = Describe("sanitize helpers", func() {
 		})
 	})
 
+	Describe("GitrepoTabURL", func() {
+		It("builds a URL with namespace, name and tab", func() {
+			Expect(GitrepoTabURL("ns", "name", "webhooks")).To(Equal("/gitrepo?namespace=ns&name=name&tab=webhooks"))
+		})
+
+		It("escapes user-controlled tab", func() {
+			got := GitrepoTabURL("ns", "name", `tab";alert(1)`)
+			Expect(got).NotTo(ContainSubstring(`";alert`))
+		})
+	})
+
 	Describe("JobLogsURL", func() {
 		It("builds a URL with namespace, runner, job, platform, and repoUrl", func() {
 			Expect(JobLogsURL("ns", "runner", "job", "github", "https://github.com/owner/repo", false)).
@@ -165,6 +165,10 @@ type WebView struct {
 	HasRecentPR   bool
 	WarnCount     int
 	ErrorCount    int
+	// BlackoutWindow is the name of the active blackout window of the Runner.
+	BlackoutWindow string
+	// BlackoutUntil is the time at which scheduled runs are allowed again.
+	BlackoutUntil time.Time
 }
 
 // GitRepoInfo is the view-layer representation of a GitRepo.
@@ -184,6 +188,7 @@ type GitRepoInfo struct {
 	UnchangedPRs       int       `json:"unchangedPRs"`
 	WarnCount          int       `json:"warnCount"`
 	ErrorCount         int       `json:"errorCount"`
+	QueuePosition      int32     `json:"queuePosition,omitzero"`
 }
 
 // JobInfo is the view-layer representation of a Kubernetes Job.
@@ -195,11 +200,96 @@ type JobInfo struct {
 	CreatedAt time.Time `json:"createdAt"`
 }
 
-// GitRepoViewData bundles a single GitRepo with its associated jobs for the
-// gitrepo detail view.
+// WebhookDecision represents the outcome of a webhook delivery.
+type WebhookDecision string
+
+const (
+	WebhookDecisionAccepted WebhookDecision = "accepted"
+	WebhookDecisionIgnored  WebhookDecision = "ignored"
+	WebhookDecisionFiltered WebhookDecision = "filtered"
+	WebhookDecisionRejected WebhookDecision = "rejected"
+)
+
+// TranslatedLabel returns the translated label for the decision.
+func (d WebhookDecision) TranslatedLabel(ctx context.Context) string {
+	tr := i18n.FromContext(ctx)
+
+	switch d {
+	case WebhookDecisionAccepted:
+		return tr.T("webhook.accepted")
+	case WebhookDecisionIgnored:
+		return tr.T("webhook.ignored")
+	case WebhookDecisionFiltered:
+		return tr.T("webhook.filtered")
+	case WebhookDecisionRejected:
+		return tr.T("webhook.rejected")
+	default:
+		return string(d)
+	}
+}
+
+// BadgeClass returns the Tailwind class set used to render the decision as a
+// rounded pill (badge).
+func (d WebhookDecision) BadgeClass() string {
+	return d.status().BadgeClass()
+}
+
+// LeftBorderClass returns the Tailwind border-color class for the left edge
+// of a list item that conveys the decision.
+func (d WebhookDecision) LeftBorderClass() string {
+	return d.status().LeftBorderClass()
+}
+
+// status maps the decision to the run status sharing its colors.
+func (d WebhookDecision) status() Status {
+	switch d {
+	case WebhookDecisionAccepted:
+		return StatusSucceeded
+	case WebhookDecisionRejected:
+		return StatusFailed
+	default:
+		return StatusUnknown
+	}
+}
+
+// WebhookDeliveryInfo is the view-layer representation of a webhook delivery
+// received for a GitRepo.
+type WebhookDeliveryInfo struct {
+	ID         string          `json:"id"`
+	Event      string          `json:"event"`
+	Sender     string          `json:"sender"`
+	Decision   WebhookDecision `json:"decision"`
+	Reason     string          `json:"reason"`
+	ReceivedAt time.Time       `json:"receivedAt"`
+}
+
+// GitRepoTab identifies a tab of the gitrepo detail view.
+type GitRepoTab string
+
+const (
+	// GitRepoTabJobs lists the recent jobs of the repository.
+	GitRepoTabJobs GitRepoTab = "jobs"
+	// GitRepoTabWebhooks lists the webhook deliveries of the repository.
+	GitRepoTabWebhooks GitRepoTab = "webhooks"
+)
+
+// ParseGitRepoTab returns the tab for the given query parameter value,
+// defaulting to the jobs tab.
+func ParseGitRepoTab(value string) GitRepoTab {
+	if GitRepoTab(value) == GitRepoTabWebhooks {
+		return GitRepoTabWebhooks
+	}
+
+	return GitRepoTabJobs
+}
+
+// GitRepoViewData bundles a single GitRepo with its associated jobs or
+// webhook deliveries for the gitrepo detail view, depending on the Tab.
 type GitRepoViewData struct {
-	Repo GitRepoInfo
-	Jobs []JobInfo
+	Repo       GitRepoInfo
+	Tab        GitRepoTab
+	Jobs       []JobInfo
+	Deliveries []WebhookDeliveryInfo
 }
 
 // DashboardData carries either the renovator list or search results for the
@@ -360,8 +450,10 @@ func TranslatedPRActivitySummary(ctx context.Context, activity *parser.PRActivit
 // used to deep-link a PR number. Supported placeholders are {repo} and
 // {number}.
 var PRURLPatterns = map[string]string{
-	"github": "{repo}/pull/{number}",
-	"gitea":  "{repo}/pulls/{number}",
+	"github":           "{repo}/pull/{number}",
+	"gitea":            "{repo}/pulls/{number}",
+	"bitbucket-server": "{repo}/pull-requests/{number}",
+	"azure":            "{repo}/pullrequest/{number}",
 }
 
 // BuildPRURL returns a deep link to a PR for the given platform and repo, or
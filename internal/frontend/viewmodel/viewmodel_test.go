@@ -162,3 +162,44 @@ var _ = Describe("TranslatedPRActivitySummary", func() {
 		Expect(result).To(Equal("2 automerged, 3 created, 1 needs approval"))
 	})
 })
+
+var _ = Describe("WebhookDecision", func() {
+	Describe("TranslatedLabel", func() {
+		DescribeTable(
+			"maps each decision to the correct i18n key",
+			func(d WebhookDecision, wantKey string) {
+				Expect(d.TranslatedLabel(context.Background())).To(Equal(wantKey))
+			},
+			Entry("accepted", WebhookDecisionAccepted, "webhook.accepted"),
+			Entry("ignored", WebhookDecisionIgnored, "webhook.ignored"),
+			Entry("filtered", WebhookDecisionFiltered, "webhook.filtered"),
+			Entry("rejected", WebhookDecisionRejected, "webhook.rejected"),
+			Entry("unknown value", WebhookDecision("nonsense"), "nonsense"),
+		)
+	})
+
+	DescribeTable(
+		"reuses the classes of the matching status",
+		func(d WebhookDecision, s Status) {
+			Expect(d.BadgeClass()).To(Equal(s.BadgeClass()))
+			Expect(d.LeftBorderClass()).To(Equal(s.LeftBorderClass()))
+		},
+		Entry("accepted", WebhookDecisionAccepted, StatusSucceeded),
+		Entry("ignored", WebhookDecisionIgnored, StatusUnknown),
+		Entry("filtered", WebhookDecisionFiltered, StatusUnknown),
+		Entry("rejected", WebhookDecisionRejected, StatusFailed),
+	)
+})
+
+var _ = Describe("ParseGitRepoTab", func() {
+	DescribeTable(
+		"returns the tab for the query parameter",
+		func(value string, want GitRepoTab) {
+			Expect(ParseGitRepoTab(value)).To(Equal(want))
+		},
+		Entry("jobs", "jobs", GitRepoTabJobs),
+		Entry("webhooks", "webhooks", GitRepoTabWebhooks),
+		Entry("empty", "", GitRepoTabJobs),
+		Entry("unknown", "nonsense", GitRepoTabJobs),
+	)
+})
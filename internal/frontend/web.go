@@ -258,6 +258,8 @@ func (h *WebHandler) buildRenovatorSummaries(
 			}
 			if len(runners) > 0 {
 				summary.RunnerName = runners[0].Name
+				summary.BlackoutWindow = runners[0].BlackoutWindow
+				summary.BlackoutUntil = runners[0].BlackoutUntil
 			}
 
 			if len(discoveries) > 0 {
@@ -460,17 +462,27 @@ func (h *WebHandler) HandleGitRepoView(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	jobs, err := h.dataFactory.GetJobsForRepo(ctx, name, opts)
-	if err != nil {
-		frontendLog.Error(err, "Failed to fetch jobs", "repo", name, "namespace", opts.Namespace)
-		http.Error(w, "Failed to fetch jobs", http.StatusInternalServerError)
-
-		return
-	}
-
 	data := viewmodel.GitRepoViewData{
 		Repo: *repoInfo,
-		Jobs: jobs,
+		Tab:  viewmodel.ParseGitRepoTab(r.URL.Query().Get("tab")),
+	}
+
+	if data.Tab == viewmodel.GitRepoTabWebhooks {
+		data.Deliveries, err = h.dataFactory.GetWebhookDeliveries(ctx, opts.Namespace, name)
+		if err != nil {
+			frontendLog.Error(err, "Failed to fetch webhook deliveries", "repo", name, "namespace", opts.Namespace)
+			http.Error(w, "Failed to fetch webhook deliveries", http.StatusInternalServerError)
+
+			return
+		}
+	} else {
+		data.Jobs, err = h.dataFactory.GetJobsForRepo(ctx, name, opts)
+		if err != nil {
+			frontendLog.Error(err, "Failed to fetch jobs", "repo", name, "namespace", opts.Namespace)
+			http.Error(w, "Failed to fetch jobs", http.StatusInternalServerError)
+
+			return
+		}
 	}
 
 	h.render(w, r, "Repository · "+repoInfo.FullName, view.GitRepoView(r.Context(), data))
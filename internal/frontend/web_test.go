@@ -63,6 +63,14 @@ var _ = Describe("WebHandler", func() {
 				},
 				Status: renovatev1beta1.GitRepoStatus{
 					WebhookID: "12345",
+					WebhookDeliveries: []renovatev1beta1.WebhookDelivery{{
+						ID:       "test-delivery",
+						Event:    "issues",
+						Sender:   "test-user",
+						Decision: renovatev1beta1.WebhookDeliveryDecision_IGNORED,
+						Reason:   "renovate checkbox not checked",
+						Time:     metav1.NewTime(time.Now()),
+					}},
 				},
 			},
 			&renovatev1beta1.Runner{
@@ -427,6 +435,21 @@ var _ = Describe("WebHandler", func() {
 			Expect(w.Header().Get("Content-Type")).To(Equal("text/html"))
 		})
 
+		It("should render the webhook deliveries tab", func() {
+			req := httptest.NewRequest(http.MethodGet, "/gitrepo?namespace=test-namespace&name=test-repo&tab=webhooks", nil)
+			req.Header.Set("HX-Request", "true")
+
+			w := httptest.NewRecorder()
+
+			handler.HandleGitRepoView(w, req)
+
+			Expect(w.Code).To(Equal(http.StatusOK))
+			Expect(w.Body.String()).To(ContainSubstring("test-delivery"))
+			Expect(w.Body.String()).To(ContainSubstring("renovate checkbox not checked"))
+			Expect(w.Body.String()).To(ContainSubstring("webhook.ignored"))
+			Expect(w.Body.String()).NotTo(ContainSubstring("job-list-container"))
+		})
+
 		It("should return not found for non-existent repo", func() {
 			req := httptest.NewRequest(http.MethodGet, "/gitrepo?namespace=test-namespace&name=nonexistent", nil)
 			w := httptest.NewRecorder()
@@ -246,6 +246,10 @@ func (r *recorder) RecordWebhookPayloadDecodeFailure(provider string) {
 	r.webhookPayloadDecodeFailures.WithLabelValues(provider).Inc()
 }
 
+func (r *recorder) RecordWebhookCoalesced(provider string) {
+	r.webhookCoalesced.WithLabelValues(provider).Inc()
+}
+
 func (r *recorder) RecordSecretResolutionError(errorType string) {
 	r.secretResolutionErrors.WithLabelValues(errorType).Inc()
 }
@@ -57,6 +57,7 @@ type Recorder interface {
 	RecordWebhookSignatureFailure(provider string)
 	RecordWebhookAuthFailure(provider, errorType string)
 	RecordWebhookPayloadDecodeFailure(provider string)
+	RecordWebhookCoalesced(provider string)
 
 	// --- Secret resolution ---
 	RecordSecretResolutionError(errorType string)
@@ -103,6 +104,7 @@ type recorder struct {
 	webhookSignatureFailures     *prometheus.CounterVec
 	webhookAuthFailures          *prometheus.CounterVec
 	webhookPayloadDecodeFailures *prometheus.CounterVec
+	webhookCoalesced             *prometheus.CounterVec
 
 	// Secret resolution
 	secretResolutionErrors *prometheus.CounterVec
@@ -339,6 +341,14 @@ func New(reg prometheus.Registerer, gatherer prometheus.Gatherer, cardinalityCap
 		[]string{"provider"},
 	)
 
+	webhookCoalesced := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "renovate_operator_webhook_coalesced_events_total",
+			Help: "Total number of webhook events coalesced into a pending debounced run.",
+		},
+		[]string{"provider"},
+	)
+
 	secretResolutionErrors := prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "renovate_operator_secret_resolution_errors_total",
@@ -376,7 +386,7 @@ func New(reg prometheus.Registerer, gatherer prometheus.Gatherer, cardinalityCap
 		discoveryJobs, discoveryJobFailures, discoveryRepoCount,
 		webhookRequests, webhookSignatureFailures,
 		webhookAuthFailures, webhookPayloadDecodeFailures,
-		secretResolutionErrors,
+		webhookCoalesced, secretResolutionErrors,
 		reconcileDur, seriesDropped,
 	)
 
@@ -408,6 +418,7 @@ func New(reg prometheus.Registerer, gatherer prometheus.Gatherer, cardinalityCap
 		webhookSignatureFailures:     webhookSignatureFailures,
 		webhookAuthFailures:          webhookAuthFailures,
 		webhookPayloadDecodeFailures: webhookPayloadDecodeFailures,
+		webhookCoalesced:             webhookCoalesced,
 		secretResolutionErrors:       secretResolutionErrors,
 		reconcileDur:                 reconcileDur,
 		seriesDropped:                seriesDropped,
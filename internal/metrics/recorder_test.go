@@ -486,6 +486,21 @@ var _ = Describe("Recorder", func() {
 			Expect(err).NotTo(HaveOccurred())
 		})
 
+		It("should record coalesced webhook events", func() {
+			rec.RecordWebhookCoalesced("github")
+			rec.RecordWebhookCoalesced("github")
+
+			//nolint:lll
+			expected := `
+				# HELP renovate_operator_webhook_coalesced_events_total Total number of webhook events coalesced into a pending debounced run.
+				# TYPE renovate_operator_webhook_coalesced_events_total counter
+				renovate_operator_webhook_coalesced_events_total{provider="github"} 2
+			`
+
+			err := testutil.CollectAndCompare(recImpl.webhookCoalesced, strings.NewReader(expected))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
 		It("should record secret resolution error", func() {
 			rec.RecordSecretResolutionError("not_found")
 			rec.RecordSecretResolutionError("key_missing")
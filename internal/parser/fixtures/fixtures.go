@@ -97,6 +97,11 @@ var RepoFinishedDone string
 //go:embed PackageFileUpdates.json
 var PackageFileUpdates string
 
+// ContainerImages contains logs with container image dependencies.
+//
+//go:embed ContainerImages.json
+var ContainerImages string
+
 // VulnerabilityFixes contains logs with vulnerability fix warnings.
 //
 //go:embed VulnerabilityFixes.json
@@ -109,3 +114,8 @@ var VulnerabilityFixes string
 //
 //go:embed ConfigWithNestedObject.json
 var ConfigWithNestedObject string
+
+// BatchedRepositories contains logs of a job processing several repositories.
+//
+//go:embed BatchedRepositories.json
+var BatchedRepositories string
@@ -7,6 +7,7 @@ import (
 	"html"
 	"io"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -26,6 +27,8 @@ const (
 
 	regexMatchLen = 2
 
+	dockerDatasource = "docker"
+
 	actionOrderAutomerged    = 0
 	actionOrderCreated       = 1
 	actionOrderUpdated       = 2
@@ -43,6 +46,10 @@ const (
 	PRActionUnchanged     PRAction = "unchanged"
 )
 
+// MsgPackageFilesWithUpdates is the message of the Renovate log entry listing
+// the dependencies of the package files. Renovate logs it at the debug level.
+const MsgPackageFilesWithUpdates = "packageFiles with updates"
+
 type LogLevel int
 
 const (
@@ -89,6 +96,9 @@ type DependencySummary struct {
 	OutdatedDeps            int
 	UpdatesByType           map[string]int
 	VulnerabilityFixesAvail int
+	// Images lists the distinct container images found as dependencies,
+	// ordered by name.
+	Images []string
 }
 
 type BranchResultSummary struct {
@@ -115,6 +125,7 @@ type renovateLogEntry struct {
 	Level        int              `json:"level"`
 	Msg          string           `json:"msg"`
 	Time         string           `json:"time"`
+	Repository   string           `json:"repository,omitempty"`
 	Config       json.RawMessage  `json:"config,omitempty"`
 	BranchesInfo []branchInfoItem `json:"branchesInformation,omitempty"`
 }
@@ -166,8 +177,11 @@ type packageFileData struct {
 }
 
 type dependency struct {
-	Updates  []update  `json:"updates"`
-	Warnings []warning `json:"warnings"`
+	DepName     string    `json:"depName"`
+	PackageName string    `json:"packageName"`
+	Datasource  string    `json:"datasource"`
+	Updates     []update  `json:"updates"`
+	Warnings    []warning `json:"warnings"`
 }
 
 type update struct {
@@ -209,6 +223,15 @@ var (
 		"not-created":     true,
 	}
 
+	// transientResults are the repository results of Renovate caused by
+	// unavailable hosts, rate limits or concurrent changes of the repository.
+	transientResults = map[string]bool{
+		"external-host-error": true,
+		"rate-limit-exceeded": true,
+		"repository-changed":  true,
+		"temporary-error":     true,
+	}
+
 	actionOrder = map[PRAction]int{
 		PRActionAutomerged:    actionOrderAutomerged,
 		PRActionCreated:       actionOrderCreated,
@@ -244,6 +267,68 @@ type ParseLogsResult struct {
 	PRActivity    *PRActivity
 	Dependencies  *DependencySummary
 	BranchResults *BranchResultSummary
+	// Finished reports whether Renovate logged the end of a repository run.
+	Finished bool
+	// Result is the outcome Renovate logged at the end of a repository run.
+	Result string
+}
+
+// IsTransientFailure reports whether the repository run failed with an error
+// that is likely to be resolved by a later run.
+func (r *ParseLogsResult) IsTransientFailure() bool {
+	return transientResults[r.Result]
+}
+
+// logAggregator accumulates the entries of a Renovate NDJSON log.
+type logAggregator struct {
+	branchMap     map[string]*PRDetail
+	result        *ParseResult
+	depSummary    *DependencySummary
+	branchResults *BranchResultSummary
+	warnCount     int
+	errorCount    int
+}
+
+func newLogAggregator() *logAggregator {
+	return &logAggregator{
+		branchMap: make(map[string]*PRDetail),
+		result:    &ParseResult{},
+		depSummary: &DependencySummary{
+			UpdatesByType: make(map[string]int),
+		},
+		branchResults: &BranchResultSummary{
+			ResultsByType: make(map[string]int),
+		},
+	}
+}
+
+func (a *logAggregator) add(line string, entry renovateLogEntry) {
+	if entry.Level >= levelWarn {
+		a.result.HasIssues = true
+
+		if entry.Level >= levelError {
+			a.errorCount++
+		} else {
+			a.warnCount++
+		}
+	}
+
+	processLogEntry(line, entry, a.branchMap, a.result, a.depSummary, a.branchResults)
+}
+
+func (a *logAggregator) build() *ParseLogsResult {
+	slices.Sort(a.depSummary.Images)
+
+	return &ParseLogsResult{
+		HasIssues:     a.result.HasIssues,
+		WarnCount:     a.warnCount,
+		ErrorCount:    a.errorCount,
+		PRActivity:    buildPRActivity(a.branchMap),
+		Dependencies:  a.depSummary,
+		BranchResults: a.branchResults,
+		Finished:      a.result.RenovateResultStatus != "",
+		Result:        a.result.RenovateResultStatus,
+	}
 }
 
 // ParseLogs streams a Renovate NDJSON log from r and returns the aggregated
@@ -251,6 +336,50 @@ type ParseLogsResult struct {
 // scanner pass feeds both the metrics and UI consumers so they cannot drift.
 // The reader is consumed up to maxBytes; pass a negative value for no cap.
 func ParseLogs(r io.Reader, maxBytes int64) (*ParseLogsResult, error) {
+	agg := newLogAggregator()
+
+	if err := scanLogs(r, maxBytes, func(line string, entry renovateLogEntry) {
+		agg.add(line, entry)
+	}); err != nil {
+		return nil, err
+	}
+
+	return agg.build(), nil
+}
+
+// ParseLogsByRepository streams the Renovate NDJSON log of a Job processing
+// several repositories from r and returns the aggregated result per repository.
+// Entries are attributed by the repository field Renovate adds while processing
+// a repository, entries logged outside of a repository run are skipped.
+func ParseLogsByRepository(r io.Reader, maxBytes int64) (map[string]*ParseLogsResult, error) {
+	aggs := make(map[string]*logAggregator)
+
+	if err := scanLogs(r, maxBytes, func(line string, entry renovateLogEntry) {
+		if entry.Repository == "" {
+			return
+		}
+
+		agg, ok := aggs[entry.Repository]
+		if !ok {
+			agg = newLogAggregator()
+			aggs[entry.Repository] = agg
+		}
+
+		agg.add(line, entry)
+	}); err != nil {
+		return nil, err
+	}
+
+	results := make(map[string]*ParseLogsResult, len(aggs))
+	for repo, agg := range aggs {
+		results[repo] = agg.build()
+	}
+
+	return results, nil
+}
+
+// scanLogs calls fn for every valid entry of a Renovate NDJSON log.
+func scanLogs(r io.Reader, maxBytes int64, fn func(line string, entry renovateLogEntry)) error {
 	reader := r
 	if maxBytes >= 0 {
 		reader = io.LimitReader(r, maxBytes)
@@ -259,17 +388,6 @@ func ParseLogs(r io.Reader, maxBytes int64) (*ParseLogsResult, error) {
 	scanner := bufio.NewScanner(reader)
 	scanner.Buffer(make([]byte, scannerInitialBuf), scannerMaxBuf)
 
-	branchMap := make(map[string]*PRDetail)
-	result := &ParseResult{}
-	depSummary := &DependencySummary{
-		UpdatesByType: make(map[string]int),
-	}
-	branchResults := &BranchResultSummary{
-		ResultsByType: make(map[string]int),
-	}
-
-	var warnCount, errorCount int
-
 	for scanner.Scan() {
 		line := scanner.Text()
 		if line == "" {
@@ -281,33 +399,14 @@ func ParseLogs(r io.Reader, maxBytes int64) (*ParseLogsResult, error) {
 			continue
 		}
 
-		if entry.Level >= levelWarn {
-			result.HasIssues = true
-
-			if entry.Level >= levelError {
-				errorCount++
-			} else {
-				warnCount++
-			}
-		}
-
-		processLogEntry(line, entry, branchMap, result, depSummary, branchResults)
+		fn(line, entry)
 	}
 
 	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("scan renovate logs: %w", err)
+		return fmt.Errorf("scan renovate logs: %w", err)
 	}
 
-	activity := buildPRActivity(branchMap)
-
-	return &ParseLogsResult{
-		HasIssues:     result.HasIssues,
-		WarnCount:     warnCount,
-		ErrorCount:    errorCount,
-		PRActivity:    activity,
-		Dependencies:  depSummary,
-		BranchResults: branchResults,
-	}, nil
+	return nil
 }
 
 func ParseRenovateLogs(logs string) *ParseResult {
@@ -476,7 +575,7 @@ func processLogEntry(
 	case entry.Msg == "branches info extended":
 		processBranchesInfo(entry, branchMap, branchResults)
 
-	case entry.Msg == "packageFiles with updates":
+	case entry.Msg == MsgPackageFilesWithUpdates:
 		if depSummary != nil {
 			processPackageFileUpdates(line, depSummary)
 		}
@@ -598,6 +697,8 @@ func processPackageFileUpdates(line string, depSummary *DependencySummary) {
 func processDependency(dep dependency, depSummary *DependencySummary) {
 	depSummary.TotalDeps++
 
+	processImage(dep, depSummary)
+
 	if len(dep.Updates) > 0 {
 		depSummary.OutdatedDeps++
 	}
@@ -606,6 +707,23 @@ func processDependency(dep dependency, depSummary *DependencySummary) {
 	processUpdates(dep.Updates, depSummary)
 }
 
+// processImage records the image of a container image dependency. Renovate
+// sets the package name if the image is looked up under a different name.
+func processImage(dep dependency, depSummary *DependencySummary) {
+	if dep.Datasource != dockerDatasource {
+		return
+	}
+
+	image := dep.PackageName
+	if image == "" {
+		image = dep.DepName
+	}
+
+	if image != "" && !slices.Contains(depSummary.Images, image) {
+		depSummary.Images = append(depSummary.Images, image)
+	}
+}
+
 func processWarnings(warnings []warning, depSummary *DependencySummary) {
 	for _, w := range warnings {
 		if containsFold(w.Message, "vulnerability") {
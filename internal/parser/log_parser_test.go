@@ -327,6 +327,14 @@ var _ = Describe("LogParser", func() {
 			Expect(res.Dependencies.VulnerabilityFixesAvail).To(Equal(0))
 		})
 
+		It("extracts container images from package file updates", func() {
+			res, err := ParseLogs(strings.NewReader(fixtures.ContainerImages), -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).NotTo(BeNil())
+			Expect(res.Dependencies.TotalDeps).To(Equal(4))
+			Expect(res.Dependencies.Images).To(Equal([]string{"harbor.example.com/library/base", "node"}))
+		})
+
 		It("extracts vulnerability fixes from package file updates", func() {
 			res, err := ParseLogs(strings.NewReader(fixtures.VulnerabilityFixes), -1)
 			Expect(err).NotTo(HaveOccurred())
@@ -358,4 +366,41 @@ var _ = Describe("LogParser", func() {
 			Expect(res.PRActivity.NeedsApproval).To(Equal(3))
 		})
 	})
+
+	Describe("ParseLogsByRepository", func() {
+		It("splits the results per repository", func() {
+			res, err := ParseLogsByRepository(strings.NewReader(fixtures.BatchedRepositories), -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(HaveLen(2))
+
+			Expect(res).To(HaveKey("org/repo-a"))
+			Expect(res["org/repo-a"].Finished).To(BeTrue())
+			Expect(res["org/repo-a"].HasIssues).To(BeFalse())
+			Expect(res["org/repo-a"].PRActivity.NeedsApproval).To(Equal(1))
+
+			Expect(res).To(HaveKey("org/repo-b"))
+			Expect(res["org/repo-b"].Finished).To(BeFalse())
+			Expect(res["org/repo-b"].WarnCount).To(Equal(1))
+			Expect(res["org/repo-b"].ErrorCount).To(Equal(1))
+		})
+
+		It("classifies transient failures", func() {
+			logs := strings.Join([]string{
+				`{"level":30,"msg":"Repository finished","repository":"org/repo-a","result":"external-host-error"}`,
+				`{"level":30,"msg":"Repository finished","repository":"org/repo-b","result":"unknown-error"}`,
+			}, "\n")
+
+			res, err := ParseLogsByRepository(strings.NewReader(logs), -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res["org/repo-a"].Result).To(Equal("external-host-error"))
+			Expect(res["org/repo-a"].IsTransientFailure()).To(BeTrue())
+			Expect(res["org/repo-b"].IsTransientFailure()).To(BeFalse())
+		})
+
+		It("returns an empty result for logs without repository entries", func() {
+			res, err := ParseLogsByRepository(strings.NewReader(fixtures.WarnAndError), -1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res).To(BeEmpty())
+		})
+	})
 })
@@ -0,0 +1,457 @@
+package azure
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+)
+
+const (
+	apiVersion    = "7.1"
+	httpTimeout   = 30 * time.Second
+	webhookUser   = "renovate-operator"
+	webhookIDSep  = ","
+	publisherID   = "tfs"
+	consumerID    = "webHooks"
+	consumerActID = "httpRequest"
+)
+
+var (
+	errInvalidRepoName   = errors.New("invalid repository name format")
+	errMissingPermission = errors.New("edit subscriptions permission required to manage service hooks")
+	errUnexpectedStatus  = errors.New("unexpected status code")
+	errEmptyIdentity     = errors.New("empty identity returned by connection data")
+)
+
+// webhookEvents are the service hook event types subscribed for every repository.
+// Azure DevOps binds a subscription to a single event type, so one subscription
+// is managed per entry.
+var webhookEvents = []string{"git.push", "git.pullrequest.merged"}
+
+// Provider manages Azure DevOps Repos and service hook subscriptions through the
+// REST API of a single organization. Repository names use the `project/repo`
+// format expected by Renovate.
+type Provider struct {
+	client  *http.Client
+	baseURL string
+	token   string
+}
+
+var _ provider.ProviderManager = (*Provider)(nil)
+
+// NewProvider creates an Azure DevOps provider authenticated with a personal access token.
+// The endpoint is the organization URL, e.g. `https://dev.azure.com/<org>/`.
+func NewProvider(_ context.Context, endpoint, token string) (*Provider, error) {
+	baseURL := strings.TrimRight(strings.TrimSpace(endpoint), "/")
+
+	if _, err := url.ParseRequestURI(baseURL); err != nil {
+		return nil, fmt.Errorf("failed to create azure devops client: %w", err)
+	}
+
+	return &Provider{
+		client:  &http.Client{Timeout: httpTimeout},
+		baseURL: baseURL,
+		token:   token,
+	}, nil
+}
+
+type connectionData struct {
+	AuthenticatedUser struct {
+		ProviderDisplayName string `json:"providerDisplayName"`
+		Properties          struct {
+			Account struct {
+				Value string `json:"$value"` //nolint:tagliatelle // Azure DevOps property bag.
+			} `json:"Account"` //nolint:tagliatelle // Azure DevOps property bag.
+		} `json:"properties"`
+	} `json:"authenticatedUser"`
+}
+
+// GetIdentity returns the account name (usually the UPN) of the token owner,
+// falling back to the display name for service principals.
+func (p *Provider) GetIdentity() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
+
+	data := &connectionData{}
+
+	status, err := p.getJSON(ctx, "/_apis/connectionData", data)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch current user: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch current user: %w: %d", errUnexpectedStatus, status)
+	}
+
+	identity := data.AuthenticatedUser.Properties.Account.Value
+	if identity == "" {
+		identity = data.AuthenticatedUser.ProviderDisplayName
+	}
+
+	if identity == "" {
+		return "", errEmptyIdentity
+	}
+
+	return identity, nil
+}
+
+type publisherInputs struct {
+	ProjectID  string `json:"projectId"`
+	Repository string `json:"repository"`
+}
+
+type consumerInputs struct {
+	URL               string `json:"url"`
+	BasicAuthUsername string `json:"basicAuthUsername,omitempty"`
+	BasicAuthPassword string `json:"basicAuthPassword,omitempty"`
+}
+
+type subscription struct {
+	ID               string          `json:"id,omitempty"`
+	PublisherID      string          `json:"publisherId"`
+	EventType        string          `json:"eventType"`
+	ResourceVersion  string          `json:"resourceVersion,omitempty"`
+	ConsumerID       string          `json:"consumerId"`
+	ConsumerActionID string          `json:"consumerActionId"`
+	PublisherInputs  publisherInputs `json:"publisherInputs"`
+	ConsumerInputs   consumerInputs  `json:"consumerInputs"`
+}
+
+type listResponse[T any] struct {
+	Value []T `json:"value"`
+}
+
+type inputFilterCondition struct {
+	InputID    string `json:"inputId"`
+	Operator   string `json:"operator"`
+	InputValue string `json:"inputValue"`
+}
+
+type inputFilter struct {
+	Conditions []inputFilterCondition `json:"conditions"`
+}
+
+// subscriptionsQuery is the request and response of the subscriptions query API.
+type subscriptionsQuery struct {
+	PublisherID           string         `json:"publisherId"`
+	ConsumerID            string         `json:"consumerId"`
+	PublisherInputFilters []inputFilter  `json:"publisherInputFilters"`
+	Results               []subscription `json:"results,omitempty"`
+}
+
+// EnsureWebhook ensures one service hook subscription per event in webhookEvents.
+// The returned ID is the comma-separated list of subscription IDs. The webhook
+// secret is sent as basic auth password, as service hooks do not sign payloads.
+func (p *Provider) EnsureWebhook(ctx context.Context, repoName, webhookURL, secret string) (string, error) {
+	repo, err := p.getRepo(ctx, repoName)
+	if err != nil {
+		return "", err
+	}
+
+	subs, err := p.querySubscriptions(ctx, repo.ID)
+	if err != nil {
+		return "", err
+	}
+
+	ids := make([]string, 0, len(webhookEvents))
+
+	for _, eventType := range webhookEvents {
+		desiredSub := &subscription{
+			PublisherID:      publisherID,
+			EventType:        eventType,
+			ResourceVersion:  "1.0",
+			ConsumerID:       consumerID,
+			ConsumerActionID: consumerActID,
+			PublisherInputs:  publisherInputs{ProjectID: repo.Project.ID, Repository: repo.ID},
+			ConsumerInputs: consumerInputs{
+				URL:               webhookURL,
+				BasicAuthUsername: webhookUser,
+				BasicAuthPassword: secret,
+			},
+		}
+
+		id, err := p.ensureSubscription(ctx, findSubscription(subs, desiredSub), desiredSub)
+		if err != nil {
+			return "", err
+		}
+
+		ids = append(ids, id)
+	}
+
+	return strings.Join(ids, webhookIDSep), nil
+}
+
+// querySubscriptions returns the webhook subscriptions of a repository. The
+// query is filtered by the repository on the server, as listing all
+// subscriptions would return the service hooks of the whole organization.
+func (p *Provider) querySubscriptions(ctx context.Context, repoID string) ([]subscription, error) {
+	query := &subscriptionsQuery{
+		PublisherID: publisherID,
+		ConsumerID:  consumerID,
+		PublisherInputFilters: []inputFilter{{
+			Conditions: []inputFilterCondition{{InputID: "repository", Operator: "equals", InputValue: repoID}},
+		}},
+	}
+
+	result := &subscriptionsQuery{}
+
+	status, err := p.sendJSON(ctx, http.MethodPost, "/_apis/hooks/subscriptionsquery", query, result)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list service hooks: %w", err)
+	}
+
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return nil, errMissingPermission
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to list service hooks: %w: %d", errUnexpectedStatus, status)
+	}
+
+	return result.Results, nil
+}
+
+func findSubscription(subs []subscription, desired *subscription) *subscription {
+	for i := range subs {
+		if subs[i].EventType == desired.EventType &&
+			subs[i].ConsumerInputs.URL == desired.ConsumerInputs.URL &&
+			strings.EqualFold(subs[i].PublisherInputs.Repository, desired.PublisherInputs.Repository) {
+			return &subs[i]
+		}
+	}
+
+	return nil
+}
+
+// ensureSubscription always updates an existing subscription, because the API
+// masks the stored basic auth password and a rotated secret cannot be detected.
+func (p *Provider) ensureSubscription(ctx context.Context, existing, desired *subscription) (string, error) {
+	if existing != nil {
+		status, err := p.sendJSON(ctx, http.MethodPut, "/_apis/hooks/subscriptions/"+url.PathEscape(existing.ID),
+			desired, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to update existing service hook: %w", err)
+		}
+
+		if status != http.StatusOK {
+			return "", fmt.Errorf("failed to update existing service hook: %w: %d", errUnexpectedStatus, status)
+		}
+
+		return existing.ID, nil
+	}
+
+	created := &subscription{}
+
+	status, err := p.sendJSON(ctx, http.MethodPost, "/_apis/hooks/subscriptions", desired, created)
+	if err != nil {
+		return "", fmt.Errorf("failed to create service hook: %w", err)
+	}
+
+	if status == http.StatusUnauthorized || status == http.StatusForbidden {
+		return "", errMissingPermission
+	}
+
+	if status != http.StatusOK && status != http.StatusCreated {
+		return "", fmt.Errorf("failed to create service hook: %w: %d", errUnexpectedStatus, status)
+	}
+
+	return created.ID, nil
+}
+
+func (p *Provider) DeleteWebhook(ctx context.Context, repoName, webhookID string) error {
+	if webhookID == "" {
+		return nil
+	}
+
+	if _, _, err := parseRepoName(repoName); err != nil {
+		return err
+	}
+
+	for id := range strings.SplitSeq(webhookID, webhookIDSep) {
+		if id == "" {
+			continue
+		}
+
+		resp, err := p.do(ctx, http.MethodDelete, "/_apis/hooks/subscriptions/"+url.PathEscape(id), nil)
+		if err != nil {
+			return fmt.Errorf("failed to delete service hook %s: %w", id, err)
+		}
+
+		_, _ = io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
+
+		switch resp.StatusCode {
+		case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		default:
+			return fmt.Errorf("failed to delete service hook %s: %w: %d", id, errUnexpectedStatus, resp.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+type repository struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	WebURL     string `json:"webUrl"`
+	IsFork     bool   `json:"isFork"`
+	IsDisabled bool   `json:"isDisabled"`
+	Project    struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"project"`
+}
+
+func (p *Provider) getRepo(ctx context.Context, repoName string) (*repository, error) {
+	project, repoSlug, err := parseRepoName(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	repo := &repository{}
+
+	path := fmt.Sprintf("/%s/_apis/git/repositories/%s", url.PathEscape(project), url.PathEscape(repoSlug))
+
+	status, err := p.getJSON(ctx, path, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch repository: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch repository: %w: %d", errUnexpectedStatus, status)
+	}
+
+	return repo, nil
+}
+
+func (p *Provider) RepoURL(ctx context.Context, repoName string) (string, error) {
+	repo, err := p.getRepo(ctx, repoName)
+	if err != nil {
+		return "", err
+	}
+
+	if repo.WebURL != "" {
+		return repo.WebURL, nil
+	}
+
+	project, repoSlug, _ := parseRepoName(repoName)
+
+	return fmt.Sprintf("%s/%s/_git/%s", p.baseURL, url.PathEscape(project), url.PathEscape(repoSlug)), nil
+}
+
+// ListRepos returns the repositories of all projects in the organization.
+// Azure DevOps has no repository topics, so a non-empty opts.Topics matches
+// no repository. Disabled repositories are always excluded.
+func (p *Provider) ListRepos(ctx context.Context, opts provider.ListReposOptions) ([]provider.Repo, error) {
+	if len(opts.Topics) > 0 {
+		return nil, nil
+	}
+
+	repos := &listResponse[repository]{}
+
+	status, err := p.getJSON(ctx, "/_apis/git/repositories", repos)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list repositories: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("failed to list repositories: %w: %d", errUnexpectedStatus, status)
+	}
+
+	var out []provider.Repo
+
+	for _, repo := range repos.Value {
+		if repo.Project.Name == "" || repo.Name == "" || repo.IsDisabled {
+			continue
+		}
+
+		if opts.SkipForks && repo.IsFork {
+			continue
+		}
+
+		out = append(out, provider.Repo{
+			ID:     repo.ID,
+			Name:   repo.Project.Name + "/" + repo.Name,
+			IsFork: repo.IsFork,
+		})
+	}
+
+	return out, nil
+}
+
+func (p *Provider) do(ctx context.Context, method, path string, body io.Reader) (*http.Response, error) {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path+separator+"api-version="+apiVersion, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth("", p.token)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return p.client.Do(req)
+}
+
+// getJSON performs a GET request and decodes a successful JSON response into out.
+// The HTTP status is returned so callers can map platform-specific failures.
+func (p *Provider) getJSON(ctx context.Context, path string, out any) (int, error) {
+	return p.sendJSON(ctx, http.MethodGet, path, nil, out)
+}
+
+func (p *Provider) sendJSON(ctx context.Context, method, path string, in, out any) (int, error) {
+	var body io.Reader
+
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	resp, err := p.do(ctx, method, path, body)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices || out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return resp.StatusCode, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func parseRepoName(fullRepo string) (string, string, error) {
+	project, repo, found := strings.Cut(fullRepo, "/")
+
+	if !found || project == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", fmt.Errorf("%w: %s", errInvalidRepoName, fullRepo)
+	}
+
+	return project, repo, nil
+}
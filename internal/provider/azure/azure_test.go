@@ -0,0 +1,259 @@
+package azure
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+)
+
+const repoJSON = `{
+	"id":"repo-id","name":"repo","webUrl":"https://dev.azure.com/org/Project/_git/repo",
+	"project":{"id":"project-id","name":"Project"}
+}`
+
+var _ = Describe("Azure DevOps Provider", func() {
+	DescribeTable(
+		"validates repository names",
+		func(repoName string, valid bool) {
+			project, repo, err := parseRepoName(repoName)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(project + "/" + repo).To(Equal(repoName))
+
+				return
+			}
+
+			Expect(err).To(MatchError(ContainSubstring("invalid repository name format")))
+		},
+		Entry("project and repository", "Project/repo", true),
+		Entry("names with spaces", "My Project/my repo", true),
+		Entry("missing repository", "Project", false),
+		Entry("empty project", "/repo", false),
+		Entry("too many segments", "Project/repo/extra", false),
+	)
+
+	Context("API interactions", func() {
+		var (
+			ctx     context.Context
+			server  *httptest.Server
+			p       *Provider
+			handler http.HandlerFunc
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				user, password, ok := r.BasicAuth()
+				Expect(ok).To(BeTrue())
+				Expect(user).To(BeEmpty())
+				Expect(password).To(Equal("test-token"))
+				Expect(r.URL.Query().Get("api-version")).To(Equal(apiVersion))
+				handler(w, r)
+			}))
+
+			var err error
+
+			p, err = NewProvider(ctx, server.URL+"/", "test-token")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("gets the authenticated account name", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/_apis/connectionData"))
+
+				_, _ = w.Write([]byte(`{"authenticatedUser":{"providerDisplayName":"Renovate Bot",` +
+					`"properties":{"Account":{"$type":"System.String","$value":"renovate@example.com"}}}}`))
+			}
+
+			identity, err := p.GetIdentity()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identity).To(Equal("renovate@example.com"))
+		})
+
+		It("falls back to the display name", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"authenticatedUser":{"providerDisplayName":"Renovate Bot"}}`))
+			}
+
+			identity, err := p.GetIdentity()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identity).To(Equal("Renovate Bot"))
+		})
+
+		It("rejects an anonymous identity", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"authenticatedUser":{}}`))
+			}
+
+			_, err := p.GetIdentity()
+			Expect(err).To(MatchError(errEmptyIdentity))
+		})
+
+		It("returns the repository web URL", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.EscapedPath()).To(Equal("/My%20Project/_apis/git/repositories/repo"))
+
+				_, _ = w.Write([]byte(repoJSON))
+			}
+
+			repoURL, err := p.RepoURL(ctx, "My Project/repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repoURL).To(Equal("https://dev.azure.com/org/Project/_git/repo"))
+		})
+
+		It("lists and filters repositories", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/_apis/git/repositories"))
+
+				_, _ = w.Write([]byte(`{"count":4,"value":[
+					{"id":"repo-1","name":"first","project":{"name":"Project"}},
+					{"name":"fork","isFork":true,"project":{"name":"Project"}},
+					{"name":"disabled","isDisabled":true,"project":{"name":"Project"}},
+					{"id":"repo-4","name":"second","project":{"name":"Other"}}
+				]}`))
+			}
+
+			repos, err := p.ListRepos(ctx, provider.ListReposOptions{SkipForks: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repos).To(Equal([]provider.Repo{
+				{ID: "repo-1", Name: "Project/first", IsFork: false},
+				{ID: "repo-4", Name: "Other/second", IsFork: false},
+			}))
+		})
+
+		It("matches no repositories when topics are requested", func() {
+			repos, err := p.ListRepos(ctx, provider.ListReposOptions{Topics: []string{"renovate"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repos).To(BeEmpty())
+		})
+
+		It("reports repository list failures", func() {
+			_, err := p.ListRepos(ctx, provider.ListReposOptions{})
+			Expect(err).To(MatchError(ContainSubstring("failed to list repositories")))
+		})
+
+		It("rejects service hook management without permission", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/Project/_apis/git/repositories/repo" {
+					_, _ = w.Write([]byte(repoJSON))
+
+					return
+				}
+
+				w.WriteHeader(http.StatusForbidden)
+			}
+
+			_, err := p.EnsureWebhook(ctx, "Project/repo", "https://operator.example/hook", "secret")
+			Expect(err).To(MatchError(errMissingPermission))
+		})
+
+		It("creates missing subscriptions and updates existing ones", func() {
+			var created []string
+
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.URL.Path == "/Project/_apis/git/repositories/repo":
+					_, _ = w.Write([]byte(repoJSON))
+				case r.URL.Path == "/_apis/hooks/subscriptionsquery":
+					Expect(r.Method).To(Equal(http.MethodPost))
+
+					query := &subscriptionsQuery{}
+					Expect(json.NewDecoder(r.Body).Decode(query)).To(Succeed())
+					Expect(query.PublisherID).To(Equal(publisherID))
+					Expect(query.ConsumerID).To(Equal(consumerID))
+					Expect(query.PublisherInputFilters).To(Equal([]inputFilter{{
+						Conditions: []inputFilterCondition{{InputID: "repository", Operator: "equals", InputValue: "repo-id"}},
+					}}))
+
+					_, _ = w.Write([]byte(`{"results":[
+						{"id":"other","eventType":"git.push",
+						 "publisherInputs":{"repository":"other-repo"},
+						 "consumerInputs":{"url":"https://operator.example/hook"}},
+						{"id":"push-id","eventType":"git.push",
+						 "publisherInputs":{"projectId":"project-id","repository":"REPO-ID"},
+						 "consumerInputs":{"url":"https://operator.example/hook","basicAuthPassword":"********"}}
+					]}`))
+				case r.Method == http.MethodPut:
+					Expect(r.URL.Path).To(Equal("/_apis/hooks/subscriptions/push-id"))
+					assertSubscriptionPayload(r, "git.push", "https://operator.example/hook", "rotated-secret")
+					_, _ = w.Write([]byte(`{"id":"push-id"}`))
+				case r.Method == http.MethodPost:
+					Expect(r.URL.Path).To(Equal("/_apis/hooks/subscriptions"))
+					created = append(created,
+						assertSubscriptionPayload(r, "git.pullrequest.merged", "https://operator.example/hook", "rotated-secret"))
+					_, _ = w.Write([]byte(`{"id":"pr-id"}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}
+
+			hookID, err := p.EnsureWebhook(ctx, "Project/repo", "https://operator.example/hook", "rotated-secret")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hookID).To(Equal("push-id,pr-id"))
+			Expect(created).To(ConsistOf("git.pullrequest.merged"))
+		})
+
+		It("deletes all subscriptions of a webhook", func() {
+			var deleted []string
+
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodDelete))
+				deleted = append(deleted, r.URL.Path)
+
+				if r.URL.Path == "/_apis/hooks/subscriptions/pr-id" {
+					w.WriteHeader(http.StatusNotFound)
+
+					return
+				}
+
+				w.WriteHeader(http.StatusNoContent)
+			}
+
+			Expect(p.DeleteWebhook(ctx, "Project/repo", "push-id,pr-id")).To(Succeed())
+			Expect(deleted).To(Equal([]string{"/_apis/hooks/subscriptions/push-id", "/_apis/hooks/subscriptions/pr-id"}))
+		})
+
+		It("ignores an empty webhook ID", func() {
+			Expect(p.DeleteWebhook(ctx, "Project/repo", "")).To(Succeed())
+		})
+
+		It("reports non-404 deletion errors", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+
+			err := p.DeleteWebhook(ctx, "Project/repo", "push-id")
+			Expect(err).To(MatchError(ContainSubstring("failed to delete service hook push-id")))
+		})
+	})
+})
+
+func assertSubscriptionPayload(r *http.Request, eventType, webhookURL, secret string) string {
+	Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+
+	payload := &subscription{}
+	Expect(json.NewDecoder(r.Body).Decode(payload)).To(Succeed())
+	Expect(payload.EventType).To(Equal(eventType))
+	Expect(payload.PublisherID).To(Equal(publisherID))
+	Expect(payload.ConsumerID).To(Equal(consumerID))
+	Expect(payload.ConsumerActionID).To(Equal(consumerActID))
+	Expect(payload.PublisherInputs).To(Equal(publisherInputs{ProjectID: "project-id", Repository: "repo-id"}))
+	Expect(payload.ConsumerInputs.URL).To(Equal(webhookURL))
+	Expect(payload.ConsumerInputs.BasicAuthUsername).To(Equal(webhookUser))
+	Expect(payload.ConsumerInputs.BasicAuthPassword).To(Equal(secret))
+
+	return payload.EventType
+}
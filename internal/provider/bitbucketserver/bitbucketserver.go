@@ -0,0 +1,443 @@
+package bitbucketserver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+)
+
+const (
+	defaultPageSize = 50
+	httpTimeout     = 30 * time.Second
+	webhookName     = "renovate-operator"
+)
+
+var (
+	errInvalidRepoName  = errors.New("invalid repository name format")
+	errInvalidEndpoint  = errors.New("invalid endpoint")
+	errMissingAdmin     = errors.New("repository admin permissions required to manage webhooks")
+	errUnexpectedStatus = errors.New("unexpected status code")
+	errEmptyIdentity    = errors.New("empty identity returned by whoami")
+)
+
+// webhookEvents are the Bitbucket Server event keys subscribed by managed webhooks.
+var webhookEvents = []string{"repo:refs_changed", "pr:merged", "pr:modified"}
+
+// Provider manages Bitbucket Server and Data Center repositories and webhooks
+// through the REST API 1.0. Repository names use the `PROJECT/repo-slug` format
+// expected by Renovate.
+type Provider struct {
+	client *http.Client
+	webURL string
+	apiURL string
+	token  string
+}
+
+var _ provider.ProviderManager = (*Provider)(nil)
+
+// NewProvider creates a Bitbucket Server provider authenticated with an HTTP access token.
+func NewProvider(_ context.Context, endpoint, token string) (*Provider, error) {
+	webURL, apiURL, err := deriveURLs(endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create bitbucket server client: %w", err)
+	}
+
+	return &Provider{
+		client: &http.Client{Timeout: httpTimeout},
+		webURL: webURL,
+		apiURL: apiURL,
+		token:  token,
+	}, nil
+}
+
+func (p *Provider) GetIdentity() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
+	defer cancel()
+
+	resp, err := p.do(ctx, http.MethodGet, p.webURL+"/plugins/servlet/applinks/whoami", nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch current user: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return "", fmt.Errorf("failed to fetch current user: %w: %d", errUnexpectedStatus, resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read current user: %w", err)
+	}
+
+	identity := strings.TrimSpace(string(body))
+	if identity == "" {
+		return "", errEmptyIdentity
+	}
+
+	return identity, nil
+}
+
+type webhookConfiguration struct {
+	Secret string `json:"secret,omitempty"`
+}
+
+type webhook struct {
+	ID            int64                `json:"id,omitempty"`
+	Name          string               `json:"name"`
+	URL           string               `json:"url"`
+	Events        []string             `json:"events"`
+	Active        bool                 `json:"active"`
+	Configuration webhookConfiguration `json:"configuration"`
+}
+
+type page[T any] struct {
+	Values        []T  `json:"values"`
+	IsLastPage    bool `json:"isLastPage"`
+	NextPageStart int  `json:"nextPageStart"`
+}
+
+func (p *Provider) EnsureWebhook(ctx context.Context, repoName, webhookURL, secret string) (string, error) {
+	project, slug, err := parseRepoName(repoName)
+	if err != nil {
+		return "", err
+	}
+
+	hooksPath := repoPath(project, slug) + "/webhooks"
+
+	var existingHook *webhook
+
+	start := 0
+
+	for {
+		hooks := &page[webhook]{}
+
+		status, err := p.getJSON(ctx, pagedPath(hooksPath, start), hooks)
+		if err != nil {
+			return "", fmt.Errorf("failed to list webhooks: %w", err)
+		}
+
+		if status == http.StatusUnauthorized || status == http.StatusForbidden {
+			return "", errMissingAdmin
+		}
+
+		if status != http.StatusOK {
+			return "", fmt.Errorf("failed to list webhooks: %w: %d", errUnexpectedStatus, status)
+		}
+
+		for i := range hooks.Values {
+			if hooks.Values[i].URL == webhookURL {
+				existingHook = &hooks.Values[i]
+
+				break
+			}
+		}
+
+		if existingHook != nil || hooks.IsLastPage {
+			break
+		}
+
+		start = hooks.NextPageStart
+	}
+
+	desiredHook := &webhook{
+		Name:          webhookName,
+		URL:           webhookURL,
+		Events:        webhookEvents,
+		Active:        true,
+		Configuration: webhookConfiguration{Secret: secret},
+	}
+
+	if existingHook != nil {
+		hookID := strconv.FormatInt(existingHook.ID, 10)
+
+		status, err := p.sendJSON(ctx, http.MethodPut, hooksPath+"/"+hookID, desiredHook, nil)
+		if err != nil {
+			return "", fmt.Errorf("failed to update existing webhook: %w", err)
+		}
+
+		if status != http.StatusOK {
+			return "", fmt.Errorf("failed to update existing webhook: %w: %d", errUnexpectedStatus, status)
+		}
+
+		return hookID, nil
+	}
+
+	newHook := &webhook{}
+
+	status, err := p.sendJSON(ctx, http.MethodPost, hooksPath, desiredHook, newHook)
+	if err != nil {
+		return "", fmt.Errorf("failed to create webhook: %w", err)
+	}
+
+	if status != http.StatusCreated && status != http.StatusOK {
+		return "", fmt.Errorf("failed to create webhook: %w: %d", errUnexpectedStatus, status)
+	}
+
+	return strconv.FormatInt(newHook.ID, 10), nil
+}
+
+func (p *Provider) DeleteWebhook(ctx context.Context, repoName, webhookID string) error {
+	if webhookID == "" {
+		return nil
+	}
+
+	project, slug, err := parseRepoName(repoName)
+	if err != nil {
+		return err
+	}
+
+	if _, err := strconv.ParseInt(webhookID, 10, 64); err != nil {
+		return fmt.Errorf("invalid webhook ID format: %w", err)
+	}
+
+	resp, err := p.do(ctx, http.MethodDelete, p.apiURL+repoPath(project, slug)+"/webhooks/"+webhookID, nil)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook %s: %w", webhookID, err)
+	}
+	defer resp.Body.Close()
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusNoContent, http.StatusNotFound:
+		return nil
+	default:
+		return fmt.Errorf("failed to delete webhook %s: %w: %d", webhookID, errUnexpectedStatus, resp.StatusCode)
+	}
+}
+
+type repoLink struct {
+	Href string `json:"href"`
+}
+
+type repository struct {
+	ID      int64  `json:"id"`
+	Slug    string `json:"slug"`
+	Project struct {
+		Key string `json:"key"`
+	} `json:"project"`
+	Origin *struct {
+		Slug string `json:"slug"`
+	} `json:"origin"`
+	Archived bool `json:"archived"`
+	Links    struct {
+		Self []repoLink `json:"self"`
+	} `json:"links"`
+}
+
+func (p *Provider) RepoURL(ctx context.Context, repoName string) (string, error) {
+	project, slug, err := parseRepoName(repoName)
+	if err != nil {
+		return "", err
+	}
+
+	repo := &repository{}
+
+	status, err := p.getJSON(ctx, repoPath(project, slug), repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch repository: %w", err)
+	}
+
+	if status != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch repository: %w: %d", errUnexpectedStatus, status)
+	}
+
+	if len(repo.Links.Self) > 0 && repo.Links.Self[0].Href != "" {
+		return strings.TrimSuffix(repo.Links.Self[0].Href, "/browse"), nil
+	}
+
+	return fmt.Sprintf("%s/projects/%s/repos/%s", p.webURL, project, slug), nil
+}
+
+var _ provider.DefaultBranchResolver = (*Provider)(nil)
+
+type defaultBranch struct {
+	DisplayID string `json:"displayId"`
+}
+
+// DefaultBranch returns the default branch of the repository. It is empty for
+// repositories without commits, which have no default branch yet.
+func (p *Provider) DefaultBranch(ctx context.Context, repoName string) (string, error) {
+	project, slug, err := parseRepoName(repoName)
+	if err != nil {
+		return "", err
+	}
+
+	branch := &defaultBranch{}
+
+	status, err := p.getJSON(ctx, repoPath(project, slug)+"/default-branch", branch)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch default branch: %w", err)
+	}
+
+	switch status {
+	case http.StatusOK:
+		return branch.DisplayID, nil
+	case http.StatusNoContent, http.StatusNotFound:
+		return "", nil
+	default:
+		return "", fmt.Errorf("failed to fetch default branch: %w: %d", errUnexpectedStatus, status)
+	}
+}
+
+// ListRepos returns repositories the authenticated identity can write to.
+// Bitbucket Server has no repository topics, so a non-empty opts.Topics
+// matches no repository. Forks are detected by the presence of an origin.
+// Archived repositories are always excluded.
+func (p *Provider) ListRepos(ctx context.Context, opts provider.ListReposOptions) ([]provider.Repo, error) {
+	if len(opts.Topics) > 0 {
+		return nil, nil
+	}
+
+	var out []provider.Repo
+
+	start := 0
+
+	for {
+		repos := &page[repository]{}
+
+		status, err := p.getJSON(ctx, pagedPath("/repos?permission=REPO_WRITE", start), repos)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list repositories: %w", err)
+		}
+
+		if status != http.StatusOK {
+			return nil, fmt.Errorf("failed to list repositories: %w: %d", errUnexpectedStatus, status)
+		}
+
+		for _, repo := range repos.Values {
+			if repo.Project.Key == "" || repo.Slug == "" || repo.Archived {
+				continue
+			}
+
+			isFork := repo.Origin != nil
+			if opts.SkipForks && isFork {
+				continue
+			}
+
+			out = append(out, provider.Repo{
+				ID:     strconv.FormatInt(repo.ID, 10),
+				Name:   repo.Project.Key + "/" + repo.Slug,
+				IsFork: isFork,
+			})
+		}
+
+		if repos.IsLastPage {
+			break
+		}
+
+		start = repos.NextPageStart
+	}
+
+	return out, nil
+}
+
+func (p *Provider) do(ctx context.Context, method, rawURL string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+p.token)
+
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	return p.client.Do(req)
+}
+
+// getJSON performs a GET request relative to the REST API root and decodes a
+// successful JSON response into out.
+// The HTTP status is returned so callers can map platform-specific failures.
+func (p *Provider) getJSON(ctx context.Context, path string, out any) (int, error) {
+	return p.sendJSON(ctx, http.MethodGet, path, nil, out)
+}
+
+func (p *Provider) sendJSON(ctx context.Context, method, path string, in, out any) (int, error) {
+	var body io.Reader
+
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode request: %w", err)
+		}
+
+		body = bytes.NewReader(data)
+	}
+
+	resp, err := p.do(ctx, method, p.apiURL+path, body)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices || out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+
+		return resp.StatusCode, nil
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp.StatusCode, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return resp.StatusCode, nil
+}
+
+func repoPath(project, slug string) string {
+	return fmt.Sprintf("/projects/%s/repos/%s", url.PathEscape(project), url.PathEscape(slug))
+}
+
+func pagedPath(path string, start int) string {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+
+	return fmt.Sprintf("%s%sstart=%d&limit=%d", path, separator, start, defaultPageSize)
+}
+
+// deriveURLs returns the web root and the REST API root of an endpoint.
+// Renovate accepts both the web root and an API root ending in /rest/api/1.0
+// or /rest/api/latest, so the endpoint is normalized to the web root once and
+// both URLs are derived from it.
+func deriveURLs(endpoint string) (string, string, error) {
+	webURL := strings.TrimRight(strings.TrimSpace(endpoint), "/")
+	webURL = strings.TrimSuffix(webURL, "/rest/api/1.0")
+	webURL = strings.TrimSuffix(webURL, "/rest/api/latest")
+
+	parsed, err := url.ParseRequestURI(webURL)
+	if err != nil {
+		return "", "", err
+	}
+
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return "", "", fmt.Errorf("%w: %s", errInvalidEndpoint, endpoint)
+	}
+
+	return webURL, webURL + "/rest/api/1.0", nil
+}
+
+func parseRepoName(fullRepo string) (string, string, error) {
+	project, slug, found := strings.Cut(fullRepo, "/")
+
+	if !found || project == "" || slug == "" || strings.Contains(slug, "/") {
+		return "", "", fmt.Errorf("%w: %s", errInvalidRepoName, fullRepo)
+	}
+
+	return project, slug, nil
+}
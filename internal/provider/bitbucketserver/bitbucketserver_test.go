@@ -0,0 +1,303 @@
+package bitbucketserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+)
+
+var _ = Describe("Bitbucket Server Provider", func() {
+	DescribeTable(
+		"derives web and API URLs",
+		func(endpoint, expectedWeb string) {
+			webURL, apiURL, err := deriveURLs(endpoint)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(webURL).To(Equal(expectedWeb))
+			Expect(apiURL).To(Equal(expectedWeb + "/rest/api/1.0"))
+		},
+		Entry("web root", "https://bitbucket.example.com", "https://bitbucket.example.com"),
+		Entry("trailing slash", "https://bitbucket.example.com/", "https://bitbucket.example.com"),
+		Entry("REST API 1.0 root", "https://bitbucket.example.com/rest/api/1.0/", "https://bitbucket.example.com"),
+		Entry("REST API latest root", "https://bitbucket.example.com/rest/api/latest", "https://bitbucket.example.com"),
+		Entry("context path", "https://example.com/bitbucket/", "https://example.com/bitbucket"),
+		Entry(
+			"context path with API root",
+			"https://example.com/bitbucket/rest/api/latest/",
+			"https://example.com/bitbucket",
+		),
+	)
+
+	DescribeTable(
+		"rejects invalid endpoints",
+		func(endpoint string) {
+			_, _, err := deriveURLs(endpoint)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("empty endpoint", ""),
+		Entry("relative endpoint", "/rest/api/1.0"),
+		Entry("not a URL", "not a url"),
+	)
+
+	DescribeTable(
+		"validates repository names",
+		func(repoName string, valid bool) {
+			project, slug, err := parseRepoName(repoName)
+			if valid {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(project + "/" + slug).To(Equal(repoName))
+
+				return
+			}
+
+			Expect(err).To(MatchError(ContainSubstring("invalid repository name format")))
+		},
+		Entry("project and slug", "PROJ/repo", true),
+		Entry("personal project", "~user/repo", true),
+		Entry("missing slug", "PROJ", false),
+		Entry("empty project", "/repo", false),
+		Entry("too many segments", "PROJ/repo/extra", false),
+	)
+
+	Context("API interactions", func() {
+		var (
+			ctx     context.Context
+			server  *httptest.Server
+			p       *Provider
+			handler http.HandlerFunc
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer test-token"))
+				handler(w, r)
+			}))
+
+			var err error
+
+			p, err = NewProvider(ctx, server.URL+"/rest/api/1.0", "test-token")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("gets the authenticated identity", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/plugins/servlet/applinks/whoami"))
+
+				_, _ = w.Write([]byte("renovate-bot\n"))
+			}
+
+			identity, err := p.GetIdentity()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identity).To(Equal("renovate-bot"))
+		})
+
+		It("rejects an anonymous identity", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}
+
+			_, err := p.GetIdentity()
+			Expect(err).To(MatchError(errEmptyIdentity))
+		})
+
+		It("returns the repository URL without the browse suffix", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/rest/api/1.0/projects/PROJ/repos/repo"))
+
+				_, _ = w.Write([]byte(`{"slug":"repo","links":{"self":[{"href":"https://bb.example/projects/PROJ/repos/repo/browse"}]}}`))
+			}
+
+			repoURL, err := p.RepoURL(ctx, "PROJ/repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repoURL).To(Equal("https://bb.example/projects/PROJ/repos/repo"))
+		})
+
+		It("falls back to a derived repository URL", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"slug":"repo"}`))
+			}
+
+			repoURL, err := p.RepoURL(ctx, "PROJ/repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repoURL).To(Equal(server.URL + "/projects/PROJ/repos/repo"))
+		})
+
+		It("returns the default branch", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/rest/api/1.0/projects/PROJ/repos/repo/default-branch"))
+
+				_, _ = w.Write([]byte(`{"id":"refs/heads/main","displayId":"main","type":"BRANCH"}`))
+			}
+
+			branch, err := p.DefaultBranch(ctx, "PROJ/repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branch).To(Equal("main"))
+		})
+
+		It("returns no default branch for an empty repository", func() {
+			branch, err := p.DefaultBranch(ctx, "PROJ/repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(branch).To(BeEmpty())
+		})
+
+		It("reports default branch failures", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusForbidden)
+			}
+
+			_, err := p.DefaultBranch(ctx, "PROJ/repo")
+			Expect(err).To(MatchError(errUnexpectedStatus))
+		})
+
+		It("paginates and filters repositories", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/rest/api/1.0/repos"))
+				Expect(r.URL.Query().Get("permission")).To(Equal("REPO_WRITE"))
+				Expect(r.URL.Query().Get("limit")).To(Equal("50"))
+
+				if r.URL.Query().Get("start") == "0" {
+					_, _ = w.Write([]byte(`{"isLastPage":false,"nextPageStart":2,"values":[
+						{"id":1,"slug":"first","project":{"key":"PROJ"}},
+						{"slug":"fork","project":{"key":"PROJ"},"origin":{"slug":"upstream"}}
+					]}`))
+
+					return
+				}
+
+				_, _ = w.Write([]byte(`{"isLastPage":true,"values":[
+					{"slug":"archived","project":{"key":"PROJ"},"archived":true},
+					{"id":4,"slug":"second","project":{"key":"OTHER"}}
+				]}`))
+			}
+
+			repos, err := p.ListRepos(ctx, provider.ListReposOptions{SkipForks: true})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repos).To(Equal([]provider.Repo{
+				{ID: "1", Name: "PROJ/first", IsFork: false},
+				{ID: "4", Name: "OTHER/second", IsFork: false},
+			}))
+		})
+
+		It("matches no repositories when topics are requested", func() {
+			repos, err := p.ListRepos(ctx, provider.ListReposOptions{Topics: []string{"renovate"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repos).To(BeEmpty())
+		})
+
+		It("reports repository list failures", func() {
+			_, err := p.ListRepos(ctx, provider.ListReposOptions{})
+			Expect(err).To(MatchError(ContainSubstring("failed to list repositories")))
+		})
+
+		It("rejects webhook management without repository admin access", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusUnauthorized)
+			}
+
+			_, err := p.EnsureWebhook(ctx, "PROJ/repo", "https://operator.example/hook", "secret")
+			Expect(err).To(MatchError(errMissingAdmin))
+		})
+
+		It("creates a webhook if no matching URL is found", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.URL.Path).To(Equal("/rest/api/1.0/projects/PROJ/repos/repo/webhooks"))
+
+				switch r.Method {
+				case http.MethodGet:
+					_, _ = w.Write([]byte(`{"isLastPage":true,"values":[{"id":1,"url":"https://other.example/hook"}]}`))
+				case http.MethodPost:
+					assertWebhookPayload(r, "https://operator.example/hook", "new-secret")
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(`{"id":99}`))
+				}
+			}
+
+			hookID, err := p.EnsureWebhook(ctx, "PROJ/repo", "https://operator.example/hook", "new-secret")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hookID).To(Equal("99"))
+		})
+
+		It("finds and updates a webhook on a later page", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case r.Method == http.MethodGet && r.URL.Query().Get("start") == "0":
+					_, _ = w.Write([]byte(`{"isLastPage":false,"nextPageStart":1,"values":[{"id":1,"url":"https://other.example/hook"}]}`))
+				case r.Method == http.MethodGet:
+					_, _ = w.Write([]byte(`{"isLastPage":true,"values":[{"id":42,"url":"https://operator.example/hook"}]}`))
+				case r.Method == http.MethodPut:
+					Expect(r.URL.Path).To(Equal("/rest/api/1.0/projects/PROJ/repos/repo/webhooks/42"))
+					assertWebhookPayload(r, "https://operator.example/hook", "rotated-secret")
+					_, _ = w.Write([]byte(`{"id":42}`))
+				}
+			}
+
+			hookID, err := p.EnsureWebhook(ctx, "PROJ/repo", "https://operator.example/hook", "rotated-secret")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hookID).To(Equal("42"))
+		})
+
+		DescribeTable(
+			"validates webhook IDs",
+			func(hookID string, wantError bool) {
+				err := p.DeleteWebhook(ctx, "PROJ/repo", hookID)
+				if wantError {
+					Expect(err).To(MatchError(ContainSubstring("invalid webhook ID format")))
+
+					return
+				}
+
+				Expect(err).NotTo(HaveOccurred())
+			},
+			Entry("empty ID", "", false),
+			Entry("non-numeric ID", "invalid", true),
+		)
+
+		It("deletes a webhook", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodDelete))
+				Expect(r.URL.Path).To(Equal("/rest/api/1.0/projects/PROJ/repos/repo/webhooks/7"))
+				w.WriteHeader(http.StatusNoContent)
+			}
+
+			Expect(p.DeleteWebhook(ctx, "PROJ/repo", "7")).To(Succeed())
+		})
+
+		It("treats a missing webhook as deleted", func() {
+			Expect(p.DeleteWebhook(ctx, "PROJ/repo", "7")).To(Succeed())
+		})
+
+		It("reports non-404 webhook deletion errors", func() {
+			handler = func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			}
+
+			err := p.DeleteWebhook(ctx, "PROJ/repo", "7")
+			Expect(err).To(MatchError(ContainSubstring("failed to delete webhook 7")))
+		})
+	})
+})
+
+func assertWebhookPayload(r *http.Request, webhookURL, secret string) {
+	Expect(r.Header.Get("Content-Type")).To(Equal("application/json"))
+
+	payload := &webhook{}
+	Expect(json.NewDecoder(r.Body).Decode(payload)).To(Succeed())
+	Expect(payload.Name).To(Equal(webhookName))
+	Expect(payload.URL).To(Equal(webhookURL))
+	Expect(payload.Active).To(BeTrue())
+	Expect(payload.Events).To(ConsistOf("repo:refs_changed", "pr:merged", "pr:modified"))
+	Expect(payload.Configuration.Secret).To(Equal(secret))
+}
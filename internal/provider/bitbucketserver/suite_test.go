@@ -0,0 +1,13 @@
+package bitbucketserver
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestBitbucketServerProvider(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Bitbucket Server Provider Suite")
+}
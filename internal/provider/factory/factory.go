@@ -5,6 +5,8 @@ import (
 	"errors"
 
 	"github.com/thegeeklab/renovate-operator/internal/provider"
+	"github.com/thegeeklab/renovate-operator/internal/provider/azure"
+	"github.com/thegeeklab/renovate-operator/internal/provider/bitbucketserver"
 	"github.com/thegeeklab/renovate-operator/internal/provider/gitea"
 	"github.com/thegeeklab/renovate-operator/internal/provider/github"
 	"github.com/thegeeklab/renovate-operator/internal/provider/gitlab"
@@ -16,6 +18,9 @@ type PlatformConfig struct {
 	Type     string
 	Endpoint string
 	Token    string
+	// GitHubApp, when set, authenticates the github provider as an App
+	// installation and takes precedence over Token.
+	GitHubApp *github.AppCredentials
 }
 
 type ProviderFactory func(
@@ -33,9 +38,17 @@ func DefaultProviderFactory(
 	case "gitea":
 		return gitea.NewProvider(ctx, config.Endpoint, config.Token)
 	case "github":
+		if config.GitHubApp != nil {
+			return github.NewAppProvider(ctx, config.Endpoint, *config.GitHubApp)
+		}
+
 		return github.NewProvider(ctx, config.Endpoint, config.Token)
 	case "gitlab":
 		return gitlab.NewProvider(ctx, config.Endpoint, config.Token)
+	case "bitbucket-server":
+		return bitbucketserver.NewProvider(ctx, config.Endpoint, config.Token)
+	case "azure":
+		return azure.NewProvider(ctx, config.Endpoint, config.Token)
 	default:
 		return nil, ErrNotImplemented
 	}
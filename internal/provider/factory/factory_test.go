@@ -26,6 +26,26 @@ var _ = Describe("DefaultProviderFactory", func() {
 		Expect(providerManager).NotTo(BeNil())
 	})
 
+	It("creates a Bitbucket Server provider", func() {
+		providerManager, err := factory.DefaultProviderFactory(context.Background(), factory.PlatformConfig{
+			Type:     "bitbucket-server",
+			Endpoint: "https://bitbucket.example.com",
+			Token:    "test-token",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(providerManager).NotTo(BeNil())
+	})
+
+	It("creates an Azure DevOps provider", func() {
+		providerManager, err := factory.DefaultProviderFactory(context.Background(), factory.PlatformConfig{
+			Type:     "azure",
+			Endpoint: "https://dev.azure.com/example/",
+			Token:    "test-token",
+		})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(providerManager).NotTo(BeNil())
+	})
+
 	It("rejects unsupported providers", func() {
 		providerManager, err := factory.DefaultProviderFactory(context.Background(), factory.PlatformConfig{
 			Type: "unsupported",
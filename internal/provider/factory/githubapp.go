@@ -0,0 +1,47 @@
+package factory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/provider/github"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var ErrGitHubAppKeyNotFound = errors.New("GitHub App private key not found in secret")
+
+// LoadGitHubApp resolves the GitHub App credentials of the platform from the
+// private key Secret in the given namespace. It returns nil when the platform
+// is not configured for GitHub App authentication.
+func LoadGitHubApp(
+	ctx context.Context,
+	c client.Reader,
+	namespace string,
+	platform *renovatev1beta1.PlatformSpec,
+) (*github.AppCredentials, error) {
+	if platform.GitHubApp == nil {
+		return nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{
+		Name:      platform.GitHubApp.PrivateKey.Name,
+		Namespace: namespace,
+	}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get GitHub App private key secret: %w", err)
+	}
+
+	privateKey, ok := secret.Data[platform.GitHubApp.PrivateKey.Key]
+	if !ok {
+		return nil, ErrGitHubAppKeyNotFound
+	}
+
+	return &github.AppCredentials{
+		AppID:          platform.GitHubApp.AppID,
+		InstallationID: platform.GitHubApp.InstallationID,
+		PrivateKey:     privateKey,
+	}, nil
+}
@@ -0,0 +1,75 @@
+package factory_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/provider/factory"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("LoadGitHubApp", func() {
+	var (
+		ctx      context.Context
+		scheme   *runtime.Scheme
+		platform *renovatev1beta1.PlatformSpec
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		platform = &renovatev1beta1.PlatformSpec{
+			Type: renovatev1beta1.PlatformType_GITHUB,
+			GitHubApp: &renovatev1beta1.GitHubAppSpec{
+				AppID:          42,
+				InstallationID: 7,
+				PrivateKey: corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "github-app"},
+					Key:                  "private-key.pem",
+				},
+			},
+		}
+	})
+
+	It("returns nil without a GitHub App", func() {
+		app, err := factory.LoadGitHubApp(ctx, fake.NewClientBuilder().Build(), "default",
+			&renovatev1beta1.PlatformSpec{Type: renovatev1beta1.PlatformType_GITHUB})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(app).To(BeNil())
+	})
+
+	It("loads the App credentials from the private key secret", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "default"},
+			Data:       map[string][]byte{"private-key.pem": []byte("pem")},
+		}).Build()
+
+		app, err := factory.LoadGitHubApp(ctx, c, "default", platform)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(app.AppID).To(Equal(int64(42)))
+		Expect(app.InstallationID).To(Equal(int64(7)))
+		Expect(app.PrivateKey).To(Equal([]byte("pem")))
+	})
+
+	It("fails when the key is missing from the secret", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "default"},
+		}).Build()
+
+		_, err := factory.LoadGitHubApp(ctx, c, "default", platform)
+		Expect(err).To(MatchError(factory.ErrGitHubAppKeyNotFound))
+	})
+
+	It("fails when the secret does not exist", func() {
+		_, err := factory.LoadGitHubApp(ctx, fake.NewClientBuilder().WithScheme(scheme).Build(), "default", platform)
+		Expect(err).To(MatchError(ContainSubstring("failed to get GitHub App private key secret")))
+	})
+})
@@ -66,7 +66,7 @@ func (p *Provider) EnsureWebhook(ctx context.Context, repoName, webhookURL, secr
 		return "", errMissingAdmin
 	}
 
-	desiredEvents := []string{"push", "pull_request", "issues"}
+	desiredEvents := []string{"push", "pull_request", "issues", "issue_comment", "pull_request_comment"}
 
 	var existingHook *gitea.Hook
 
@@ -214,6 +214,7 @@ func (p *Provider) ListRepos(ctx context.Context, opts provider.ListReposOptions
 			}
 
 			out = append(out, provider.Repo{
+				ID:     strconv.FormatInt(repo.ID, 10),
 				Name:   name,
 				IsFork: repo.Fork,
 			})
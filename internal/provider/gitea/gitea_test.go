@@ -213,6 +213,7 @@ var _ = Describe("Gitea Provider", func() {
 				Expect(err).ToNot(HaveOccurred())
 				Expect(repos).To(HaveLen(1))
 				Expect(repos[0].Name).To(Equal("owner/repo1"))
+				Expect(repos[0].ID).To(Equal("1"))
 			})
 
 			It("should return all repositories when no topics filter is specified", func() {
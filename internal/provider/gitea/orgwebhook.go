@@ -0,0 +1,101 @@
+package gitea
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"code.gitea.io/sdk/gitea"
+
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+)
+
+var _ provider.OrgWebhookManager = (*Provider)(nil)
+
+// EnsureOrgWebhook creates or updates an organization webhook. Managing
+// organization webhooks requires owner permissions on the organization.
+func (p *Provider) EnsureOrgWebhook(ctx context.Context, org, webhookURL, secret string) (string, error) {
+	desiredEvents := []string{"push", "pull_request", "issues", "issue_comment", "pull_request_comment", "repository"}
+
+	var existingHook *gitea.Hook
+
+	opts := gitea.ListHooksOptions{ListOptions: gitea.ListOptions{Page: 1, PageSize: defaultPageSize}}
+
+	for {
+		hooks, resp, err := p.client.ListOrgHooks(org, opts)
+		if err != nil {
+			return "", fmt.Errorf("failed to list organization webhooks: %w", err)
+		}
+
+		for _, hook := range hooks {
+			if hook.Config["url"] == webhookURL {
+				existingHook = hook
+
+				break
+			}
+		}
+
+		if existingHook != nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	config := map[string]string{
+		"url":          webhookURL,
+		"content_type": "json",
+		"secret":       secret,
+	}
+
+	if existingHook != nil {
+		editOpts := gitea.EditHookOption{
+			Config: config,
+			Events: desiredEvents,
+			Active: new(true),
+		}
+
+		if _, err := p.client.EditOrgHook(org, existingHook.ID, editOpts); err != nil {
+			return "", fmt.Errorf("failed to update existing organization webhook: %w", err)
+		}
+
+		return strconv.FormatInt(existingHook.ID, 10), nil
+	}
+
+	createOpts := gitea.CreateHookOption{
+		Type:   "gitea",
+		Config: config,
+		Events: desiredEvents,
+		Active: true,
+	}
+
+	newHook, _, err := p.client.CreateOrgHook(org, createOpts)
+	if err != nil {
+		return "", fmt.Errorf("failed to create organization webhook: %w", err)
+	}
+
+	return strconv.FormatInt(newHook.ID, 10), nil
+}
+
+func (p *Provider) DeleteOrgWebhook(ctx context.Context, org, webhookID string) error {
+	if webhookID == "" {
+		return nil
+	}
+
+	id, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook ID format: %w", err)
+	}
+
+	resp, err := p.client.DeleteOrgHook(org, id)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete organization webhook %s: %w", webhookID, err)
+	}
+
+	return nil
+}
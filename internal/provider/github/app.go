@@ -0,0 +1,248 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v90/github"
+)
+
+const (
+	// jwtLifetime is kept below the ten minute maximum accepted by GitHub.
+	jwtLifetime = 9 * time.Minute
+	// jwtClockSkew backdates the issued-at claim to tolerate clock drift.
+	jwtClockSkew = time.Minute
+	// tokenRefreshSkew is the remaining lifetime at which a cached installation
+	// token is replaced by a new one.
+	tokenRefreshSkew = 5 * time.Minute
+)
+
+var (
+	errInvalidPrivateKey = errors.New("invalid GitHub App private key")
+	errMissingAppConfig  = errors.New("GitHub App ID and installation ID are required")
+)
+
+// AppCredentials identifies a GitHub App installation and the private key used
+// to authenticate as the App.
+type AppCredentials struct {
+	AppID          int64
+	InstallationID int64
+	PrivateKey     []byte
+}
+
+// InstallationToken is a short-lived access token of a GitHub App installation.
+type InstallationToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// CreateInstallationToken mints a new installation access token for the App.
+func CreateInstallationToken(ctx context.Context, endpoint string, app AppCredentials) (*InstallationToken, error) {
+	appClient, err := newAppClient(endpoint, app)
+	if err != nil {
+		return nil, err
+	}
+
+	token, _, err := appClient.Apps.CreateInstallationToken(ctx, app.InstallationID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create installation token: %w", err)
+	}
+
+	return &InstallationToken{
+		Token:     token.GetToken(),
+		ExpiresAt: token.GetExpiresAt().Time,
+	}, nil
+}
+
+// installationTransports holds the transport of every App installation.
+// Providers are created for every reconcile and webhook, sharing the transport
+// lets them reuse the cached installation token instead of minting a new one.
+var installationTransports sync.Map
+
+// installationKey identifies the transport of an App installation. The digest
+// of the private key is part of the key, so a rotated key mints a new token.
+type installationKey struct {
+	endpoint       string
+	appID          int64
+	installationID int64
+	keyDigest      [sha256.Size]byte
+}
+
+// NewAppProvider creates a GitHub provider authenticated as an App installation.
+// Installation tokens are minted on demand, shared by all providers of the
+// installation and cached until shortly before they expire.
+func NewAppProvider(_ context.Context, endpoint string, app AppCredentials) (*Provider, error) {
+	if err := validateApp(app); err != nil {
+		return nil, err
+	}
+
+	transport := installationTransportFor(endpoint, app)
+
+	client, err := newClient(endpoint, github.WithTransport(transport))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{
+		client:   client,
+		forgeURL: deriveForgeURL(endpoint),
+		endpoint: endpoint,
+		app:      &app,
+	}, nil
+}
+
+// installationTransportFor returns the shared transport of the App installation.
+func installationTransportFor(endpoint string, app AppCredentials) *installationTransport {
+	key := installationKey{
+		endpoint:       endpoint,
+		appID:          app.AppID,
+		installationID: app.InstallationID,
+		keyDigest:      sha256.Sum256(app.PrivateKey),
+	}
+
+	cached, _ := installationTransports.LoadOrStore(key, &installationTransport{
+		base: http.DefaultTransport,
+		mint: func(ctx context.Context) (*InstallationToken, error) {
+			return CreateInstallationToken(ctx, endpoint, app)
+		},
+	})
+
+	transport, _ := cached.(*installationTransport)
+
+	return transport
+}
+
+// validateApp checks the App credentials without contacting GitHub.
+func validateApp(app AppCredentials) error {
+	if app.AppID == 0 || app.InstallationID == 0 {
+		return errMissingAppConfig
+	}
+
+	_, err := parsePrivateKey(app.PrivateKey)
+
+	return err
+}
+
+// newAppClient returns a client authenticated with a freshly signed App JWT.
+// The JWT is valid for jwtLifetime, so the client must not be cached.
+func newAppClient(endpoint string, app AppCredentials) (*github.Client, error) {
+	if err := validateApp(app); err != nil {
+		return nil, err
+	}
+
+	jwt, err := signAppJWT(app.AppID, app.PrivateKey, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return newClient(endpoint, github.WithAuthToken(jwt))
+}
+
+// signAppJWT creates the RS256 signed JWT used to authenticate as the App.
+func signAppJWT(appID int64, privateKey []byte, now time.Time) (string, error) {
+	key, err := parsePrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-jwtClockSkew).Unix(),
+		"exp": now.Add(jwtLifetime).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(unsigned))
+
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign App JWT: %w", err)
+	}
+
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parsePrivateKey accepts PKCS#1 keys as downloaded from GitHub as well as
+// PKCS#8 encoded RSA keys.
+func parsePrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errInvalidPrivateKey
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errInvalidPrivateKey, err)
+	}
+
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("%w: not an RSA key", errInvalidPrivateKey)
+	}
+
+	return key, nil
+}
+
+// installationTransport authorizes requests with a cached installation token.
+type installationTransport struct {
+	base http.RoundTripper
+	mint func(ctx context.Context) (*InstallationToken, error)
+
+	mu    sync.Mutex
+	token *InstallationToken
+}
+
+func (t *installationTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	token, err := t.currentToken(req.Context())
+	if err != nil {
+		return nil, err
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	return t.base.RoundTrip(req)
+}
+
+func (t *installationTransport) currentToken(ctx context.Context) (string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.token != nil && time.Until(t.token.ExpiresAt) > tokenRefreshSkew {
+		return t.token.Token, nil
+	}
+
+	token, err := t.mint(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	t.token = token
+
+	return token.Token, nil
+}
@@ -0,0 +1,211 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+)
+
+var _ = Describe("GitHub App", func() {
+	var (
+		key    *rsa.PrivateKey
+		keyPEM []byte
+	)
+
+	BeforeEach(func() {
+		var err error
+
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+
+		keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	})
+
+	Describe("signAppJWT", func() {
+		It("creates a verifiable RS256 token with App claims", func() {
+			now := time.Unix(1700000000, 0)
+
+			jwt, err := signAppJWT(42, keyPEM, now)
+			Expect(err).NotTo(HaveOccurred())
+
+			parts := strings.Split(jwt, ".")
+			Expect(parts).To(HaveLen(3))
+
+			signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+			Expect(err).NotTo(HaveOccurred())
+
+			digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			Expect(rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature)).To(Succeed())
+
+			payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+			Expect(err).NotTo(HaveOccurred())
+
+			claims := map[string]any{}
+			Expect(json.Unmarshal(payload, &claims)).To(Succeed())
+			Expect(claims).To(HaveKeyWithValue("iss", "42"))
+			Expect(claims).To(HaveKeyWithValue("iat", BeNumerically("==", now.Add(-jwtClockSkew).Unix())))
+			Expect(claims).To(HaveKeyWithValue("exp", BeNumerically("==", now.Add(jwtLifetime).Unix())))
+		})
+
+		It("accepts PKCS#8 encoded keys", func() {
+			der, err := x509.MarshalPKCS8PrivateKey(key)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = signAppJWT(42, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), time.Now())
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("rejects invalid keys", func() {
+			_, err := signAppJWT(42, []byte("not a key"), time.Now())
+			Expect(err).To(MatchError(errInvalidPrivateKey))
+		})
+	})
+
+	Context("API Interactions", func() {
+		var (
+			ctx        context.Context
+			mockServer *httptest.Server
+			mux        *http.ServeMux
+			app        AppCredentials
+			minted     atomic.Int32
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			mux = http.NewServeMux()
+			mockServer = httptest.NewServer(mux)
+			app = AppCredentials{AppID: 42, InstallationID: 7, PrivateKey: keyPEM}
+			minted.Store(0)
+
+			mux.HandleFunc("/api/v3/app/installations/7/access_tokens", func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodPost))
+				Expect(r.Header.Get("Authorization")).To(HavePrefix("Bearer ey"))
+				minted.Add(1)
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"token":"ghs_installation","expires_at":"` +
+					time.Now().Add(time.Hour).UTC().Format(time.RFC3339) + `"}`))
+			})
+		})
+
+		AfterEach(func() {
+			mockServer.Close()
+		})
+
+		It("mints an installation token", func() {
+			token, err := CreateInstallationToken(ctx, mockServer.URL, app)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token.Token).To(Equal("ghs_installation"))
+			Expect(token.ExpiresAt).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+		})
+
+		It("requires App and installation IDs", func() {
+			_, err := NewAppProvider(ctx, mockServer.URL, AppCredentials{PrivateKey: keyPEM})
+			Expect(err).To(MatchError(errMissingAppConfig))
+		})
+
+		It("returns the bot login as identity", func() {
+			mux.HandleFunc("/api/v3/app", func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(HavePrefix("Bearer ey"))
+				_, _ = w.Write([]byte(`{"slug":"renovate-app"}`))
+			})
+
+			p, err := NewAppProvider(ctx, mockServer.URL, app)
+			Expect(err).NotTo(HaveOccurred())
+
+			identity, err := p.GetIdentity()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(identity).To(Equal("renovate-app[bot]"))
+		})
+
+		It("lists installation repositories with a cached token", func() {
+			mux.HandleFunc("/api/v3/installation/repositories", func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer ghs_installation"))
+
+				if r.URL.Query().Get("page") == "2" {
+					_, _ = w.Write([]byte(`{"total_count":3,"repositories":[
+						{"id":7,"full_name":"org/tagged","topics":["renovate"]}
+					]}`))
+
+					return
+				}
+
+				w.Header().Set("Link", `<`+mockServer.URL+`/api/v3/installation/repositories?page=2>; rel="next"`)
+				_, _ = w.Write([]byte(`{"total_count":3,"repositories":[
+					{"full_name":"org/fork","fork":true,"topics":["renovate"]},
+					{"full_name":"org/untagged"}
+				]}`))
+			})
+
+			p, err := NewAppProvider(ctx, mockServer.URL, app)
+			Expect(err).NotTo(HaveOccurred())
+
+			repos, err := p.ListRepos(ctx, provider.ListReposOptions{SkipForks: true, Topics: []string{"renovate"}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repos).To(Equal([]provider.Repo{{ID: "7", Name: "org/tagged"}}))
+			Expect(minted.Load()).To(Equal(int32(1)))
+		})
+
+		It("shares the installation token between providers of the installation", func() {
+			mux.HandleFunc("/api/v3/installation/repositories", func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer ghs_installation"))
+				_, _ = w.Write([]byte(`{"total_count":1,"repositories":[{"id":7,"full_name":"org/repo"}]}`))
+			})
+
+			for range 3 {
+				p, err := NewAppProvider(ctx, mockServer.URL, app)
+				Expect(err).NotTo(HaveOccurred())
+
+				_, err = p.ListRepos(ctx, provider.ListReposOptions{})
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			Expect(minted.Load()).To(Equal(int32(1)))
+		})
+
+		It("rejects an invalid private key", func() {
+			_, err := NewAppProvider(ctx, mockServer.URL, AppCredentials{AppID: 42, InstallationID: 7})
+			Expect(err).To(MatchError(errInvalidPrivateKey))
+		})
+
+		It("manages webhooks without a repository admin check", func() {
+			mux.HandleFunc("/api/v3/repos/org/repo", func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(`{"full_name":"org/repo"}`))
+			})
+			mux.HandleFunc("/api/v3/repos/org/repo/hooks", func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Header.Get("Authorization")).To(Equal("Bearer ghs_installation"))
+
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`[]`))
+
+					return
+				}
+
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id":99}`))
+			})
+
+			p, err := NewAppProvider(ctx, mockServer.URL, app)
+			Expect(err).NotTo(HaveOccurred())
+
+			hookID, err := p.EnsureWebhook(ctx, "org/repo", "https://hook.url", "secret")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(hookID).To(Equal("99"))
+		})
+	})
+})
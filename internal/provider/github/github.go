@@ -27,18 +27,29 @@ var (
 type Provider struct {
 	client   *github.Client
 	forgeURL string
+
+	// endpoint and app are set when authenticated as a GitHub App installation.
+	endpoint string
+	app      *AppCredentials
 }
 
 func NewProvider(ctx context.Context, endpoint, token string) (*Provider, error) {
+	client, err := newClient(endpoint, github.WithAuthToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Provider{client: client, forgeURL: deriveForgeURL(endpoint)}, nil
+}
+
+func newClient(endpoint string, authOpt github.ClientOptionsFunc) (*github.Client, error) {
 	baseURL := sanitizeEndpoint(endpoint)
 	if baseURL == "" {
 		baseURL = "https://api.github.com"
 	}
 
-	forgeURL := deriveForgeURL(endpoint)
-
 	opts := []github.ClientOptionsFunc{
-		github.WithAuthToken(token),
+		authOpt,
 		github.WithTimeout(httpTimeout),
 	}
 
@@ -51,13 +62,29 @@ func NewProvider(ctx context.Context, endpoint, token string) (*Provider, error)
 		return nil, fmt.Errorf("failed to create github client: %w", err)
 	}
 
-	return &Provider{client: client, forgeURL: forgeURL}, nil
+	return client, nil
 }
 
+// GetIdentity returns the login of the token owner, or the bot login
+// (`<app-slug>[bot]`) when authenticated as a GitHub App installation.
 func (p *Provider) GetIdentity() (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), httpTimeout)
 	defer cancel()
 
+	if p.app != nil {
+		appClient, err := newAppClient(p.endpoint, *p.app)
+		if err != nil {
+			return "", err
+		}
+
+		app, _, err := appClient.Apps.Get(ctx, "")
+		if err != nil {
+			return "", fmt.Errorf("failed to fetch current app: %w", err)
+		}
+
+		return app.GetSlug() + "[bot]", nil
+	}
+
 	user, _, err := p.client.Users.Get(ctx, "")
 	if err != nil {
 		return "", fmt.Errorf("failed to fetch current user: %w", err)
@@ -77,11 +104,13 @@ func (p *Provider) EnsureWebhook(ctx context.Context, repoName, webhookURL, secr
 		return "", fmt.Errorf("failed to fetch repository: %w", err)
 	}
 
-	if !repoData.GetPermissions().GetAdmin() {
+	// Installation tokens carry no per-repository permissions; missing App
+	// permissions surface as API errors instead.
+	if p.app == nil && !repoData.GetPermissions().GetAdmin() {
 		return "", errMissingAdmin
 	}
 
-	desiredEvents := []string{"push", "pull_request", "issues"}
+	desiredEvents := []string{"push", "pull_request", "issues", "issue_comment"}
 
 	var existingHook *github.Hook
 
@@ -198,6 +227,10 @@ func (p *Provider) RepoURL(ctx context.Context, repoName string) (string, error)
 // When opts.Topics is non-empty, only repositories containing all specified
 // topics are included (client-side filter).
 func (p *Provider) ListRepos(ctx context.Context, opts provider.ListReposOptions) ([]provider.Repo, error) {
+	if p.app != nil {
+		return p.listInstallationRepos(ctx, opts)
+	}
+
 	listOpts := &github.RepositoryListByAuthenticatedUserOptions{
 		ListOptions: github.ListOptions{Page: 1, PerPage: defaultPageSize},
 		Affiliation: "owner,collaborator,organization_member",
@@ -230,6 +263,7 @@ func (p *Provider) ListRepos(ctx context.Context, opts provider.ListReposOptions
 			}
 
 			out = append(out, provider.Repo{
+				ID:     strconv.FormatInt(repo.GetID(), 10),
 				Name:   name,
 				IsFork: repo.GetFork(),
 			})
@@ -245,6 +279,52 @@ func (p *Provider) ListRepos(ctx context.Context, opts provider.ListReposOptions
 	return out, nil
 }
 
+// listInstallationRepos returns the repositories the App installation was
+// granted access to. Forks and topics are filtered client-side.
+func (p *Provider) listInstallationRepos(
+	ctx context.Context,
+	opts provider.ListReposOptions,
+) ([]provider.Repo, error) {
+	listOpts := &github.ListOptions{Page: 1, PerPage: defaultPageSize}
+
+	var out []provider.Repo
+
+	for {
+		repos, resp, err := p.client.Apps.ListRepos(ctx, listOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list installation repositories: %w", err)
+		}
+
+		for _, repo := range repos.Repositories {
+			if repo.GetFullName() == "" {
+				continue
+			}
+
+			if opts.SkipForks && repo.GetFork() {
+				continue
+			}
+
+			if len(opts.Topics) > 0 && !util.ContainsAll(repo.Topics, opts.Topics) {
+				continue
+			}
+
+			out = append(out, provider.Repo{
+				ID:     strconv.FormatInt(repo.GetID(), 10),
+				Name:   repo.GetFullName(),
+				IsFork: repo.GetFork(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+
+		listOpts.Page = resp.NextPage
+	}
+
+	return out, nil
+}
+
 func sanitizeEndpoint(endpoint string) string {
 	endpoint = strings.TrimRight(endpoint, "/")
 
@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v90/github"
+
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+)
+
+var _ provider.OrgWebhookManager = (*Provider)(nil)
+
+// EnsureOrgWebhook creates or updates an organization webhook. Managing
+// organization webhooks requires the admin:org_hook scope, or the
+// organization_hooks permission when authenticated as a GitHub App.
+func (p *Provider) EnsureOrgWebhook(ctx context.Context, org, webhookURL, secret string) (string, error) {
+	desiredEvents := []string{"push", "pull_request", "issues", "issue_comment", "repository"}
+
+	var existingHook *github.Hook
+
+	opts := &github.ListOptions{Page: 1, PerPage: defaultPageSize}
+
+	for {
+		hooks, resp, err := p.client.Organizations.ListHooks(ctx, org, opts)
+		if err != nil {
+			return "", fmt.Errorf("failed to list organization webhooks: %w", err)
+		}
+
+		for _, hook := range hooks {
+			if hook.GetConfig().GetURL() == webhookURL {
+				existingHook = hook
+
+				break
+			}
+		}
+
+		if existingHook != nil || resp.NextPage == 0 {
+			break
+		}
+
+		opts.Page = resp.NextPage
+	}
+
+	hook := &github.Hook{
+		Config: &github.HookConfig{
+			URL:         new(webhookURL),
+			ContentType: new("json"),
+			Secret:      new(secret),
+		},
+		Events: desiredEvents,
+		Active: new(true),
+	}
+
+	if existingHook != nil {
+		_, _, err := p.client.Organizations.EditHook(ctx, org, existingHook.GetID(), hook)
+		if err != nil {
+			return "", fmt.Errorf("failed to update existing organization webhook: %w", err)
+		}
+
+		return strconv.FormatInt(existingHook.GetID(), 10), nil
+	}
+
+	hook.Name = new("web")
+
+	newHook, _, err := p.client.Organizations.CreateHook(ctx, org, hook)
+	if err != nil {
+		return "", fmt.Errorf("failed to create organization webhook: %w", err)
+	}
+
+	return strconv.FormatInt(newHook.GetID(), 10), nil
+}
+
+func (p *Provider) DeleteOrgWebhook(ctx context.Context, org, webhookID string) error {
+	if webhookID == "" {
+		return nil
+	}
+
+	id, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook ID format: %w", err)
+	}
+
+	resp, err := p.client.Organizations.DeleteHook(ctx, org, id)
+	if err != nil {
+		var errResp *github.ErrorResponse
+		if (resp != nil && resp.StatusCode == http.StatusNotFound) ||
+			(errors.As(err, &errResp) && errResp.Response.StatusCode == http.StatusNotFound) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete organization webhook %s: %w", webhookID, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("GitHub Organization Webhooks", func() {
+	var (
+		ctx        context.Context
+		mockServer *httptest.Server
+		mux        *http.ServeMux
+		p          *Provider
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		mux = http.NewServeMux()
+		mockServer = httptest.NewServer(mux)
+
+		var err error
+
+		p, err = NewProvider(ctx, mockServer.URL, "dummy-token")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		mockServer.Close()
+	})
+
+	Describe("EnsureOrgWebhook", func() {
+		It("should create a new organization webhook if no matching URL is found", func() {
+			mux.HandleFunc("/api/v3/orgs/thegeeklab/hooks", func(w http.ResponseWriter, r *http.Request) {
+				if r.Method == http.MethodGet {
+					_, _ = w.Write([]byte(`[{"id": 1, "config": {"url": "https://other.url"}}]`))
+
+					return
+				}
+
+				Expect(r.Method).To(Equal(http.MethodPost))
+
+				var body map[string]any
+				Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+				Expect(body).To(HaveKeyWithValue("name", "web"))
+
+				w.WriteHeader(http.StatusCreated)
+				_, _ = w.Write([]byte(`{"id": 999}`))
+			})
+
+			id, err := p.EnsureOrgWebhook(ctx, "thegeeklab", "https://hook.url", "dummy-secret")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("999"))
+		})
+
+		It("should update an existing organization webhook with a matching URL", func() {
+			mux.HandleFunc("/api/v3/orgs/thegeeklab/hooks", func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodGet))
+				_, _ = w.Write([]byte(`[{"id": 42, "config": {"url": "https://hook.url"}}]`))
+			})
+
+			mux.HandleFunc("/api/v3/orgs/thegeeklab/hooks/42", func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodPatch))
+				_, _ = w.Write([]byte(`{"id": 42}`))
+			})
+
+			id, err := p.EnsureOrgWebhook(ctx, "thegeeklab", "https://hook.url", "dummy-secret")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(id).To(Equal("42"))
+		})
+	})
+
+	Describe("DeleteOrgWebhook", func() {
+		It("should return early without error if the webhook ID is empty", func() {
+			Expect(p.DeleteOrgWebhook(ctx, "thegeeklab", "")).To(Succeed())
+		})
+
+		It("should ignore webhooks that no longer exist", func() {
+			mux.HandleFunc("/api/v3/orgs/thegeeklab/hooks/123", func(w http.ResponseWriter, r *http.Request) {
+				Expect(r.Method).To(Equal(http.MethodDelete))
+				w.WriteHeader(http.StatusNotFound)
+			})
+
+			Expect(p.DeleteOrgWebhook(ctx, "thegeeklab", "123")).To(Succeed())
+		})
+
+		It("should return an error if the webhook ID is not a valid integer", func() {
+			err := p.DeleteOrgWebhook(ctx, "thegeeklab", "invalid-id")
+			Expect(err).To(MatchError(ContainSubstring("invalid webhook ID format")))
+		})
+	})
+})
@@ -117,6 +117,7 @@ func (p *Provider) EnsureWebhook(ctx context.Context, repoName, webhookURL, secr
 			PushEvents:            new(true),
 			MergeRequestsEvents:   new(true),
 			IssuesEvents:          new(true),
+			NoteEvents:            new(true),
 			EnableSSLVerification: new(true),
 		}
 
@@ -139,6 +140,7 @@ func (p *Provider) EnsureWebhook(ctx context.Context, repoName, webhookURL, secr
 		PushEvents:            new(true),
 		MergeRequestsEvents:   new(true),
 		IssuesEvents:          new(true),
+		NoteEvents:            new(true),
 		EnableSSLVerification: new(true),
 	}
 
@@ -249,6 +251,7 @@ func (p *Provider) ListRepos(ctx context.Context, opts provider.ListReposOptions
 			}
 
 			repos = append(repos, provider.Repo{
+				ID:     strconv.FormatInt(project.ID, 10),
 				Name:   project.PathWithNamespace,
 				IsFork: isFork,
 			})
@@ -146,7 +146,7 @@ var _ = Describe("GitLab Provider", func() {
 				if query.Get("page") == "1" {
 					w.Header().Set("X-Next-Page", "2")
 					_, _ = w.Write([]byte(`[
-						{"path_with_namespace":"group/first","topics":["renovate","prod"]},
+						{"id":1,"path_with_namespace":"group/first","topics":["renovate","prod"]},
 						{"path_with_namespace":"group/fork","topics":["renovate","prod"],"forked_from_project":{"id":1}},
 						{"path_with_namespace":"group/wrong-topic","topics":["renovate"]}
 					]`))
@@ -155,7 +155,7 @@ var _ = Describe("GitLab Provider", func() {
 				}
 
 				_, _ = w.Write([]byte(`[
-					{"path_with_namespace":"group/subgroup/second","topics":["prod","renovate"]},
+					{"id":4,"path_with_namespace":"group/subgroup/second","topics":["prod","renovate"]},
 					{"path_with_namespace":"","topics":["prod","renovate"]}
 				]`))
 			}
@@ -166,8 +166,8 @@ var _ = Describe("GitLab Provider", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(repos).To(Equal([]provider.Repo{
-				{Name: "group/first", IsFork: false},
-				{Name: "group/subgroup/second", IsFork: false},
+				{ID: "1", Name: "group/first", IsFork: false},
+				{ID: "4", Name: "group/subgroup/second", IsFork: false},
 			}))
 		})
 
@@ -181,9 +181,9 @@ var _ = Describe("GitLab Provider", func() {
 				Expect(r.URL.Path).To(Equal("/api/v4/projects"))
 
 				_, _ = w.Write([]byte(`[
-					{"path_with_namespace":"group/active","topics":[]},
+					{"id":1,"path_with_namespace":"group/active","topics":[]},
 					{"path_with_namespace":"group/pending-delete","topics":[],"marked_for_deletion_on":"2025-06-01T00:00:00.000Z"},
-					{"path_with_namespace":"group/another-active","topics":[]}
+					{"id":3,"path_with_namespace":"group/another-active","topics":[]}
 				]`))
 			}
 
@@ -192,8 +192,8 @@ var _ = Describe("GitLab Provider", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 			Expect(repos).To(Equal([]provider.Repo{
-				{Name: "group/active", IsFork: false},
-				{Name: "group/another-active", IsFork: false},
+				{ID: "1", Name: "group/active", IsFork: false},
+				{ID: "3", Name: "group/another-active", IsFork: false},
 			}))
 		})
 
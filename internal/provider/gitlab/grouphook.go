@@ -0,0 +1,108 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	gitlab "gitlab.com/gitlab-org/api/client-go/v2"
+
+	"github.com/thegeeklab/renovate-operator/internal/provider"
+)
+
+var _ provider.OrgWebhookManager = (*Provider)(nil)
+
+// EnsureOrgWebhook creates or updates a group hook. Group hooks cover all
+// projects in the group and its subgroups and require the Owner role.
+func (p *Provider) EnsureOrgWebhook(ctx context.Context, group, webhookURL, secret string) (string, error) {
+	listOpts := &gitlab.ListGroupHooksOptions{
+		ListOptions: gitlab.ListOptions{Page: 1, PerPage: defaultPageSize},
+	}
+
+	var existingHook *gitlab.GroupHook
+
+	for {
+		hooks, resp, err := p.client.Groups.ListGroupHooks(group, listOpts, gitlab.WithContext(ctx))
+		if err != nil {
+			return "", fmt.Errorf("failed to list group hooks: %w", err)
+		}
+
+		for _, hook := range hooks {
+			if hook.URL == webhookURL {
+				existingHook = hook
+
+				break
+			}
+		}
+
+		if existingHook != nil || resp.NextPage == 0 {
+			break
+		}
+
+		listOpts.Page = resp.NextPage
+	}
+
+	if existingHook != nil {
+		editOpts := &gitlab.EditGroupHookOptions{
+			URL:                   new(webhookURL),
+			Token:                 new(secret),
+			PushEvents:            new(true),
+			MergeRequestsEvents:   new(true),
+			IssuesEvents:          new(true),
+			NoteEvents:            new(true),
+			ProjectEvents:         new(true),
+			EnableSSLVerification: new(true),
+		}
+
+		_, _, err := p.client.Groups.EditGroupHook(group, existingHook.ID, editOpts, gitlab.WithContext(ctx))
+		if err != nil {
+			return "", fmt.Errorf("failed to update existing group hook: %w", err)
+		}
+
+		return strconv.FormatInt(existingHook.ID, 10), nil
+	}
+
+	createOpts := &gitlab.AddGroupHookOptions{
+		URL:                   new(webhookURL),
+		Token:                 new(secret),
+		PushEvents:            new(true),
+		MergeRequestsEvents:   new(true),
+		IssuesEvents:          new(true),
+		NoteEvents:            new(true),
+		ProjectEvents:         new(true),
+		EnableSSLVerification: new(true),
+	}
+
+	hook, _, err := p.client.Groups.AddGroupHook(group, createOpts, gitlab.WithContext(ctx))
+	if err != nil {
+		return "", fmt.Errorf("failed to create group hook: %w", err)
+	}
+
+	return strconv.FormatInt(hook.ID, 10), nil
+}
+
+func (p *Provider) DeleteOrgWebhook(ctx context.Context, group, webhookID string) error {
+	if webhookID == "" {
+		return nil
+	}
+
+	hookID, err := strconv.ParseInt(webhookID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook ID format: %w", err)
+	}
+
+	resp, err := p.client.Groups.DeleteGroupHook(group, hookID, gitlab.WithContext(ctx))
+	if err != nil {
+		var responseErr *gitlab.ErrorResponse
+		if (resp != nil && resp.StatusCode == http.StatusNotFound) ||
+			(errors.As(err, &responseErr) && responseErr.HasStatusCode(http.StatusNotFound)) {
+			return nil
+		}
+
+		return fmt.Errorf("failed to delete group hook %s: %w", webhookID, err)
+	}
+
+	return nil
+}
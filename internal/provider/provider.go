@@ -14,6 +14,9 @@ type ListReposOptions struct {
 
 // Repo is the platform-agnostic representation of a repository returned by ListRepos.
 type Repo struct {
+	// ID is the immutable platform ID of the repository. It is kept when the
+	// repository is renamed or transferred.
+	ID string
 	// Name is the full platform repository or project path and may include nested namespaces.
 	Name string
 	// IsFork reports whether the repository is a fork of another repository.
@@ -35,3 +38,20 @@ type ProviderManager interface {
 	// applying the given options. Results are paginated internally.
 	ListRepos(ctx context.Context, opts ListReposOptions) ([]Repo, error)
 }
+
+// OrgWebhookManager is implemented by providers that support webhooks on the
+// organization or group level, covering all repositories below it.
+type OrgWebhookManager interface {
+	// EnsureOrgWebhook creates an organization webhook if it doesn't exist and returns its ID.
+	EnsureOrgWebhook(ctx context.Context, org, webhookURL, secret string) (string, error)
+	// DeleteOrgWebhook removes the organization webhook from the remote provider.
+	DeleteOrgWebhook(ctx context.Context, org, webhookID string) error
+}
+
+// DefaultBranchResolver is implemented by providers of platforms that do not
+// include the default branch of the repository in push webhook payloads.
+type DefaultBranchResolver interface {
+	// DefaultBranch returns the name of the default branch of the repository.
+	// It is empty if the repository has no default branch yet.
+	DefaultBranch(ctx context.Context, repoName string) (string, error)
+}
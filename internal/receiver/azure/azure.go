@@ -0,0 +1,107 @@
+package azure
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/thegeeklab/renovate-operator/internal/receiver"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid basic auth credentials")
+	ErrMissingCredentials = errors.New("missing basic auth credentials")
+)
+
+// Receiver validates and parses Azure DevOps service hook deliveries.
+type Receiver struct{}
+
+var _ receiver.Receiver = (*Receiver)(nil)
+
+// NewReceiver creates an Azure DevOps service hook receiver.
+func NewReceiver() *Receiver {
+	return &Receiver{}
+}
+
+// Validate checks the basic auth password against the webhook secret. Service
+// hooks do not sign payloads, so the shared secret is configured as basic auth
+// password of the subscription.
+func (p *Receiver) Validate(req *http.Request, secretToken, body []byte) error {
+	_, password, ok := req.BasicAuth()
+	if !ok || password == "" {
+		return ErrMissingCredentials
+	}
+
+	if subtle.ConstantTimeCompare([]byte(password), secretToken) != 1 {
+		return ErrInvalidCredentials
+	}
+
+	return nil
+}
+
+type eventPayload struct {
+	EventType string          `json:"eventType"`
+	Resource  json.RawMessage `json:"resource"`
+}
+
+func (p *Receiver) Parse(req *http.Request, body []byte) (receiver.ParseResult, error) {
+	payload := &eventPayload{}
+	if err := json.Unmarshal(body, payload); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	switch payload.EventType {
+	case "git.push":
+		return p.parsePushEvent(payload.Resource)
+	case "git.pullrequest.merged":
+		return p.parsePullRequestEvent(payload.Resource)
+	default:
+		return receiver.ParseResult{}, nil
+	}
+}
+
+type pushResource struct {
+	RefUpdates []struct {
+		Name string `json:"name"`
+	} `json:"refUpdates"`
+	Repository struct {
+		DefaultBranch string `json:"defaultBranch"`
+	} `json:"repository"`
+}
+
+func (p *Receiver) parsePushEvent(resource json.RawMessage) (receiver.ParseResult, error) {
+	push := &pushResource{}
+	if err := json.Unmarshal(resource, push); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	if push.Repository.DefaultBranch == "" {
+		return receiver.ParseResult{}, nil
+	}
+
+	for _, update := range push.RefUpdates {
+		if update.Name == push.Repository.DefaultBranch {
+			return receiver.ParseResult{ShouldTrigger: true}, nil
+		}
+	}
+
+	return receiver.ParseResult{}, nil
+}
+
+type pullRequestResource struct {
+	Status      string `json:"status"`
+	MergeStatus string `json:"mergeStatus"`
+}
+
+// parsePullRequestEvent triggers a run once a pull request is merged. Merge
+// events are also sent for the merge commits Azure DevOps creates to preview
+// active pull requests, which are ignored.
+func (p *Receiver) parsePullRequestEvent(resource json.RawMessage) (receiver.ParseResult, error) {
+	pr := &pullRequestResource{}
+	if err := json.Unmarshal(resource, pr); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	return receiver.ParseResult{ShouldTrigger: pr.Status == "completed" && pr.MergeStatus == "succeeded"}, nil
+}
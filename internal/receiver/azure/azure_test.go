@@ -0,0 +1,105 @@
+package azure
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/receiver"
+)
+
+var _ = Describe("Azure DevOps Webhook Receiver", func() {
+	var azureReceiver *Receiver
+
+	BeforeEach(func() {
+		azureReceiver = NewReceiver()
+	})
+
+	Describe("Validate", func() {
+		It("accepts a matching basic auth password regardless of the user", func() {
+			req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewBufferString("body"))
+			req.SetBasicAuth("any-user", "secret")
+
+			Expect(azureReceiver.Validate(req, []byte("secret"), []byte("body"))).To(Succeed())
+		})
+
+		It("rejects missing credentials", func() {
+			req := httptest.NewRequest(http.MethodPost, "/hook", nil)
+			Expect(azureReceiver.Validate(req, []byte("secret"), nil)).To(MatchError(ErrMissingCredentials))
+		})
+
+		It("rejects an invalid password", func() {
+			req := httptest.NewRequest(http.MethodPost, "/hook", nil)
+			req.SetBasicAuth("renovate-operator", "wrong")
+
+			Expect(azureReceiver.Validate(req, []byte("secret"), nil)).To(MatchError(ErrInvalidCredentials))
+		})
+	})
+
+	DescribeTable(
+		"parses service hook events",
+		func(body string, expected receiver.ParseResult) {
+			req := httptest.NewRequest(http.MethodPost, "/hook", nil)
+
+			result, err := azureReceiver.Parse(req, []byte(body))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry(
+			"push to default branch",
+			`{"eventType":"git.push","resource":{"refUpdates":[{"name":"refs/heads/main"}],`+
+				`"repository":{"defaultBranch":"refs/heads/main"}}}`,
+			receiver.ParseResult{ShouldTrigger: true},
+		),
+		Entry(
+			"push to feature branch",
+			`{"eventType":"git.push","resource":{"refUpdates":[{"name":"refs/heads/feature"}],`+
+				`"repository":{"defaultBranch":"refs/heads/main"}}}`,
+			receiver.ParseResult{},
+		),
+		Entry(
+			"push to empty repository",
+			`{"eventType":"git.push","resource":{"refUpdates":[{"name":"refs/heads/main"}],"repository":{}}}`,
+			receiver.ParseResult{},
+		),
+		Entry(
+			"merged pull request",
+			`{"eventType":"git.pullrequest.merged","resource":{"status":"completed","mergeStatus":"succeeded"}}`,
+			receiver.ParseResult{ShouldTrigger: true},
+		),
+		Entry(
+			"merge preview of an active pull request",
+			`{"eventType":"git.pullrequest.merged","resource":{"status":"active","mergeStatus":"succeeded"}}`,
+			receiver.ParseResult{},
+		),
+		Entry(
+			"merge with conflicts",
+			`{"eventType":"git.pullrequest.merged","resource":{"status":"active","mergeStatus":"conflicts"}}`,
+			receiver.ParseResult{},
+		),
+		Entry(
+			"update of a completed pull request",
+			`{"eventType":"git.pullrequest.updated","resource":{"status":"completed","mergeStatus":"succeeded"}}`,
+			receiver.ParseResult{},
+		),
+		Entry(
+			"update of an active pull request",
+			`{"eventType":"git.pullrequest.updated","resource":{"status":"active"}}`,
+			receiver.ParseResult{},
+		),
+		Entry("unknown event", `{"eventType":"build.complete","resource":{}}`, receiver.ParseResult{}),
+	)
+
+	It("returns an error for malformed payloads", func() {
+		req := httptest.NewRequest(http.MethodPost, "/hook", nil)
+
+		_, err := azureReceiver.Parse(req, []byte(`{`))
+		Expect(err).To(HaveOccurred())
+
+		_, err = azureReceiver.Parse(req, []byte(`{"eventType":"git.push","resource":[]}`))
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,184 @@
+package bitbucketserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/thegeeklab/renovate-operator/internal/receiver"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrMissingSignature = errors.New("missing X-Hub-Signature header")
+)
+
+const (
+	signatureParts = 2
+
+	// branchRefPrefix is the prefix of branch refs.
+	branchRefPrefix = "refs/heads/"
+
+	// renovateBranchPrefix is the default prefix of branches created by Renovate.
+	renovateBranchPrefix = branchRefPrefix + "renovate/"
+
+	// eventDateLayout is the layout of the event date of the payload.
+	eventDateLayout = "2006-01-02T15:04:05-0700"
+)
+
+// Receiver validates and parses Bitbucket Server and Data Center repository webhooks.
+type Receiver struct{}
+
+var _ receiver.Receiver = (*Receiver)(nil)
+
+// NewReceiver creates a Bitbucket Server webhook receiver.
+func NewReceiver() *Receiver {
+	return &Receiver{}
+}
+
+func (p *Receiver) Validate(req *http.Request, secretToken, body []byte) error {
+	signature := req.Header.Get("X-Hub-Signature")
+	if signature == "" {
+		return ErrMissingSignature
+	}
+
+	parts := strings.SplitN(signature, "=", signatureParts)
+	if len(parts) != signatureParts || parts[0] != "sha256" {
+		return ErrInvalidSignature
+	}
+
+	mac := hmac.New(sha256.New, secretToken)
+	mac.Write(body)
+	expectedMAC := hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expectedMAC), []byte(parts[1])) {
+		return ErrInvalidSignature
+	}
+
+	return nil
+}
+
+// Parse parses the payload of the event. The event date is part of the signed
+// payload and returned as timestamp of the result.
+func (p *Receiver) Parse(req *http.Request, body []byte) (receiver.ParseResult, error) {
+	result, err := p.parseEvent(req.Header.Get("X-Event-Key"), body)
+	if err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	result.Timestamp, err = parseEventDate(body)
+	if err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	return result, nil
+}
+
+func (p *Receiver) parseEvent(event string, body []byte) (receiver.ParseResult, error) {
+	switch event {
+	case "repo:refs_changed":
+		return p.parseRefsChangedEvent(body)
+	case "pr:merged":
+		return receiver.ParseResult{ShouldTrigger: true}, nil
+	case "pr:modified":
+		return p.parsePullRequestModifiedEvent(body)
+	default:
+		return receiver.ParseResult{}, nil
+	}
+}
+
+type refChange struct {
+	RefID string `json:"refId"`
+	Type  string `json:"type"`
+	Ref   struct {
+		Type string `json:"type"`
+	} `json:"ref"`
+}
+
+type refsChangedPayload struct {
+	Changes []refChange `json:"changes"`
+}
+
+// parseRefsChangedEvent triggers on branch updates. Bitbucket Server does not
+// include the default branch in the payload, so the updated branches except
+// the ones created by Renovate itself are returned and the server compares
+// them against the default branch of the GitRepo.
+func (p *Receiver) parseRefsChangedEvent(body []byte) (receiver.ParseResult, error) {
+	payload := &refsChangedPayload{}
+	if err := json.Unmarshal(body, payload); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	var branches []string
+
+	for _, change := range payload.Changes {
+		if change.Ref.Type != "BRANCH" || change.Type != "UPDATE" {
+			continue
+		}
+
+		if strings.HasPrefix(change.RefID, renovateBranchPrefix) {
+			continue
+		}
+
+		branches = append(branches, strings.TrimPrefix(change.RefID, branchRefPrefix))
+	}
+
+	if len(branches) == 0 {
+		return receiver.ParseResult{}, nil
+	}
+
+	return receiver.ParseResult{ShouldTrigger: true, Branches: branches}, nil
+}
+
+type pullRequestModifiedPayload struct {
+	Actor struct {
+		Name string `json:"name"`
+	} `json:"actor"`
+	PullRequest struct {
+		Description string `json:"description"`
+	} `json:"pullRequest"`
+}
+
+func (p *Receiver) parsePullRequestModifiedEvent(body []byte) (receiver.ParseResult, error) {
+	payload := &pullRequestModifiedPayload{}
+	if err := json.Unmarshal(body, payload); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	if !receiver.IsRenovateCheckboxChecked(payload.PullRequest.Description) {
+		return receiver.ParseResult{
+			User:   payload.Actor.Name,
+			Reason: receiver.ReasonCheckboxUnchecked,
+		}, nil
+	}
+
+	return receiver.ParseResult{
+		ShouldTrigger:    true,
+		RequireUserCheck: true,
+		User:             payload.Actor.Name,
+	}, nil
+}
+
+type eventPayload struct {
+	Date string `json:"date"`
+}
+
+// parseEventDate returns the event date of the payload. It is zero if the
+// payload has no date.
+func parseEventDate(body []byte) (time.Time, error) {
+	payload := &eventPayload{}
+
+	// Malformed payloads of unknown events are accepted without a date.
+	_ = json.Unmarshal(body, payload)
+
+	if payload.Date == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(eventDateLayout, payload.Date)
+}
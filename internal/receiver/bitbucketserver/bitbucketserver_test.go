@@ -0,0 +1,182 @@
+package bitbucketserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/receiver"
+	"github.com/thegeeklab/renovate-operator/internal/receiver/bitbucketserver/fixtures"
+)
+
+const renovateDescription = "## Detected Dependencies\n\n- [x] update dependency"
+
+var _ = Describe("Bitbucket Server Webhook Receiver", func() {
+	var (
+		bitbucketReceiver *Receiver
+		secret            []byte
+	)
+
+	BeforeEach(func() {
+		bitbucketReceiver = NewReceiver()
+		secret = []byte("test-secret-token")
+	})
+
+	Describe("Validate", func() {
+		body := []byte(`{"eventKey":"repo:refs_changed"}`)
+
+		It("accepts a valid HMAC signature", func() {
+			req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(body))
+			req.Header.Set("X-Hub-Signature", signature(secret, body))
+
+			Expect(bitbucketReceiver.Validate(req, secret, body)).To(Succeed())
+		})
+
+		It("rejects a missing signature", func() {
+			req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(body))
+
+			Expect(bitbucketReceiver.Validate(req, secret, body)).To(MatchError(ErrMissingSignature))
+		})
+
+		It("rejects an invalid signature", func() {
+			req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(body))
+			req.Header.Set("X-Hub-Signature", signature([]byte("wrong"), body))
+
+			Expect(bitbucketReceiver.Validate(req, secret, body)).To(MatchError(ErrInvalidSignature))
+		})
+
+		It("rejects a signature with an unsupported algorithm", func() {
+			req := httptest.NewRequest(http.MethodPost, "/hook", bytes.NewReader(body))
+			req.Header.Set("X-Hub-Signature", "sha1=abc123")
+
+			Expect(bitbucketReceiver.Validate(req, secret, body)).To(MatchError(ErrInvalidSignature))
+		})
+	})
+
+	DescribeTable(
+		"parses refs_changed events",
+		func(refType, refID, changeType string, expected receiver.ParseResult) {
+			body := []byte(`{"changes":[{"ref":{"type":"` + refType + `"},"refId":"` + refID +
+				`","type":"` + changeType + `"}]}`)
+
+			result, err := bitbucketReceiver.Parse(webhookRequest("repo:refs_changed"), body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry(
+			"updated branch",
+			"BRANCH", "refs/heads/main", "UPDATE",
+			receiver.ParseResult{ShouldTrigger: true, Branches: []string{"main"}},
+		),
+		Entry("created branch", "BRANCH", "refs/heads/feature", "ADD", receiver.ParseResult{}),
+		Entry("deleted branch", "BRANCH", "refs/heads/feature", "DELETE", receiver.ParseResult{}),
+		Entry("renovate branch", "BRANCH", "refs/heads/renovate/lodash-4.x", "UPDATE", receiver.ParseResult{}),
+		Entry("tag", "TAG", "refs/tags/v1.0.0", "ADD", receiver.ParseResult{}),
+	)
+
+	It("returns the updated default branch of a push", func() {
+		result, err := bitbucketReceiver.Parse(webhookRequest("repo:refs_changed"), []byte(fixtures.HookRefsChanged))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.ShouldTrigger).To(BeTrue())
+		Expect(result.Branches).To(Equal([]string{"main"}))
+	})
+
+	It("returns the updated feature branch of a push", func() {
+		body := []byte(fixtures.HookRefsChangedFeatureBranch)
+
+		result, err := bitbucketReceiver.Parse(webhookRequest("repo:refs_changed"), body)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.Branches).To(Equal([]string{"feature/login"}))
+	})
+
+	It("triggers on merged pull requests", func() {
+		result, err := bitbucketReceiver.Parse(webhookRequest("pr:merged"), []byte(`{}`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(receiver.ParseResult{ShouldTrigger: true}))
+	})
+
+	DescribeTable(
+		"parses pr:modified events",
+		func(description string, expected receiver.ParseResult) {
+			body := []byte(`{"actor":{"name":"renovate-bot"},"pullRequest":{"description":` +
+				jsonString(description) + `}}`)
+
+			result, err := bitbucketReceiver.Parse(webhookRequest("pr:modified"), body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry(
+			"checked checkbox",
+			renovateDescription,
+			receiver.ParseResult{ShouldTrigger: true, RequireUserCheck: true, User: "renovate-bot"},
+		),
+		Entry(
+			"unchecked checkbox",
+			"## Detected Dependencies\n- [ ] update",
+			receiver.ParseResult{User: "renovate-bot", Reason: receiver.ReasonCheckboxUnchecked},
+		),
+		Entry(
+			"regular pull request",
+			"- [x] done",
+			receiver.ParseResult{User: "renovate-bot", Reason: receiver.ReasonCheckboxUnchecked},
+		),
+	)
+
+	DescribeTable(
+		"returns the signed event date as timestamp",
+		func(body string, expected time.Time) {
+			result, err := bitbucketReceiver.Parse(webhookRequest("pr:merged"), []byte(body))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Timestamp.Equal(expected)).To(BeTrue())
+		},
+		Entry("with date", `{"date":"2017-09-19T09:58:11+1000"}`, time.Date(2017, 9, 18, 23, 58, 11, 0, time.UTC)),
+		Entry("without date", `{}`, time.Time{}),
+	)
+
+	It("returns an error for a malformed event date", func() {
+		_, err := bitbucketReceiver.Parse(webhookRequest("pr:merged"), []byte(`{"date":"yesterday"}`))
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("accepts an unknown event without triggering", func() {
+		result, err := bitbucketReceiver.Parse(webhookRequest("diagnostics:ping"), []byte(`not-json`))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result).To(Equal(receiver.ParseResult{}))
+	})
+
+	It("returns an error for malformed supported events", func() {
+		result, err := bitbucketReceiver.Parse(webhookRequest("repo:refs_changed"), []byte(`not-json`))
+		Expect(err).To(HaveOccurred())
+		Expect(result).To(Equal(receiver.ParseResult{}))
+	})
+})
+
+func signature(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func webhookRequest(event string) *http.Request {
+	req := httptest.NewRequestWithContext(context.Background(), http.MethodPost, "/hook", nil)
+	req.Header.Set("X-Event-Key", event)
+
+	return req
+}
+
+func jsonString(value string) string {
+	quoted, err := json.Marshal(value)
+	Expect(err).NotTo(HaveOccurred())
+
+	return string(quoted)
+}
@@ -0,0 +1,13 @@
+package fixtures
+
+import _ "embed"
+
+// HookRefsChanged is a sample Bitbucket Server repo:refs_changed hook updating the default branch.
+//
+//go:embed HookRefsChanged.json
+var HookRefsChanged string
+
+// HookRefsChangedFeatureBranch is a sample Bitbucket Server repo:refs_changed hook updating a feature branch.
+//
+//go:embed HookRefsChangedFeatureBranch.json
+var HookRefsChangedFeatureBranch string
@@ -0,0 +1,13 @@
+package bitbucketserver
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestBitbucketServerReceiver(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Bitbucket Server Receiver Suite")
+}
@@ -0,0 +1,96 @@
+package receiver
+
+import (
+	"strings"
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+)
+
+// ReasonNotRenovateComment is the reason of a comment event ignored because the
+// comment was not written on a Renovate pull request or the Dependency Dashboard.
+const ReasonNotRenovateComment = "comment not on a Renovate pull request or issue"
+
+// Reasons recorded in the webhook delivery history of a GitRepo for comment events.
+const (
+	reasonCommandsDisabled = "commands not enabled"
+	reasonNoCommand        = "comment contains no command"
+	reasonSkipScheduled    = "next scheduled run skipped"
+)
+
+// Command is a ChatOps command read from a comment.
+type Command string
+
+//nolint:revive
+const (
+	// Command_RUN triggers a Renovate run.
+	Command_RUN Command = "run"
+	// Command_DEBUG triggers a Renovate run with debug logging.
+	Command_DEBUG Command = "debug"
+	// Command_SKIP skips the next scheduled Renovate run.
+	Command_SKIP Command = "skip"
+)
+
+// ParseCommand returns the first command of the comment. A command is a line
+// starting with the prefix followed by the command name, e.g. `/renovate run`.
+func ParseCommand(comment, prefix string) (Command, bool) {
+	for line := range strings.Lines(comment) {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || fields[0] != prefix {
+			continue
+		}
+
+		switch command := Command(strings.ToLower(fields[1])); command {
+		case Command_RUN, Command_DEBUG, Command_SKIP:
+			return command, true
+		}
+	}
+
+	return "", false
+}
+
+// resolveCommand returns the command of a comment event for the GitRepo. Events
+// without a comment run Renovate. It returns an empty command and the reason if
+// the comment does not contain a command enabled for the GitRepo.
+func resolveCommand(repo *renovatev1beta1.GitRepo, result ParseResult) (Command, string) {
+	if result.Comment == "" {
+		return Command_RUN, ""
+	}
+
+	commands := repo.Spec.Webhooks.Commands
+	if commands == nil {
+		return "", reasonCommandsDisabled
+	}
+
+	command, ok := ParseCommand(result.Comment, commands.GetPrefix())
+	if !ok {
+		return "", reasonNoCommand
+	}
+
+	return command, ""
+}
+
+// applyCommand annotates the GitRepo to execute the command. It returns the
+// reason recorded in the webhook delivery history.
+func applyCommand(repo *renovatev1beta1.GitRepo, command Command, now time.Time) string {
+	if repo.Annotations == nil {
+		repo.Annotations = make(map[string]string)
+	}
+
+	if command == Command_SKIP {
+		repo.Annotations[renovatev1beta1.AnnotationSkipScheduledRun] = renovatev1beta1.ValueTrue
+
+		return reasonSkipScheduled
+	}
+
+	coalesced := recordWebhookEvent(repo, now)
+
+	repo.Annotations[renovatev1beta1.RenovatorOperation] = renovatev1beta1.OperationRenovate
+	repo.Annotations[renovatev1beta1.AnnotationTriggerSource] = renovatev1beta1.TriggerSourceWebhook
+
+	if command == Command_DEBUG {
+		repo.Annotations[renovatev1beta1.AnnotationDebugRun] = renovatev1beta1.ValueTrue
+	}
+
+	return acceptReason(coalesced)
+}
@@ -0,0 +1,31 @@
+package receiver_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/receiver"
+)
+
+var _ = Describe("Commands", func() {
+	DescribeTable("ParseCommand",
+		func(comment, prefix string, expected receiver.Command, expectedOK bool) {
+			command, ok := receiver.ParseCommand(comment, prefix)
+			Expect(ok).To(Equal(expectedOK))
+			Expect(command).To(Equal(expected))
+		},
+		Entry("run", "/renovate run", "/renovate", receiver.Command_RUN, true),
+		Entry("debug", "/renovate debug", "/renovate", receiver.Command_DEBUG, true),
+		Entry("skip", "/renovate skip", "/renovate", receiver.Command_SKIP, true),
+		Entry("rebase-all", "/renovate rebase-all", "/renovate", receiver.Command(""), false),
+		Entry("upper-case command", "/renovate RUN", "/renovate", receiver.Command_RUN, true),
+		Entry("command after text", "Please refresh.\r\n\r\n  /renovate run now", "/renovate",
+			receiver.Command_RUN, true),
+		Entry("first known command", "/renovate unknown\n/renovate skip", "/renovate", receiver.Command_SKIP, true),
+		Entry("custom prefix", "/deps run", "/deps", receiver.Command_RUN, true),
+		Entry("other prefix", "/deps run", "/renovate", receiver.Command(""), false),
+		Entry("prefix within text", "Use /renovate run to refresh", "/renovate", receiver.Command(""), false),
+		Entry("prefix without command", "/renovate", "/renovate", receiver.Command(""), false),
+		Entry("empty comment", "", "/renovate", receiver.Command(""), false),
+	)
+})
@@ -0,0 +1,150 @@
+package receiver
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Reasons recorded in the webhook delivery history of a GitRepo.
+const (
+	reasonNoTrigger      = "event does not trigger a run"
+	reasonInvalidPayload = "invalid payload"
+	reasonPathFiltered   = "no changed file matches the path filter"
+	reasonBranchFiltered = "push does not update the default branch"
+	reasonUserMismatch   = "user does not match the platform identity"
+	reasonCoalesced      = "coalesced into the pending webhook triggered run"
+	reasonDuplicate      = "duplicate delivery"
+	reasonClockSkew      = "event time outside the allowed clock skew"
+)
+
+var (
+	// deliveryIDHeaders are the headers carrying the delivery ID on the
+	// supported platforms. GitLab keeps the Idempotency-Key across retries of
+	// a delivery, unlike the event UUID.
+	deliveryIDHeaders = []string{
+		"X-GitHub-Delivery",
+		"X-Gitea-Delivery",
+		"Idempotency-Key",
+		"X-Gitlab-Event-UUID",
+		"X-Request-Id",
+	}
+
+	// eventHeaders are the headers carrying the event type on the supported
+	// platforms.
+	eventHeaders = []string{
+		"X-GitHub-Event",
+		"X-Gitea-Event",
+		"X-Gitlab-Event",
+		"X-Event-Key",
+	}
+)
+
+// newWebhookDelivery builds the delivery record of a webhook request.
+func newWebhookDelivery(
+	req *http.Request,
+	result ParseResult,
+	decision renovatev1beta1.WebhookDeliveryDecision,
+	reason string,
+	now time.Time,
+) renovatev1beta1.WebhookDelivery {
+	return renovatev1beta1.WebhookDelivery{
+		ID:       firstHeader(req, deliveryIDHeaders),
+		Event:    firstHeader(req, eventHeaders),
+		Sender:   result.User,
+		Decision: decision,
+		Reason:   reason,
+		Time:     metav1.NewTime(now.UTC()),
+	}
+}
+
+// recordDelivery prepends the delivery to the webhook delivery history of the
+// GitRepo and drops the oldest deliveries beyond MaxWebhookDeliveries. The
+// history is patched with an optimistic lock and the GitRepo is read again on
+// conflicts, so concurrent deliveries do not overwrite each other. Errors are
+// logged only, the history must not fail the webhook request.
+func (s *Server) recordDelivery(
+	ctx context.Context,
+	repo *renovatev1beta1.GitRepo,
+	delivery renovatev1beta1.WebhookDelivery,
+) {
+	key := client.ObjectKeyFromObject(repo)
+	current := repo.DeepCopy()
+	stale := false
+
+	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		if stale {
+			current = &renovatev1beta1.GitRepo{}
+			if err := s.client.Get(ctx, key, current); err != nil {
+				return err
+			}
+		}
+
+		stale = true
+		patch := client.MergeFromWithOptions(current.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+		deliveries := append([]renovatev1beta1.WebhookDelivery{delivery}, current.Status.WebhookDeliveries...)
+		if len(deliveries) > renovatev1beta1.MaxWebhookDeliveries {
+			deliveries = deliveries[:renovatev1beta1.MaxWebhookDeliveries]
+		}
+
+		current.Status.WebhookDeliveries = deliveries
+
+		return s.client.Status().Patch(ctx, current, patch)
+	})
+	if err != nil {
+		receiverLog.Error(err, "Failed to record webhook delivery", "namespace", repo.Namespace, "name", repo.Name)
+	}
+}
+
+// recordResolvedDelivery records a delivery on the GitRepo resolved for the
+// event. It is skipped if the event does not resolve to a GitRepo.
+func (s *Server) recordResolvedDelivery(
+	ctx context.Context,
+	req *http.Request,
+	result ParseResult,
+	resolveRepo func(context.Context, ParseResult) (*renovatev1beta1.GitRepo, error),
+	decision renovatev1beta1.WebhookDeliveryDecision,
+	reason string,
+) {
+	repo, err := resolveRepo(ctx, result)
+	if err != nil {
+		return
+	}
+
+	s.recordDelivery(ctx, repo, newWebhookDelivery(req, result, decision, reason, time.Now()))
+}
+
+// ignoreReason returns the reason of an event that does not trigger a run.
+func ignoreReason(result ParseResult) string {
+	if result.Reason != "" {
+		return result.Reason
+	}
+
+	return reasonNoTrigger
+}
+
+// acceptReason returns the reason of an event that triggers a run.
+func acceptReason(coalesced bool) string {
+	if coalesced {
+		return reasonCoalesced
+	}
+
+	return ""
+}
+
+// firstHeader returns the first non-empty value of the given headers.
+func firstHeader(req *http.Request, headers []string) string {
+	for _, header := range headers {
+		if value := req.Header.Get(header); value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
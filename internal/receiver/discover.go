@@ -0,0 +1,59 @@
+package receiver
+
+import (
+	"net/http"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/component/renovator"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// triggerDiscovery annotates the Discovery to discover the repositories of the
+// platform again after a repository lifecycle event. Events received by the
+// webhook of a GitRepo have no Discovery and are ignored. It returns false if
+// the Discovery could not be patched.
+func (s *Server) triggerDiscovery(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *renovatev1beta1.RenovateConfig,
+	discovery *renovatev1beta1.Discovery,
+	result ParseResult,
+) bool {
+	ctx := r.Context()
+
+	if discovery == nil {
+		receiverLog.Info("Webhook repository event not received by an organization webhook",
+			"repository", result.Repository)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted"}`))
+
+		if s.metrics != nil {
+			s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "ignored")
+		}
+
+		return true
+	}
+
+	patch := client.MergeFrom(discovery.DeepCopy())
+	discovery.Annotations = renovator.AddOperation(discovery.Annotations, renovatev1beta1.OperationDiscover)
+
+	if err := s.client.Patch(ctx, discovery, patch); err != nil {
+		receiverLog.Error(err, "Failed to apply discovery annotation",
+			"namespace", discovery.Namespace, "name", discovery.Name)
+		http.Error(w, "Failed to trigger discovery", http.StatusInternalServerError)
+
+		return false
+	}
+
+	receiverLog.Info("Webhook repository event triggered discovery",
+		"namespace", discovery.Namespace, "name", discovery.Name, "repository", result.Repository)
+
+	if s.metrics != nil {
+		s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "accepted")
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = w.Write([]byte(`{"status":"accepted"}`))
+
+	return true
+}
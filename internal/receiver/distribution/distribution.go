@@ -0,0 +1,83 @@
+package distribution
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// actionPush is the action of events pushing a blob or manifest.
+const actionPush = "push"
+
+type Receiver struct{}
+
+func NewReceiver() *Receiver {
+	return &Receiver{}
+}
+
+type target struct {
+	MediaType  string `json:"mediaType"`
+	Repository string `json:"repository"`
+	URL        string `json:"url"`
+}
+
+type request struct {
+	Host string `json:"host"`
+}
+
+type event struct {
+	Action  string  `json:"action"`
+	Target  target  `json:"target"`
+	Request request `json:"request"`
+}
+
+type envelope struct {
+	Events []event `json:"events"`
+}
+
+// Parse returns the images of the manifests pushed to a registry sending Docker
+// Distribution notifications. Pushed layers are ignored, an image is complete
+// only once its manifest or index is pushed.
+func (p *Receiver) Parse(_ *http.Request, body []byte) ([]string, error) {
+	envelope := &envelope{}
+	if err := json.Unmarshal(body, envelope); err != nil {
+		return nil, err
+	}
+
+	var images []string
+
+	for _, event := range envelope.Events {
+		if event.Action != actionPush || !isManifest(event.Target.MediaType) || event.Target.Repository == "" {
+			continue
+		}
+
+		host := event.Request.Host
+		if host == "" {
+			host = urlHost(event.Target.URL)
+		}
+
+		if host == "" {
+			continue
+		}
+
+		images = append(images, host+"/"+event.Target.Repository)
+	}
+
+	return images, nil
+}
+
+// isManifest reports whether the media type is an image manifest or index.
+func isManifest(mediaType string) bool {
+	return strings.Contains(mediaType, "manifest") || strings.Contains(mediaType, "image.index")
+}
+
+// urlHost returns the host of the URL or an empty string if it is invalid.
+func urlHost(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+
+	return u.Host
+}
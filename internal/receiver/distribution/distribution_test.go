@@ -0,0 +1,53 @@
+package distribution
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/receiver/distribution/fixtures"
+)
+
+var _ = Describe("Distribution Registry Receiver", func() {
+	var Receiver *Receiver
+
+	BeforeEach(func() {
+		Receiver = NewReceiver()
+	})
+
+	Describe("Parse", func() {
+		parse := func(body string) ([]string, error) {
+			req := httptest.NewRequest(http.MethodPost, "/registry", strings.NewReader(body))
+
+			return Receiver.Parse(req, []byte(body))
+		}
+
+		It("should return the images of pushed manifests", func() {
+			images, err := parse(fixtures.Push)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(images).To(Equal([]string{"registry.example.com/platform/base"}))
+		})
+
+		It("should fall back to the host of the target URL", func() {
+			images, err := parse(`{"events":[{"action":"push","target":{` +
+				`"mediaType":"application/vnd.docker.distribution.manifest.v2+json","repository":"platform/base",` +
+				`"url":"https://registry.example.com:5000/v2/platform/base/manifests/sha256:d89e"}}]}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(images).To(Equal([]string{"registry.example.com:5000/platform/base"}))
+		})
+
+		It("should ignore pulls", func() {
+			images, err := parse(fixtures.Pull)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(images).To(BeEmpty())
+		})
+
+		It("should fail on a malformed payload", func() {
+			_, err := parse(`{"events":`)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
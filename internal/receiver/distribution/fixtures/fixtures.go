@@ -0,0 +1,14 @@
+package fixtures
+
+import _ "embed"
+
+// Push is a sample Docker Distribution notification for a pushed image with a
+// layer and an image index.
+//
+//go:embed Push.json
+var Push string
+
+// Pull is a sample Docker Distribution notification for a pulled manifest.
+//
+//go:embed Pull.json
+var Pull string
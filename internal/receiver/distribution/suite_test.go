@@ -0,0 +1,13 @@
+package distribution
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestReceiverDistribution(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Distribution Receiver Suite")
+}
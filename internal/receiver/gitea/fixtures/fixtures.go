@@ -72,3 +72,21 @@ var HookIssueRenovateOpened string
 //
 //go:embed HookIssueRenovateClosed.json
 var HookIssueRenovateClosed string
+
+// HookIssueCommentRenovate is a sample Gitea issue_comment hook with a command written on the
+// Renovate dependency dashboard.
+//
+//go:embed HookIssueCommentRenovate.json
+var HookIssueCommentRenovate string
+
+// HookPullRequestCommentRegular is a sample Gitea issue_comment hook with a command written on a
+// regular pull request (no renovate markers).
+//
+//go:embed HookPullRequestCommentRegular.json
+var HookPullRequestCommentRegular string
+
+// HookRepositoryCreated is a sample Gitea repository hook for a repository created in an
+// organization.
+//
+//go:embed HookRepositoryCreated.json
+var HookRepositoryCreated string
@@ -24,12 +24,20 @@ func NewReceiver() *Receiver {
 
 //nolint:tagliatelle // Gitea API uses snake_case
 type pushRepository struct {
+	FullName      string `json:"full_name"`
 	DefaultBranch string `json:"default_branch"`
 }
 
+type repository struct {
+	FullName string `json:"full_name"`
+}
+
+//nolint:tagliatelle // Gitea API uses snake_case
 type pushPayload struct {
-	Ref        string         `json:"ref"`
-	Repository pushRepository `json:"repository"`
+	Ref          string                `json:"ref"`
+	Repository   pushRepository        `json:"repository"`
+	Commits      []receiver.PushCommit `json:"commits"`
+	TotalCommits int                   `json:"total_commits"`
 }
 
 type pullRequestUser struct {
@@ -46,6 +54,7 @@ type pullRequest struct {
 type pullRequestPayload struct {
 	Action      string          `json:"action"`
 	PullRequest pullRequest     `json:"pull_request"`
+	Repository  repository      `json:"repository"`
 	Sender      pullRequestUser `json:"sender"`
 }
 
@@ -55,9 +64,27 @@ type issue struct {
 }
 
 type issuePayload struct {
-	Action string          `json:"action"`
-	Issue  issue           `json:"issue"`
-	Sender pullRequestUser `json:"sender"`
+	Action     string          `json:"action"`
+	Issue      issue           `json:"issue"`
+	Repository repository      `json:"repository"`
+	Sender     pullRequestUser `json:"sender"`
+}
+
+type comment struct {
+	Body string          `json:"body"`
+	User pullRequestUser `json:"user"`
+}
+
+type commentPayload struct {
+	Action     string     `json:"action"`
+	Issue      issue      `json:"issue"`
+	Comment    comment    `json:"comment"`
+	Repository repository `json:"repository"`
+}
+
+type repositoryPayload struct {
+	Action     string     `json:"action"`
+	Repository repository `json:"repository"`
 }
 
 func (p *Receiver) Validate(req *http.Request, secretToken, body []byte) error {
@@ -87,6 +114,10 @@ func (p *Receiver) Parse(req *http.Request, body []byte) (receiver.ParseResult,
 		return p.parsePullRequestEvent(body)
 	case "issues":
 		return p.parseIssueEvent(body)
+	case "issue_comment":
+		return p.parseCommentEvent(body)
+	case "repository":
+		return p.parseRepositoryEvent(body)
 	default:
 		return receiver.ParseResult{}, nil
 	}
@@ -100,7 +131,17 @@ func (p *Receiver) parsePushEvent(body []byte) (receiver.ParseResult, error) {
 
 	expectedRef := "refs/heads/" + payload.Repository.DefaultBranch
 
-	return receiver.ParseResult{ShouldTrigger: payload.Ref == expectedRef}, nil
+	// The changed files are unknown if the payload omits commits.
+	var changedFiles []string
+	if payload.TotalCommits <= len(payload.Commits) {
+		changedFiles = receiver.ChangedFiles(payload.Commits)
+	}
+
+	return receiver.ParseResult{
+		ShouldTrigger: payload.Ref == expectedRef,
+		Repository:    payload.Repository.FullName,
+		ChangedFiles:  changedFiles,
+	}, nil
 }
 
 func (p *Receiver) parseIssueEvent(body []byte) (receiver.ParseResult, error) {
@@ -114,13 +155,47 @@ func (p *Receiver) parseIssueEvent(body []byte) (receiver.ParseResult, error) {
 	}
 
 	if !receiver.IsRenovateCheckboxChecked(payload.Issue.Body) {
-		return receiver.ParseResult{}, nil
+		return receiver.ParseResult{
+			User:       payload.Sender.Login,
+			Repository: payload.Repository.FullName,
+			Reason:     receiver.ReasonCheckboxUnchecked,
+		}, nil
 	}
 
 	return receiver.ParseResult{
 		ShouldTrigger:    true,
 		RequireUserCheck: true,
 		User:             payload.Sender.Login,
+		Repository:       payload.Repository.FullName,
+	}, nil
+}
+
+// parseCommentEvent parses comments written on issues and pull requests, Gitea
+// reports both as issue comments.
+func (p *Receiver) parseCommentEvent(body []byte) (receiver.ParseResult, error) {
+	var payload commentPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	if payload.Action != "created" {
+		return receiver.ParseResult{}, nil
+	}
+
+	if !receiver.IsRenovateContent(payload.Issue.Body) {
+		return receiver.ParseResult{
+			User:       payload.Comment.User.Login,
+			Repository: payload.Repository.FullName,
+			Reason:     receiver.ReasonNotRenovateComment,
+		}, nil
+	}
+
+	return receiver.ParseResult{
+		ShouldTrigger:    true,
+		RequireUserCheck: true,
+		User:             payload.Comment.User.Login,
+		Repository:       payload.Repository.FullName,
+		Comment:          payload.Comment.Body,
 	}, nil
 }
 
@@ -131,7 +206,7 @@ func (p *Receiver) parsePullRequestEvent(body []byte) (receiver.ParseResult, err
 	}
 
 	if payload.Action == "closed" && payload.PullRequest.Merged {
-		return receiver.ParseResult{ShouldTrigger: true}, nil
+		return receiver.ParseResult{ShouldTrigger: true, Repository: payload.Repository.FullName}, nil
 	}
 
 	if payload.Action != "edited" {
@@ -139,12 +214,32 @@ func (p *Receiver) parsePullRequestEvent(body []byte) (receiver.ParseResult, err
 	}
 
 	if !receiver.IsRenovateCheckboxChecked(payload.PullRequest.Description) {
-		return receiver.ParseResult{}, nil
+		return receiver.ParseResult{
+			User:       payload.Sender.Login,
+			Repository: payload.Repository.FullName,
+			Reason:     receiver.ReasonCheckboxUnchecked,
+		}, nil
 	}
 
 	return receiver.ParseResult{
 		ShouldTrigger:    true,
 		RequireUserCheck: true,
 		User:             payload.Sender.Login,
+		Repository:       payload.Repository.FullName,
 	}, nil
 }
+
+// parseRepositoryEvent parses repository lifecycle events of organization
+// webhooks. Gitea only sends them for created and deleted repositories.
+func (p *Receiver) parseRepositoryEvent(body []byte) (receiver.ParseResult, error) {
+	var payload repositoryPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	if payload.Action != "created" && payload.Action != "deleted" {
+		return receiver.ParseResult{}, nil
+	}
+
+	return receiver.ParseResult{Discover: true, Repository: payload.Repository.FullName}, nil
+}
@@ -7,6 +7,7 @@ import (
 	"encoding/hex"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -70,7 +71,11 @@ var _ = Describe("Gitea Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{ShouldTrigger: true}))
+			Expect(result).To(Equal(receiver.ParseResult{
+				ShouldTrigger: true,
+				Repository:    "gordon/hello-world",
+				ChangedFiles:  []string{"CHANGELOG.md", "app/controller/application.rb"},
+			}))
 		})
 
 		It("should NOT trigger a run for a push to a non-default branch", func() {
@@ -81,7 +86,7 @@ var _ = Describe("Gitea Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{Repository: "meisam/woodpecktester"}))
 		})
 
 		It("should NOT trigger a run for a tag event", func() {
@@ -92,7 +97,7 @@ var _ = Describe("Gitea Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{Repository: "gordon/hello-world"}))
 		})
 
 		It("should NOT trigger a run for a non-push event type", func() {
@@ -153,7 +158,11 @@ var _ = Describe("Gitea Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{
+				User:       "renovate[bot]",
+				Repository: "gordon/hello-world",
+				Reason:     receiver.ReasonCheckboxUnchecked,
+			}))
 		})
 
 		It("should NOT trigger a run for regular PR without renovate markers", func() {
@@ -164,7 +173,11 @@ var _ = Describe("Gitea Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{
+				User:       "contributor",
+				Repository: "gordon/hello-world",
+				Reason:     receiver.ReasonCheckboxUnchecked,
+			}))
 		})
 
 		It("should NOT trigger a run for renovate PR with unchecked rebase checkbox", func() {
@@ -175,7 +188,11 @@ var _ = Describe("Gitea Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{
+				User:       "renovate[bot]",
+				Repository: "gordon/hello-world",
+				Reason:     receiver.ReasonCheckboxUnchecked,
+			}))
 		})
 
 		It("should trigger a run for a Renovate dependency dashboard issue with a checked checkbox", func() {
@@ -199,7 +216,11 @@ var _ = Describe("Gitea Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{
+				User:       "gordon",
+				Repository: "gordon/hello-world",
+				Reason:     receiver.ReasonCheckboxUnchecked,
+			}))
 		})
 
 		It("should NOT trigger a run for pull_request with action 'opened'", func() {
@@ -232,7 +253,7 @@ var _ = Describe("Gitea Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{ShouldTrigger: true}))
+			Expect(result).To(Equal(receiver.ParseResult{ShouldTrigger: true, Repository: "gordon/hello-world"}))
 		})
 
 		It("should NOT trigger a run for issues with action 'opened'", func() {
@@ -256,5 +277,74 @@ var _ = Describe("Gitea Webhook Receiver", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).To(Equal(receiver.ParseResult{}))
 		})
+
+		It("should pass a comment on a Renovate dependency dashboard issue", func() {
+			body := []byte(fixtures.HookIssueCommentRenovate)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+			req.Header.Set("X-Gitea-Event", "issue_comment")
+
+			result, err := Receiver.Parse(req, body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(receiver.ParseResult{
+				ShouldTrigger:    true,
+				RequireUserCheck: true,
+				User:             "gordon",
+				Repository:       "gordon/hello-world",
+				Comment:          "/renovate debug",
+			}))
+		})
+
+		It("should NOT pass a comment on a regular pull request", func() {
+			body := []byte(fixtures.HookPullRequestCommentRegular)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+			req.Header.Set("X-Gitea-Event", "issue_comment")
+
+			result, err := Receiver.Parse(req, body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(receiver.ParseResult{
+				User:       "contributor",
+				Repository: "gordon/hello-world",
+				Reason:     receiver.ReasonNotRenovateComment,
+			}))
+		})
+
+		It("should NOT pass an edited comment", func() {
+			body := []byte(strings.Replace(fixtures.HookIssueCommentRenovate, `"created"`, `"edited"`, 1))
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+			req.Header.Set("X-Gitea-Event", "issue_comment")
+
+			result, err := Receiver.Parse(req, body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(receiver.ParseResult{}))
+		})
+
+		DescribeTable("should request a discovery for a repository lifecycle event",
+			func(action string) {
+				body := []byte(strings.Replace(fixtures.HookRepositoryCreated, `"created"`, `"`+action+`"`, 1))
+
+				req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+				req.Header.Set("X-Gitea-Event", "repository")
+
+				result, err := Receiver.Parse(req, body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(receiver.ParseResult{Discover: true, Repository: "gordon/new-service"}))
+			},
+			Entry("created", "created"),
+			Entry("deleted", "deleted"),
+		)
+
+		It("should NOT request a discovery for an edited repository", func() {
+			body := []byte(strings.Replace(fixtures.HookRepositoryCreated, `"created"`, `"edited"`, 1))
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+			req.Header.Set("X-Gitea-Event", "repository")
+
+			result, err := Receiver.Parse(req, body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(receiver.ParseResult{}))
+		})
 	})
 })
@@ -17,7 +17,13 @@ var (
 	ErrMissingSignature = errors.New("missing X-Hub-Signature-256 header")
 )
 
-const signatureParts = 2
+const (
+	signatureParts = 2
+
+	// maxPushCommits is the maximum number of commits listed in a push webhook
+	// payload, further commits of the push are omitted.
+	maxPushCommits = 2048
+)
 
 type Receiver struct{}
 
@@ -27,12 +33,32 @@ func NewReceiver() *Receiver {
 
 //nolint:tagliatelle // GitHub API uses snake_case
 type pushRepository struct {
+	FullName      string `json:"full_name"`
 	DefaultBranch string `json:"default_branch"`
 }
 
+type repository struct {
+	FullName string `json:"full_name"`
+}
+
 type pushPayload struct {
-	Ref        string         `json:"ref"`
-	Repository pushRepository `json:"repository"`
+	Ref        string                `json:"ref"`
+	Repository pushRepository        `json:"repository"`
+	Commits    []receiver.PushCommit `json:"commits"`
+	// Size is the number of pushed commits. It is not part of every push
+	// payload.
+	Size int `json:"size"`
+}
+
+// complete reports whether the payload lists all pushed commits. Without the
+// size of the push, a payload listing the maximum number of commits may have
+// been truncated and is not considered complete.
+func (p pushPayload) complete() bool {
+	if p.Size > 0 {
+		return p.Size <= len(p.Commits)
+	}
+
+	return len(p.Commits) < maxPushCommits
 }
 
 type pullRequestUser struct {
@@ -48,6 +74,7 @@ type pullRequest struct {
 type pullRequestPayload struct {
 	Action      string          `json:"action"`
 	PullRequest pullRequest     `json:"pull_request"`
+	Repository  repository      `json:"repository"`
 	Sender      pullRequestUser `json:"sender"`
 }
 
@@ -56,9 +83,27 @@ type issue struct {
 }
 
 type issuePayload struct {
-	Action string          `json:"action"`
-	Issue  issue           `json:"issue"`
-	Sender pullRequestUser `json:"sender"`
+	Action     string          `json:"action"`
+	Issue      issue           `json:"issue"`
+	Repository repository      `json:"repository"`
+	Sender     pullRequestUser `json:"sender"`
+}
+
+type comment struct {
+	Body string          `json:"body"`
+	User pullRequestUser `json:"user"`
+}
+
+type commentPayload struct {
+	Action     string     `json:"action"`
+	Issue      issue      `json:"issue"`
+	Comment    comment    `json:"comment"`
+	Repository repository `json:"repository"`
+}
+
+type repositoryPayload struct {
+	Action     string     `json:"action"`
+	Repository repository `json:"repository"`
 }
 
 func (p *Receiver) Validate(req *http.Request, secretToken, body []byte) error {
@@ -93,6 +138,10 @@ func (p *Receiver) Parse(req *http.Request, body []byte) (receiver.ParseResult,
 		return p.parsePullRequestEvent(body)
 	case "issues":
 		return p.parseIssueEvent(body)
+	case "issue_comment":
+		return p.parseCommentEvent(body)
+	case "repository":
+		return p.parseRepositoryEvent(body)
 	default:
 		return receiver.ParseResult{}, nil
 	}
@@ -106,7 +155,17 @@ func (p *Receiver) parsePushEvent(body []byte) (receiver.ParseResult, error) {
 
 	expectedRef := "refs/heads/" + payload.Repository.DefaultBranch
 
-	return receiver.ParseResult{ShouldTrigger: payload.Ref == expectedRef}, nil
+	// The changed files are unknown if the payload omits commits.
+	var changedFiles []string
+	if payload.complete() {
+		changedFiles = receiver.ChangedFiles(payload.Commits)
+	}
+
+	return receiver.ParseResult{
+		ShouldTrigger: payload.Ref == expectedRef,
+		Repository:    payload.Repository.FullName,
+		ChangedFiles:  changedFiles,
+	}, nil
 }
 
 func (p *Receiver) parseIssueEvent(body []byte) (receiver.ParseResult, error) {
@@ -120,16 +179,66 @@ func (p *Receiver) parseIssueEvent(body []byte) (receiver.ParseResult, error) {
 	}
 
 	if !receiver.IsRenovateCheckboxChecked(payload.Issue.Body) {
-		return receiver.ParseResult{}, nil
+		return receiver.ParseResult{
+			User:       payload.Sender.Login,
+			Repository: payload.Repository.FullName,
+			Reason:     receiver.ReasonCheckboxUnchecked,
+		}, nil
 	}
 
 	return receiver.ParseResult{
 		ShouldTrigger:    true,
 		RequireUserCheck: true,
 		User:             payload.Sender.Login,
+		Repository:       payload.Repository.FullName,
+	}, nil
+}
+
+// parseCommentEvent parses comments written on issues and pull requests, GitHub
+// reports both as issue comments.
+func (p *Receiver) parseCommentEvent(body []byte) (receiver.ParseResult, error) {
+	var payload commentPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	if payload.Action != "created" {
+		return receiver.ParseResult{}, nil
+	}
+
+	if !receiver.IsRenovateContent(payload.Issue.Body) {
+		return receiver.ParseResult{
+			User:       payload.Comment.User.Login,
+			Repository: payload.Repository.FullName,
+			Reason:     receiver.ReasonNotRenovateComment,
+		}, nil
+	}
+
+	return receiver.ParseResult{
+		ShouldTrigger:    true,
+		RequireUserCheck: true,
+		User:             payload.Comment.User.Login,
+		Repository:       payload.Repository.FullName,
+		Comment:          payload.Comment.Body,
 	}, nil
 }
 
+// parseRepositoryEvent parses repository lifecycle events of organization
+// webhooks that change the repositories found by a discovery.
+func (p *Receiver) parseRepositoryEvent(body []byte) (receiver.ParseResult, error) {
+	var payload repositoryPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	switch payload.Action {
+	case "created", "deleted", "renamed", "transferred", "archived", "unarchived":
+		return receiver.ParseResult{Discover: true, Repository: payload.Repository.FullName}, nil
+	default:
+		return receiver.ParseResult{}, nil
+	}
+}
+
 func (p *Receiver) parsePullRequestEvent(body []byte) (receiver.ParseResult, error) {
 	var payload pullRequestPayload
 	if err := json.Unmarshal(body, &payload); err != nil {
@@ -137,7 +246,7 @@ func (p *Receiver) parsePullRequestEvent(body []byte) (receiver.ParseResult, err
 	}
 
 	if payload.Action == "closed" && payload.PullRequest.Merged {
-		return receiver.ParseResult{ShouldTrigger: true}, nil
+		return receiver.ParseResult{ShouldTrigger: true, Repository: payload.Repository.FullName}, nil
 	}
 
 	if payload.Action != "edited" {
@@ -145,12 +254,17 @@ func (p *Receiver) parsePullRequestEvent(body []byte) (receiver.ParseResult, err
 	}
 
 	if !receiver.IsRenovateCheckboxChecked(payload.PullRequest.Body) {
-		return receiver.ParseResult{}, nil
+		return receiver.ParseResult{
+			User:       payload.Sender.Login,
+			Repository: payload.Repository.FullName,
+			Reason:     receiver.ReasonCheckboxUnchecked,
+		}, nil
 	}
 
 	return receiver.ParseResult{
 		ShouldTrigger:    true,
 		RequireUserCheck: true,
 		User:             payload.Sender.Login,
+		Repository:       payload.Repository.FullName,
 	}, nil
 }
@@ -5,8 +5,10 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -88,9 +90,48 @@ var _ = Describe("GitHub Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{ShouldTrigger: true}))
+			Expect(result).To(Equal(receiver.ParseResult{
+				ShouldTrigger: true,
+				Repository:    "woodpecker-ci/woodpecker",
+				ChangedFiles: []string{
+					"pipeline/shared/replace_secrets.go",
+					"pipeline/shared/replace_secrets_test.go",
+				},
+			}))
 		})
 
+		DescribeTable("should only report the changed files of a push listing all commits",
+			func(commits, size int, complete bool) {
+				payload := pushPayload{
+					Ref:        "refs/heads/main",
+					Repository: pushRepository{FullName: "org/repo", DefaultBranch: "main"},
+					Commits:    make([]receiver.PushCommit, commits),
+					Size:       size,
+				}
+				payload.Commits[0].Modified = []string{"go.mod"}
+
+				body, err := json.Marshal(payload)
+				Expect(err).NotTo(HaveOccurred())
+
+				req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+				req.Header.Set("X-GitHub-Event", "push")
+
+				result, err := Receiver.Parse(req, body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result.ShouldTrigger).To(BeTrue())
+
+				if complete {
+					Expect(result.ChangedFiles).To(Equal([]string{"go.mod"}))
+				} else {
+					Expect(result.ChangedFiles).To(BeNil())
+				}
+			},
+			Entry("below the commit limit", maxPushCommits-1, 0, true),
+			Entry("at the commit limit", maxPushCommits, 0, false),
+			Entry("at the commit limit with the push size", maxPushCommits, maxPushCommits, true),
+			Entry("with more pushed commits than listed", 20, 21, false),
+		)
+
 		It("should NOT trigger a run for a push to a non-default branch", func() {
 			body := []byte(fixtures.HookPushBranch)
 
@@ -99,7 +140,7 @@ var _ = Describe("GitHub Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{Repository: "meisam/woodpecktester"}))
 		})
 
 		It("should NOT trigger a run for a tag event", func() {
@@ -110,7 +151,7 @@ var _ = Describe("GitHub Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{Repository: "6543/test_ci_tmp"}))
 		})
 
 		It("should NOT trigger a run for a non-push event type", func() {
@@ -171,7 +212,11 @@ var _ = Describe("GitHub Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{
+				User:       "renovate[bot]",
+				Repository: "gordon/hello-world",
+				Reason:     receiver.ReasonCheckboxUnchecked,
+			}))
 		})
 
 		It("should NOT trigger a run for regular PR without renovate markers", func() {
@@ -182,7 +227,11 @@ var _ = Describe("GitHub Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{
+				User:       "contributor",
+				Repository: "gordon/hello-world",
+				Reason:     receiver.ReasonCheckboxUnchecked,
+			}))
 		})
 
 		It("should NOT trigger a run for renovate PR with unchecked rebase checkbox", func() {
@@ -193,7 +242,11 @@ var _ = Describe("GitHub Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{
+				User:       "renovate[bot]",
+				Repository: "gordon/hello-world",
+				Reason:     receiver.ReasonCheckboxUnchecked,
+			}))
 		})
 
 		It("should trigger a run for a Renovate dependency dashboard issue with a checked checkbox", func() {
@@ -217,7 +270,11 @@ var _ = Describe("GitHub Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{}))
+			Expect(result).To(Equal(receiver.ParseResult{
+				User:       "gordon",
+				Repository: "gordon/hello-world",
+				Reason:     receiver.ReasonCheckboxUnchecked,
+			}))
 		})
 
 		It("should NOT trigger a run for pull_request with action 'opened'", func() {
@@ -250,7 +307,7 @@ var _ = Describe("GitHub Webhook Receiver", func() {
 
 			result, err := Receiver.Parse(req, body)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(receiver.ParseResult{ShouldTrigger: true}))
+			Expect(result).To(Equal(receiver.ParseResult{ShouldTrigger: true, Repository: "gordon/hello-world"}))
 		})
 
 		It("should NOT trigger a run for issues with action 'opened'", func() {
@@ -274,5 +331,75 @@ var _ = Describe("GitHub Webhook Receiver", func() {
 			Expect(err).NotTo(HaveOccurred())
 			Expect(result).To(Equal(receiver.ParseResult{}))
 		})
+
+		It("should pass a comment on a Renovate dependency dashboard issue", func() {
+			body := []byte(fixtures.HookIssueCommentRenovate)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+			req.Header.Set("X-GitHub-Event", "issue_comment")
+
+			result, err := Receiver.Parse(req, body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(receiver.ParseResult{
+				ShouldTrigger:    true,
+				RequireUserCheck: true,
+				User:             "gordon",
+				Repository:       "gordon/hello-world",
+				Comment:          "Please refresh the updates.\r\n\r\n/renovate run",
+			}))
+		})
+
+		It("should NOT pass a comment on a regular pull request", func() {
+			body := []byte(fixtures.HookPullRequestCommentRegular)
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+			req.Header.Set("X-GitHub-Event", "issue_comment")
+
+			result, err := Receiver.Parse(req, body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(receiver.ParseResult{
+				User:       "contributor",
+				Repository: "gordon/hello-world",
+				Reason:     receiver.ReasonNotRenovateComment,
+			}))
+		})
+
+		It("should NOT pass an edited comment", func() {
+			body := []byte(strings.Replace(fixtures.HookIssueCommentRenovate, `"created"`, `"edited"`, 1))
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+			req.Header.Set("X-GitHub-Event", "issue_comment")
+
+			result, err := Receiver.Parse(req, body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(receiver.ParseResult{}))
+		})
+
+		DescribeTable("should request a discovery for a repository lifecycle event",
+			func(action string) {
+				body := []byte(strings.Replace(fixtures.HookRepositoryCreated, `"created"`, `"`+action+`"`, 1))
+
+				req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+				req.Header.Set("X-GitHub-Event", "repository")
+
+				result, err := Receiver.Parse(req, body)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(result).To(Equal(receiver.ParseResult{Discover: true, Repository: "gordon/new-service"}))
+			},
+			Entry("created", "created"),
+			Entry("renamed", "renamed"),
+			Entry("archived", "archived"),
+		)
+
+		It("should NOT request a discovery for an edited repository", func() {
+			body := []byte(strings.Replace(fixtures.HookRepositoryCreated, `"created"`, `"edited"`, 1))
+
+			req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(body))
+			req.Header.Set("X-GitHub-Event", "repository")
+
+			result, err := Receiver.Parse(req, body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(receiver.ParseResult{}))
+		})
 	})
 })
@@ -43,6 +43,10 @@ func (p *Receiver) Parse(req *http.Request, body []byte) (receiver.ParseResult,
 		return p.parsePushEvent(body)
 	case "Merge Request Hook", "Issue Hook":
 		return p.parseEditableEvent(body)
+	case "Note Hook":
+		return p.parseNoteEvent(body)
+	case "Project Hook", "System Hook":
+		return p.parseProjectEvent(body)
 	default:
 		return receiver.ParseResult{}, nil
 	}
@@ -52,8 +56,11 @@ func (p *Receiver) Parse(req *http.Request, body []byte) (receiver.ParseResult,
 type pushPayload struct {
 	Ref     string `json:"ref"`
 	Project struct {
-		DefaultBranch string `json:"default_branch"`
+		PathWithNamespace string `json:"path_with_namespace"`
+		DefaultBranch     string `json:"default_branch"`
 	} `json:"project"`
+	Commits           []receiver.PushCommit `json:"commits"`
+	TotalCommitsCount int                   `json:"total_commits_count"`
 }
 
 func (p *Receiver) parsePushEvent(body []byte) (receiver.ParseResult, error) {
@@ -62,8 +69,16 @@ func (p *Receiver) parsePushEvent(body []byte) (receiver.ParseResult, error) {
 		return receiver.ParseResult{}, err
 	}
 
+	// The changed files are unknown if the payload omits commits.
+	var changedFiles []string
+	if payload.TotalCommitsCount <= len(payload.Commits) {
+		changedFiles = receiver.ChangedFiles(payload.Commits)
+	}
+
 	return receiver.ParseResult{
 		ShouldTrigger: payload.Ref == "refs/heads/"+payload.Project.DefaultBranch,
+		Repository:    payload.Project.PathWithNamespace,
+		ChangedFiles:  changedFiles,
 	}, nil
 }
 
@@ -76,6 +91,9 @@ type editablePayload struct {
 	User struct {
 		Username string `json:"username"`
 	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
 }
 
 func (p *Receiver) parseEditableEvent(body []byte) (receiver.ParseResult, error) {
@@ -85,17 +103,109 @@ func (p *Receiver) parseEditableEvent(body []byte) (receiver.ParseResult, error)
 	}
 
 	if payload.ObjectAttributes.Action == "merge" {
-		return receiver.ParseResult{ShouldTrigger: true}, nil
+		return receiver.ParseResult{ShouldTrigger: true, Repository: payload.Project.PathWithNamespace}, nil
 	}
 
-	if payload.ObjectAttributes.Action != "update" ||
-		!receiver.IsRenovateCheckboxChecked(payload.ObjectAttributes.Description) {
+	if payload.ObjectAttributes.Action != "update" {
 		return receiver.ParseResult{}, nil
 	}
 
+	if !receiver.IsRenovateCheckboxChecked(payload.ObjectAttributes.Description) {
+		return receiver.ParseResult{
+			User:       payload.User.Username,
+			Repository: payload.Project.PathWithNamespace,
+			Reason:     receiver.ReasonCheckboxUnchecked,
+		}, nil
+	}
+
 	return receiver.ParseResult{
 		ShouldTrigger:    true,
 		RequireUserCheck: true,
 		User:             payload.User.Username,
+		Repository:       payload.Project.PathWithNamespace,
 	}, nil
 }
+
+//nolint:tagliatelle // GitLab API uses snake_case.
+type notePayload struct {
+	ObjectAttributes struct {
+		Action       string `json:"action"`
+		Note         string `json:"note"`
+		NoteableType string `json:"noteable_type"`
+	} `json:"object_attributes"`
+	User struct {
+		Username string `json:"username"`
+	} `json:"user"`
+	Project struct {
+		PathWithNamespace string `json:"path_with_namespace"`
+	} `json:"project"`
+	MergeRequest struct {
+		Description string `json:"description"`
+	} `json:"merge_request"`
+	Issue struct {
+		Description string `json:"description"`
+	} `json:"issue"`
+}
+
+// parseNoteEvent parses comments written on merge requests and issues. Older
+// GitLab versions omit the action of note events.
+func (p *Receiver) parseNoteEvent(body []byte) (receiver.ParseResult, error) {
+	payload := &notePayload{}
+	if err := json.Unmarshal(body, payload); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	action := payload.ObjectAttributes.Action
+	if action != "" && action != "create" {
+		return receiver.ParseResult{}, nil
+	}
+
+	var description string
+
+	switch payload.ObjectAttributes.NoteableType {
+	case "MergeRequest":
+		description = payload.MergeRequest.Description
+	case "Issue":
+		description = payload.Issue.Description
+	default:
+		return receiver.ParseResult{}, nil
+	}
+
+	if !receiver.IsRenovateContent(description) {
+		return receiver.ParseResult{
+			User:       payload.User.Username,
+			Repository: payload.Project.PathWithNamespace,
+			Reason:     receiver.ReasonNotRenovateComment,
+		}, nil
+	}
+
+	return receiver.ParseResult{
+		ShouldTrigger:    true,
+		RequireUserCheck: true,
+		User:             payload.User.Username,
+		Repository:       payload.Project.PathWithNamespace,
+		Comment:          payload.ObjectAttributes.Note,
+	}, nil
+}
+
+//nolint:tagliatelle // GitLab API uses snake_case.
+type projectPayload struct {
+	EventName         string `json:"event_name"`
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+// parseProjectEvent parses project lifecycle events sent by group hooks with
+// project events enabled and by system hooks.
+func (p *Receiver) parseProjectEvent(body []byte) (receiver.ParseResult, error) {
+	payload := &projectPayload{}
+	if err := json.Unmarshal(body, payload); err != nil {
+		return receiver.ParseResult{}, err
+	}
+
+	switch payload.EventName {
+	case "project_create", "project_destroy", "project_rename", "project_transfer", "project_update":
+		return receiver.ParseResult{Discover: true, Repository: payload.PathWithNamespace}, nil
+	default:
+		return receiver.ParseResult{}, nil
+	}
+}
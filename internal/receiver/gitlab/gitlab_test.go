@@ -58,6 +58,22 @@ var _ = Describe("GitLab Webhook Receiver", func() {
 		Entry("tag", "refs/tags/v1.0.0", receiver.ParseResult{}),
 	)
 
+	DescribeTable(
+		"lists the changed files of push hooks",
+		func(totalCommits string, expected []string) {
+			body := []byte(`{"ref":"refs/heads/main","project":{"default_branch":"main"},` +
+				`"commits":[{"added":["go.mod"],"modified":["main.go"],"removed":[]},` +
+				`{"added":[],"modified":["go.mod"],"removed":["README.md"]}],` +
+				`"total_commits_count":` + totalCommits + `}`)
+
+			result, err := gitlabReceiver.Parse(webhookRequest("Push Hook"), body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.ChangedFiles).To(Equal(expected))
+		},
+		Entry("all commits listed", "2", []string{"README.md", "go.mod", "main.go"}),
+		Entry("commits omitted", "30", nil),
+	)
+
 	DescribeTable(
 		"parses editable hooks",
 		func(event, action, description string, expected receiver.ParseResult) {
@@ -95,7 +111,7 @@ var _ = Describe("GitLab Webhook Receiver", func() {
 			"Merge Request Hook",
 			"update",
 			"## Detected Dependencies\n- [ ] update",
-			receiver.ParseResult{},
+			receiver.ParseResult{User: "renovate-bot", Reason: receiver.ReasonCheckboxUnchecked},
 		),
 		Entry(
 			"updated issue with checked checkbox",
@@ -106,7 +122,79 @@ var _ = Describe("GitLab Webhook Receiver", func() {
 		),
 		Entry("opened issue", "Issue Hook", "open", renovateDescription, receiver.ParseResult{}),
 		Entry("closed issue", "Issue Hook", "close", renovateDescription, receiver.ParseResult{}),
-		Entry("unchecked issue", "Issue Hook", "update", "## Detected Dependencies\n- [ ] update", receiver.ParseResult{}),
+		Entry(
+			"unchecked issue",
+			"Issue Hook",
+			"update",
+			"## Detected Dependencies\n- [ ] update",
+			receiver.ParseResult{User: "renovate-bot", Reason: receiver.ReasonCheckboxUnchecked},
+		),
+	)
+
+	DescribeTable(
+		"parses note hooks",
+		func(action, noteableType, description string, expected receiver.ParseResult) {
+			body := []byte(`{"object_attributes":{"action":"` + action + `","note":"/renovate run",` +
+				`"noteable_type":"` + noteableType + `"},"user":{"username":"maintainer"},` +
+				`"merge_request":{"description":` + jsonString(description) + `},` +
+				`"issue":{"description":` + jsonString(description) + `}}`)
+
+			result, err := gitlabReceiver.Parse(webhookRequest("Note Hook"), body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry(
+			"note on a Renovate merge request",
+			"create",
+			"MergeRequest",
+			renovateDescription,
+			receiver.ParseResult{
+				ShouldTrigger: true, RequireUserCheck: true, User: "maintainer", Comment: "/renovate run",
+			},
+		),
+		Entry(
+			"note on the Dependency Dashboard without action",
+			"",
+			"Issue",
+			renovateDescription,
+			receiver.ParseResult{
+				ShouldTrigger: true, RequireUserCheck: true, User: "maintainer", Comment: "/renovate run",
+			},
+		),
+		Entry(
+			"note on a regular merge request",
+			"create",
+			"MergeRequest",
+			"Add a feature",
+			receiver.ParseResult{User: "maintainer", Reason: receiver.ReasonNotRenovateComment},
+		),
+		Entry("updated note", "update", "MergeRequest", renovateDescription, receiver.ParseResult{}),
+		Entry("note on a commit", "create", "Commit", renovateDescription, receiver.ParseResult{}),
+	)
+
+	DescribeTable(
+		"parses project lifecycle events",
+		func(event, eventName string, expected receiver.ParseResult) {
+			body := []byte(`{"event_name":"` + eventName + `","path_with_namespace":"group/new-service",` +
+				`"old_path_with_namespace":"group/old-service"}`)
+
+			result, err := gitlabReceiver.Parse(webhookRequest(event), body)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(expected))
+		},
+		Entry(
+			"created project of a group hook",
+			"Project Hook",
+			"project_create",
+			receiver.ParseResult{Discover: true, Repository: "group/new-service"},
+		),
+		Entry(
+			"renamed project of a system hook",
+			"System Hook",
+			"project_rename",
+			receiver.ParseResult{Discover: true, Repository: "group/new-service"},
+		),
+		Entry("user created by a system hook", "System Hook", "user_create", receiver.ParseResult{}),
 	)
 
 	It("accepts an unknown event without triggering", func() {
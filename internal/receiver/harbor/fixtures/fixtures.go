@@ -0,0 +1,13 @@
+package fixtures
+
+import _ "embed"
+
+// PushArtifact is a sample Harbor webhook for a pushed artifact.
+//
+//go:embed PushArtifact.json
+var PushArtifact string
+
+// DeleteArtifact is a sample Harbor webhook for a deleted artifact.
+//
+//go:embed DeleteArtifact.json
+var DeleteArtifact string
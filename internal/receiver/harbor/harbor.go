@@ -0,0 +1,55 @@
+package harbor
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// Event types of Harbor for pushed artifacts. Harbor 1.x names the event
+// pushImage.
+const (
+	eventPushArtifact = "PUSH_ARTIFACT"
+	eventPushImage    = "pushImage"
+)
+
+type Receiver struct{}
+
+func NewReceiver() *Receiver {
+	return &Receiver{}
+}
+
+//nolint:tagliatelle // Harbor API uses snake_case
+type resource struct {
+	ResourceURL string `json:"resource_url"`
+}
+
+//nolint:tagliatelle // Harbor API uses snake_case
+type payload struct {
+	Type      string `json:"type"`
+	EventData struct {
+		Resources []resource `json:"resources"`
+	} `json:"event_data"`
+}
+
+// Parse returns the images of the artifacts pushed to Harbor. The resource URL
+// of an artifact is the image reference including the registry host.
+func (p *Receiver) Parse(_ *http.Request, body []byte) ([]string, error) {
+	payload := &payload{}
+	if err := json.Unmarshal(body, payload); err != nil {
+		return nil, err
+	}
+
+	if payload.Type != eventPushArtifact && payload.Type != eventPushImage {
+		return nil, nil
+	}
+
+	images := make([]string, 0, len(payload.EventData.Resources))
+
+	for _, res := range payload.EventData.Resources {
+		if res.ResourceURL != "" {
+			images = append(images, res.ResourceURL)
+		}
+	}
+
+	return images, nil
+}
@@ -0,0 +1,52 @@
+package harbor
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/receiver/harbor/fixtures"
+)
+
+var _ = Describe("Harbor Registry Receiver", func() {
+	var Receiver *Receiver
+
+	BeforeEach(func() {
+		Receiver = NewReceiver()
+	})
+
+	Describe("Parse", func() {
+		parse := func(body string) ([]string, error) {
+			req := httptest.NewRequest(http.MethodPost, "/registry", strings.NewReader(body))
+
+			return Receiver.Parse(req, []byte(body))
+		}
+
+		It("should return the images of pushed artifacts", func() {
+			images, err := parse(fixtures.PushArtifact)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(images).To(Equal([]string{"harbor.example.com/library/base:1.3"}))
+		})
+
+		It("should support the push event of Harbor 1.x", func() {
+			images, err := parse(`{"type":"pushImage","event_data":{"resources":[` +
+				`{"resource_url":"harbor.example.com/library/base:1.3"}]}}`)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(images).To(Equal([]string{"harbor.example.com/library/base:1.3"}))
+		})
+
+		It("should ignore other events", func() {
+			images, err := parse(fixtures.DeleteArtifact)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(images).To(BeEmpty())
+		})
+
+		It("should fail on a malformed payload", func() {
+			_, err := parse(`{"type":`)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,13 @@
+package harbor
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestReceiverHarbor(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Harbor Receiver Suite")
+}
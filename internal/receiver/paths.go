@@ -0,0 +1,142 @@
+package receiver
+
+import (
+	"path"
+	"slices"
+	"strings"
+)
+
+// DefaultPathPatterns match the manifests and lock files of common package
+// managers and the Renovate configuration files.
+var DefaultPathPatterns = []string{
+	// Renovate
+	"renovate.json",
+	"renovate.json5",
+	".renovaterc",
+	".renovaterc.json",
+	".renovaterc.json5",
+	// JavaScript
+	"package.json",
+	"package-lock.json",
+	"npm-shrinkwrap.json",
+	"yarn.lock",
+	"pnpm-lock.yaml",
+	"pnpm-workspace.yaml",
+	"bun.lockb",
+	"bun.lock",
+	// Go
+	"go.mod",
+	"go.sum",
+	"go.work",
+	// Rust
+	"Cargo.toml",
+	"Cargo.lock",
+	// Java
+	"pom.xml",
+	"build.gradle",
+	"build.gradle.kts",
+	"settings.gradle",
+	"settings.gradle.kts",
+	"gradle.properties",
+	"libs.versions.toml",
+	"gradle-wrapper.properties",
+	// Python
+	"requirements*.txt",
+	"pyproject.toml",
+	"poetry.lock",
+	"uv.lock",
+	"Pipfile",
+	"Pipfile.lock",
+	"setup.py",
+	"setup.cfg",
+	// Ruby
+	"Gemfile",
+	"Gemfile.lock",
+	"*.gemspec",
+	// PHP
+	"composer.json",
+	"composer.lock",
+	// .NET
+	"*.csproj",
+	"*.fsproj",
+	"packages.config",
+	"Directory.Packages.props",
+	"global.json",
+	// Containers and deployments
+	"Dockerfile",
+	"*.dockerfile",
+	"Containerfile",
+	"docker-compose*.yml",
+	"docker-compose*.yaml",
+	"compose*.yml",
+	"compose*.yaml",
+	"Chart.yaml",
+	"Chart.lock",
+	"values*.yaml",
+	"kustomization.yaml",
+	// Infrastructure
+	"*.tf",
+	".terraform.lock.hcl",
+	".pre-commit-config.yaml",
+	".tool-versions",
+	".nvmrc",
+	".node-version",
+	".python-version",
+	".ruby-version",
+	".go-version",
+	// CI
+	".github/workflows/*.yml",
+	".github/workflows/*.yaml",
+	".github/actions/*/action.yml",
+	".github/actions/*/action.yaml",
+	".gitea/workflows/*.yml",
+	".gitea/workflows/*.yaml",
+	".gitlab-ci.yml",
+	".woodpecker.yml",
+	".woodpecker/*.yml",
+	".woodpecker/*.yaml",
+	"azure-pipelines.yml",
+}
+
+// PushCommit holds the files changed by a commit of a push event. GitHub,
+// Gitea and GitLab share the same payload layout.
+type PushCommit struct {
+	Added    []string `json:"added"`
+	Modified []string `json:"modified"`
+	Removed  []string `json:"removed"`
+}
+
+// ChangedFiles returns the sorted distinct files changed by the commits.
+func ChangedFiles(commits []PushCommit) []string {
+	var files []string
+
+	for _, commit := range commits {
+		files = append(files, commit.Added...)
+		files = append(files, commit.Modified...)
+		files = append(files, commit.Removed...)
+	}
+
+	slices.Sort(files)
+
+	return slices.Compact(files)
+}
+
+// MatchesPathPatterns reports whether any of the files matches one of the glob
+// patterns. Patterns without a slash are matched against the file name in any
+// directory, others against the full path.
+func MatchesPathPatterns(files, patterns []string) bool {
+	for _, file := range files {
+		for _, pattern := range patterns {
+			name := file
+			if !strings.Contains(pattern, "/") {
+				name = path.Base(file)
+			}
+
+			if ok, err := path.Match(pattern, name); err == nil && ok {
+				return true
+			}
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,41 @@
+package receiver_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/thegeeklab/renovate-operator/internal/receiver"
+)
+
+var _ = Describe("Path Filter", func() {
+	Describe("ChangedFiles", func() {
+		It("should return the distinct files of all commits", func() {
+			commits := []receiver.PushCommit{
+				{Added: []string{"go.mod"}, Modified: []string{"main.go"}},
+				{Modified: []string{"go.mod"}, Removed: []string{"README.md"}},
+			}
+
+			Expect(receiver.ChangedFiles(commits)).To(Equal([]string{"README.md", "go.mod", "main.go"}))
+		})
+
+		It("should return nil without commits", func() {
+			Expect(receiver.ChangedFiles(nil)).To(BeNil())
+		})
+	})
+
+	DescribeTable("MatchesPathPatterns",
+		func(files []string, patterns []string, expected bool) {
+			Expect(receiver.MatchesPathPatterns(files, patterns)).To(Equal(expected))
+		},
+		Entry("file name in root directory", []string{"package.json"}, receiver.DefaultPathPatterns, true),
+		Entry("file name in subdirectory", []string{"services/api/go.mod"}, receiver.DefaultPathPatterns, true),
+		Entry("file name wildcard", []string{"requirements-dev.txt"}, receiver.DefaultPathPatterns, true),
+		Entry("renovate config", []string{".github/renovate.json"}, receiver.DefaultPathPatterns, true),
+		Entry("path pattern", []string{".github/workflows/ci.yml"}, receiver.DefaultPathPatterns, true),
+		Entry("path pattern in other directory",
+			[]string{"docs/workflows/ci.yml"}, []string{".github/workflows/*.yml"}, false),
+		Entry("unrelated files", []string{"README.md", "main.go"}, receiver.DefaultPathPatterns, false),
+		Entry("custom patterns", []string{"deps/versions.txt"}, []string{"deps/*.txt"}, true),
+		Entry("no files", nil, receiver.DefaultPathPatterns, false),
+	)
+})
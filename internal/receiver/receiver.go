@@ -1,6 +1,13 @@
 package receiver
 
-import "net/http"
+import (
+	"net/http"
+	"time"
+)
+
+// ReasonCheckboxUnchecked is the reason of an edit event ignored because the
+// Renovate checkbox is not checked.
+const ReasonCheckboxUnchecked = "renovate checkbox not checked"
 
 // ParseResult holds the outcome of parsing a webhook payload.
 type ParseResult struct {
@@ -12,8 +19,35 @@ type ParseResult struct {
 	// and no user check is needed.
 	RequireUserCheck bool
 	// User is the login of the user who triggered the event.
-	// Only meaningful when RequireUserCheck is true.
+	// It is verified only when RequireUserCheck is true.
 	User string
+	// Repository is the full name of the repository the event belongs to.
+	// It is used to route organization webhook events to the matching GitRepo.
+	Repository string
+	// ChangedFiles lists the files changed by a push event. It is nil for other
+	// events and for pushes whose changed files are unknown, e.g. if the
+	// payload does not list all commits.
+	ChangedFiles []string
+	// Branches lists the branches updated by a push event of platforms that do
+	// not include the default branch of the repository in the payload. The
+	// server triggers a run only if the default branch recorded in the status
+	// of the GitRepo is one of them. It is nil for other platforms and events.
+	Branches []string
+	// Comment is the body of a comment written on a Renovate pull request or
+	// the Dependency Dashboard. It is empty for other events. The server reads
+	// the command from it if commands are enabled for the GitRepo.
+	Comment string
+	// Discover indicates a repository lifecycle event, e.g. a repository was
+	// created, renamed or archived. It triggers the Discovery owning the
+	// organization webhook instead of a Renovate run.
+	Discover bool
+	// Reason explains why the event does not trigger a run. It is recorded in
+	// the webhook delivery history of the GitRepo.
+	Reason string
+	// Timestamp is the time of the event if it is covered by the signature of
+	// the payload. It is used to reject replayed deliveries outside the
+	// allowed clock skew and is zero for platforms not signing a timestamp.
+	Timestamp time.Time
 }
 
 // Receiver defines how a specific Git platform validates and parses incoming webhooks.
@@ -0,0 +1,181 @@
+package receiver
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// RegistryType is the type of a container registry sending push events.
+type RegistryType string
+
+//nolint:revive
+const (
+	RegistryType_HARBOR       RegistryType = "harbor"
+	RegistryType_DISTRIBUTION RegistryType = "distribution"
+	RegistryType_GITLAB       RegistryType = "gitlab"
+)
+
+// dockerHubHost is the registry host of images referenced without a host.
+const dockerHubHost = "docker.io"
+
+// RegistryReceiver defines how a container registry parses incoming push events.
+type RegistryReceiver interface {
+	// Parse returns the images pushed by the event as references including the
+	// registry host. Events not pushing an image return no images.
+	Parse(req *http.Request, body []byte) ([]string, error)
+}
+
+// RegistryReceiverFactory creates a RegistryReceiver for a given registry type.
+type RegistryReceiverFactory func(registryType RegistryType) RegistryReceiver
+
+// NormalizeImage returns the canonical name of an image reference, that is the
+// lower-case registry host and repository path without tag or digest. Images
+// without a registry host refer to Docker Hub, which keeps its official images
+// in the library namespace.
+func NormalizeImage(image string) string {
+	name, _, _ := strings.Cut(image, "@")
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		name = name[:i]
+	}
+
+	name = strings.ToLower(name)
+
+	host, path, ok := strings.Cut(name, "/")
+	if !ok || (!strings.ContainsAny(host, ".:") && host != "localhost") {
+		host, path = dockerHubHost, name
+	}
+
+	if host == "index.docker.io" || host == "registry-1.docker.io" {
+		host = dockerHubHost
+	}
+
+	if host == dockerHubHost && !strings.Contains(path, "/") {
+		path = "library/" + path
+	}
+
+	return host + "/" + path
+}
+
+// handleRegistryEvent receives the push events of a container registry and
+// triggers a Renovate run on the GitRepos of the Renovator whose last run found
+// a pushed image as dependency. Requests are authenticated like trigger API
+// requests.
+func (s *Server) handleRegistryEvent(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+	registryType := RegistryType(chi.URLParam(r, "type"))
+
+	var registryReceiver RegistryReceiver
+	if s.registryFactory != nil {
+		registryReceiver = s.registryFactory(registryType)
+	}
+
+	if registryReceiver == nil {
+		receiverLog.Info("Registry events not implemented for registry", "registry", registryType)
+		http.Error(w, "Registry not supported", http.StatusNotImplemented)
+
+		return
+	}
+
+	instance := &renovatev1beta1.Renovator{}
+	if err := s.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, instance); err != nil {
+		http.Error(w, "Renovator not found", http.StatusNotFound)
+
+		return
+	}
+
+	if !s.authenticateTrigger(w, r, instance, string(registryType)) {
+		return
+	}
+
+	body, err := readWebhookBody(w, r)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+
+		return
+	}
+
+	images, err := registryReceiver.Parse(r, body)
+	if err != nil {
+		receiverLog.Error(err, "Failed to parse registry event")
+		http.Error(w, "Bad Request", http.StatusBadRequest)
+
+		if s.metrics != nil {
+			s.metrics.RecordWebhookRequest(string(registryType), "rejected")
+			s.metrics.RecordWebhookPayloadDecodeFailure(string(registryType))
+		}
+
+		return
+	}
+
+	repos, err := s.findImageRepos(ctx, instance, images)
+	if err != nil {
+		receiverLog.Error(err, "Failed to list GitRepos for registry event")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+
+		return
+	}
+
+	response := s.triggerRepos(ctx, repos, renovatev1beta1.TriggerSourceRegistry)
+
+	receiverLog.Info("Registry event processed", "namespace", namespace, "name", name,
+		"images", images, "queued", response.Queued, "failed", response.Failed)
+
+	result := "accepted"
+	if len(repos) == 0 {
+		result = "ignored"
+	}
+
+	s.recordTriggerRequest(string(registryType), response.result(result))
+	writeTriggerResponse(w, response)
+}
+
+// findImageRepos returns the GitRepos of the Renovator using any of the images
+// as dependency, ordered by name.
+func (s *Server) findImageRepos(
+	ctx context.Context,
+	instance *renovatev1beta1.Renovator,
+	images []string,
+) ([]*renovatev1beta1.GitRepo, error) {
+	if len(images) == 0 {
+		return nil, nil
+	}
+
+	pushed := make(map[string]bool, len(images))
+	for _, image := range images {
+		pushed[NormalizeImage(image)] = true
+	}
+
+	repos := &renovatev1beta1.GitRepoList{}
+	if err := s.client.List(ctx, repos, client.InNamespace(instance.Namespace), client.MatchingLabels{
+		renovatev1beta1.LabelRenovator: string(instance.UID),
+	}); err != nil {
+		return nil, err
+	}
+
+	var result []*renovatev1beta1.GitRepo
+
+	for i := range repos.Items {
+		repo := &repos.Items[i]
+
+		if slices.ContainsFunc(repo.Status.Images, func(image string) bool {
+			return pushed[NormalizeImage(image)]
+		}) {
+			result = append(result, repo)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b *renovatev1beta1.GitRepo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return result, nil
+}
@@ -0,0 +1,210 @@
+package receiver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/receiver"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// registryReceiverFunc adapts a function to a RegistryReceiver.
+type registryReceiverFunc func(req *http.Request, body []byte) ([]string, error)
+
+func (f registryReceiverFunc) Parse(req *http.Request, body []byte) ([]string, error) {
+	return f(req, body)
+}
+
+var _ = Describe("Registry Events", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		server    *receiver.Server
+		pushed    []string
+		parseErr  error
+		failRepos map[string]bool
+	)
+
+	newGitRepo := func(name, renovatorID string, images ...string) *renovatev1beta1.GitRepo {
+		return &renovatev1beta1.GitRepo{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels:    map[string]string{renovatev1beta1.LabelRenovator: renovatorID},
+			},
+			Status: renovatev1beta1.GitRepoStatus{Images: images},
+		}
+	}
+
+	send := func(registryType, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost,
+			"/registry/default/"+testRenovatorName+"/"+registryType, strings.NewReader("{}"))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		response := httptest.NewRecorder()
+		server.ServeHTTP(response, req)
+
+		return response
+	}
+
+	getRepo := func(name string) *renovatev1beta1.GitRepo {
+		repo := &renovatev1beta1.GitRepo{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: name}, repo)).To(Succeed())
+
+		return repo
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		pushed = nil
+		parseErr = nil
+		failRepos = map[string]bool{}
+
+		scheme := runtime.NewScheme()
+		Expect(renovatev1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&renovatev1beta1.Renovator{
+				ObjectMeta: metav1.ObjectMeta{Name: testRenovatorName, Namespace: testNamespace, UID: testRenovatorID},
+				Spec: renovatev1beta1.RenovatorSpec{
+					Trigger: renovatev1beta1.TriggerSpec{TokenSecret: &corev1.SecretKeySelector{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "trigger-token"},
+						Key:                  "token",
+					}},
+				},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "trigger-token", Namespace: testNamespace},
+				Data:       map[string][]byte{"token": []byte(testTriggerToken)},
+			},
+			newGitRepo("service", testRenovatorID, "harbor.example.com/library/base", "node"),
+			newGitRepo("worker", testRenovatorID, "Harbor.example.com/library/base:1.2@sha256:d89e"),
+			newGitRepo("frontend", testRenovatorID, "nginx"),
+			newGitRepo("foreign", "other-renovator", "harbor.example.com/library/base"),
+		).WithInterceptorFuncs(interceptor.Funcs{
+			Patch: func(
+				ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption,
+			) error {
+				if failRepos[obj.GetName()] {
+					return errors.New("patch failed")
+				}
+
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+	})
+
+	JustBeforeEach(func() {
+		server = receiver.NewServer(receiver.DefaultServerConfig(), k8sClient, nil,
+			func(registryType receiver.RegistryType) receiver.RegistryReceiver {
+				if registryType != receiver.RegistryType_HARBOR {
+					return nil
+				}
+
+				return registryReceiverFunc(func(*http.Request, []byte) ([]string, error) {
+					return pushed, parseErr
+				})
+			}, nil)
+	})
+
+	It("triggers the GitRepos using a pushed image", func() {
+		pushed = []string{"harbor.example.com/library/base:1.3"}
+
+		response := send("harbor", testTriggerToken)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+
+		var result receiver.TriggerResponse
+		Expect(json.Unmarshal(response.Body.Bytes(), &result)).To(Succeed())
+		Expect(result.Queued).To(Equal([]string{"service", "worker"}))
+
+		for _, name := range result.Queued {
+			Expect(getRepo(name).Annotations).To(And(
+				HaveKeyWithValue(renovatev1beta1.RenovatorOperation, renovatev1beta1.OperationRenovate),
+				HaveKeyWithValue(renovatev1beta1.AnnotationTriggerSource, renovatev1beta1.TriggerSourceRegistry),
+			))
+		}
+
+		Expect(getRepo("frontend").Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+		Expect(getRepo("foreign").Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+	})
+
+	It("triggers the remaining GitRepos if a GitRepo fails to be triggered", func() {
+		pushed = []string{"harbor.example.com/library/base:1.3"}
+		failRepos["service"] = true
+
+		response := send("harbor", testTriggerToken)
+		Expect(response.Code).To(Equal(http.StatusMultiStatus))
+
+		var result receiver.TriggerResponse
+		Expect(json.Unmarshal(response.Body.Bytes(), &result)).To(Succeed())
+		Expect(result.Queued).To(Equal([]string{"worker"}))
+		Expect(result.Failed).To(Equal([]string{"service"}))
+		Expect(getRepo("worker").Annotations).To(HaveKey(renovatev1beta1.RenovatorOperation))
+	})
+
+	It("matches official Docker Hub images", func() {
+		pushed = []string{"docker.io/library/nginx:1.27"}
+
+		response := send("harbor", testTriggerToken)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(getRepo("frontend").Annotations).To(HaveKey(renovatev1beta1.RenovatorOperation))
+	})
+
+	It("accepts events not matching any GitRepo", func() {
+		pushed = []string{"harbor.example.com/library/unused:1.0"}
+
+		response := send("harbor", testTriggerToken)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+		Expect(response.Body.String()).To(MatchJSON(`{"queued":[],"discovery":false}`))
+	})
+
+	It("returns 400 on a malformed event", func() {
+		parseErr = errors.New("malformed event")
+
+		response := send("harbor", testTriggerToken)
+		Expect(response.Code).To(Equal(http.StatusBadRequest))
+	})
+
+	It("returns 401 without a bearer token", func() {
+		pushed = []string{"harbor.example.com/library/base:1.3"}
+
+		response := send("harbor", "")
+		Expect(response.Code).To(Equal(http.StatusUnauthorized))
+		Expect(getRepo("service").Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+	})
+
+	It("returns 501 for an unsupported registry", func() {
+		response := send("quay", testTriggerToken)
+		Expect(response.Code).To(Equal(http.StatusNotImplemented))
+	})
+
+	DescribeTable("NormalizeImage",
+		func(image, expected string) {
+			Expect(receiver.NormalizeImage(image)).To(Equal(expected))
+		},
+		Entry("official image", "node", "docker.io/library/node"),
+		Entry("official image with tag", "node:20", "docker.io/library/node"),
+		Entry("Docker Hub image", "grafana/grafana", "docker.io/grafana/grafana"),
+		Entry("Docker Hub index host", "index.docker.io/library/node", "docker.io/library/node"),
+		Entry("registry host", "harbor.example.com/library/base:1.3", "harbor.example.com/library/base"),
+		Entry("registry port", "localhost:5000/base:1.3", "localhost:5000/base"),
+		Entry("digest", "ghcr.io/org/app:1.0@sha256:d89e", "ghcr.io/org/app"),
+		Entry("upper-case host", "Harbor.Example.com/library/base", "harbor.example.com/library/base"),
+	)
+})
@@ -0,0 +1,118 @@
+package receiver
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"github.com/maypok86/otter/v2"
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+)
+
+// maxTrackedDeliveries caps the number of deliveries kept for de-duplication.
+const maxTrackedDeliveries = 100_000
+
+// newDeliveryCache creates the cache of recently received deliveries. It
+// returns nil if de-duplication is disabled.
+func newDeliveryCache(ttl time.Duration) *otter.Cache[string, struct{}] {
+	if ttl <= 0 {
+		return nil
+	}
+
+	return otter.Must(&otter.Options[string, struct{}]{
+		ExpiryCalculator: otter.ExpiryWriting[string, struct{}](ttl),
+		MaximumSize:      maxTrackedDeliveries,
+	})
+}
+
+// checkReplay rejects deliveries whose signed event time is outside the allowed
+// clock skew and ignores deliveries already received. It writes the response
+// and returns false if the delivery must not be processed. Otherwise it returns
+// the key of the claimed delivery, which is released again by releaseDelivery
+// if processing fails.
+func (s *Server) checkReplay(
+	w http.ResponseWriter,
+	r *http.Request,
+	body []byte,
+	config *renovatev1beta1.RenovateConfig,
+	result ParseResult,
+	resolveRepo func(context.Context, ParseResult) (*renovatev1beta1.GitRepo, error),
+) (string, bool) {
+	ctx := r.Context()
+
+	if s.isClockSkewed(result, time.Now()) {
+		receiverLog.Info("Webhook event time outside the allowed clock skew",
+			"path", r.URL.Path, "timestamp", result.Timestamp)
+		http.Error(w, "Stale webhook delivery", http.StatusForbidden)
+
+		if s.metrics != nil {
+			s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "rejected")
+		}
+
+		s.recordResolvedDelivery(ctx, r, result, resolveRepo,
+			renovatev1beta1.WebhookDeliveryDecision_REJECTED, reasonClockSkew)
+
+		return "", false
+	}
+
+	key, duplicate := s.claimDelivery(r, body)
+	if duplicate {
+		receiverLog.Info("Ignoring duplicate webhook delivery", "path", r.URL.Path,
+			"delivery", firstHeader(r, deliveryIDHeaders))
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted"}`))
+
+		if s.metrics != nil {
+			s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "duplicate")
+		}
+
+		s.recordResolvedDelivery(ctx, r, result, resolveRepo,
+			renovatev1beta1.WebhookDeliveryDecision_IGNORED, reasonDuplicate)
+
+		return "", false
+	}
+
+	return key, true
+}
+
+// isClockSkewed reports whether the signed event time differs from now by more
+// than the allowed clock skew. Events without a signed time are never skewed.
+func (s *Server) isClockSkewed(result ParseResult, now time.Time) bool {
+	if result.Timestamp.IsZero() || s.config.MaxClockSkew <= 0 {
+		return false
+	}
+
+	return now.Sub(result.Timestamp).Abs() > s.config.MaxClockSkew
+}
+
+// claimDelivery records the delivery of the request. It returns the cache key
+// and whether the delivery was received before. The delivery ID header is not
+// covered by the signature, so the key is bound to a hash of the signed body
+// instead: a captured delivery replayed with another delivery ID is still
+// detected, while redeliveries by the platform carry the same body.
+func (s *Server) claimDelivery(r *http.Request, body []byte) (string, bool) {
+	if s.deliveries == nil {
+		return "", false
+	}
+
+	// Deliveries are scoped to the endpoint as the same event may be sent to
+	// the webhooks of several GitRepos.
+	sum := sha256.Sum256(body)
+	key := r.URL.Path + "#" + hex.EncodeToString(sum[:])
+
+	_, claimed := s.deliveries.SetIfAbsent(key, struct{}{})
+
+	return key, !claimed
+}
+
+// releaseDelivery forgets a claimed delivery after an internal error, so a
+// redelivery by the platform is processed.
+func (s *Server) releaseDelivery(key string) {
+	if s.deliveries == nil || key == "" {
+		return
+	}
+
+	s.deliveries.Invalidate(key)
+}
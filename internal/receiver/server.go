@@ -6,14 +6,19 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
+	"github.com/maypok86/otter/v2"
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
 	"github.com/thegeeklab/renovate-operator/internal/metrics"
 	"github.com/thegeeklab/renovate-operator/internal/provider/factory"
+	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
 	corev1 "k8s.io/api/core/v1"
 	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
@@ -28,6 +33,7 @@ var (
 	ErrGitRepoInvalid                   = errors.New("GitRepo invalid")
 	ErrRenovateConfigNotFound           = errors.New("RenovateConfig not found")
 	ErrWebhookSecretKeyNotFound         = errors.New("webhook secret key not found in secret")
+	ErrGitRepoNotManaged                = errors.New("repository is not managed by a GitRepo")
 )
 
 // ReceiverFactory creates a Receiver for a given platform type.
@@ -38,6 +44,13 @@ const (
 	DefaultWriteTimeout = 30 * time.Second
 	DefaultIdleTimeout  = 120 * time.Second
 
+	// DefaultDeliveryTTL is how long deliveries are remembered to ignore
+	// duplicate deliveries.
+	DefaultDeliveryTTL = 24 * time.Hour
+	// DefaultMaxClockSkew is the maximum difference between the signed event
+	// time and the time the delivery is received.
+	DefaultMaxClockSkew = 5 * time.Minute
+
 	// maxWebhookBodyBytes caps the webhook request body to protect against memory exhaustion.
 	maxWebhookBodyBytes = 1 << 20 // 1 MiB
 )
@@ -47,6 +60,13 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+
+	// DeliveryTTL is how long deliveries are remembered to ignore duplicate
+	// deliveries. De-duplication is disabled if it is zero.
+	DeliveryTTL time.Duration
+	// MaxClockSkew is the maximum difference between the signed event time and
+	// the time the delivery is received. The check is disabled if it is zero.
+	MaxClockSkew time.Duration
 }
 
 func DefaultServerConfig() ServerConfig {
@@ -55,6 +75,8 @@ func DefaultServerConfig() ServerConfig {
 		ReadTimeout:  DefaultReadTimeout,
 		WriteTimeout: DefaultWriteTimeout,
 		IdleTimeout:  DefaultIdleTimeout,
+		DeliveryTTL:  DefaultDeliveryTTL,
+		MaxClockSkew: DefaultMaxClockSkew,
 	}
 }
 
@@ -66,13 +88,16 @@ type Server struct {
 
 	providerFactory factory.ProviderFactory
 	receiverFactory ReceiverFactory
+	registryFactory RegistryReceiverFactory
 	metrics         metrics.Recorder
+	deliveries      *otter.Cache[string, struct{}]
 }
 
 func NewServer(
 	config ServerConfig,
 	k8sClient client.Client,
 	receiverFactory ReceiverFactory,
+	registryFactory RegistryReceiverFactory,
 	metricsRecorder metrics.Recorder,
 ) *Server {
 	s := &Server{
@@ -82,10 +107,15 @@ func NewServer(
 
 		providerFactory: factory.DefaultProviderFactory,
 		receiverFactory: receiverFactory,
+		registryFactory: registryFactory,
 		metrics:         metricsRecorder,
+		deliveries:      newDeliveryCache(config.DeliveryTTL),
 	}
 
 	s.router.Post("/hooks/{namespace}/{name}", s.handleIncomingWebhook)
+	s.router.Post("/hooks/{namespace}/discoveries/{name}", s.handleOrganizationWebhook)
+	s.router.Post("/trigger/{namespace}/{name}", s.handleTrigger)
+	s.router.Post("/registry/{namespace}/{name}/{type}", s.handleRegistryEvent)
 
 	s.server = &http.Server{
 		Addr:         config.Addr,
@@ -135,17 +165,14 @@ func (s *Server) handleIncomingWebhook(w http.ResponseWriter, r *http.Request) {
 	namespace := chi.URLParam(r, "namespace")
 	name := chi.URLParam(r, "name")
 
-	r.Body = http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes)
-	defer r.Body.Close()
-
-	body, err := io.ReadAll(r.Body)
+	body, err := readWebhookBody(w, r)
 	if err != nil {
 		http.Error(w, "Failed to read request body", http.StatusBadRequest)
 
 		return
 	}
 
-	secretToken, err := s.resolveWebhookSecret(ctx, namespace, name)
+	secretToken, err := s.resolveWebhookSecret(ctx, namespace, fmt.Sprintf("%s-webhook-secret", name))
 	if err != nil {
 		receiverLog.Error(err, "Failed to find webhook secret")
 		http.Error(w, "Webhook secret not found", http.StatusNotFound)
@@ -160,7 +187,7 @@ func (s *Server) handleIncomingWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	config, err := s.resolveRenovateConfig(ctx, namespace, name, repo)
+	config, err := s.resolveRenovateConfig(ctx, namespace, name, repo.Labels)
 	if err != nil {
 		receiverLog.Error(err, "Failed to resolve RenovateConfig for GitRepo")
 		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
@@ -168,6 +195,84 @@ func (s *Server) handleIncomingWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	s.processWebhook(w, r, body, secretToken, config, nil,
+		func(context.Context, ParseResult) (*renovatev1beta1.GitRepo, error) {
+			return repo, nil
+		},
+	)
+}
+
+// handleOrganizationWebhook receives events of an organization webhook managed
+// by a Discovery and routes them to the GitRepo matching the repository full name.
+func (s *Server) handleOrganizationWebhook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	body, err := readWebhookBody(w, r)
+	if err != nil {
+		http.Error(w, "Failed to read request body", http.StatusBadRequest)
+
+		return
+	}
+
+	discovery := &renovatev1beta1.Discovery{}
+	if err := s.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, discovery); err != nil {
+		http.Error(w, "Discovery not found", http.StatusNotFound)
+
+		return
+	}
+
+	secretName, err := k8s.DeterministicSubdomain(name, "-webhook-secret")
+	if err != nil {
+		http.Error(w, "Webhook secret not found", http.StatusNotFound)
+
+		return
+	}
+
+	secretToken, err := s.resolveWebhookSecret(ctx, namespace, secretName)
+	if err != nil {
+		receiverLog.Error(err, "Failed to find webhook secret")
+		http.Error(w, "Webhook secret not found", http.StatusNotFound)
+
+		return
+	}
+
+	config, err := s.resolveDiscoveryConfig(ctx, discovery)
+	if err != nil {
+		receiverLog.Error(err, "Failed to resolve RenovateConfig for Discovery")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+
+		return
+	}
+
+	s.processWebhook(w, r, body, secretToken, config, discovery,
+		func(ctx context.Context, result ParseResult) (*renovatev1beta1.GitRepo, error) {
+			return s.findDiscoveredRepo(ctx, discovery, result.Repository)
+		},
+	)
+}
+
+// processWebhook validates and parses the webhook payload and triggers a
+// Renovate run on the GitRepo returned by resolveRepo, or executes the command
+// of a comment event. Repository lifecycle events trigger the Discovery of an
+// organization webhook, which is nil for GitRepo webhooks. Events for repositories
+// without a GitRepo (ErrGitRepoNotManaged) are ignored. The decision is recorded
+// in the webhook delivery history of the GitRepo, except for requests with an
+// invalid signature as their content is not trusted. Duplicate deliveries and
+// events outside the allowed clock skew are dropped by checkReplay.
+func (s *Server) processWebhook(
+	w http.ResponseWriter,
+	r *http.Request,
+	body, secretToken []byte,
+	config *renovatev1beta1.RenovateConfig,
+	discovery *renovatev1beta1.Discovery,
+	resolveRepo func(context.Context, ParseResult) (*renovatev1beta1.GitRepo, error),
+) {
+	ctx := r.Context()
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
 	if s.receiverFactory == nil {
 		receiverLog.Info("No receiver factory configured")
 		http.Error(w, "Receiver not configured", http.StatusNotImplemented)
@@ -205,6 +310,22 @@ func (s *Server) handleIncomingWebhook(w http.ResponseWriter, r *http.Request) {
 			s.metrics.RecordWebhookPayloadDecodeFailure(string(config.Spec.Platform.Type))
 		}
 
+		s.recordResolvedDelivery(ctx, r, ParseResult{}, resolveRepo,
+			renovatev1beta1.WebhookDeliveryDecision_REJECTED, reasonInvalidPayload)
+
+		return
+	}
+
+	deliveryKey, ok := s.checkReplay(w, r, body, config, result, resolveRepo)
+	if !ok {
+		return
+	}
+
+	if result.Discover {
+		if !s.triggerDiscovery(w, r, config, discovery, result) {
+			s.releaseDelivery(deliveryKey)
+		}
+
 		return
 	}
 
@@ -217,6 +338,52 @@ func (s *Server) handleIncomingWebhook(w http.ResponseWriter, r *http.Request) {
 			s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "ignored")
 		}
 
+		s.recordResolvedDelivery(ctx, r, result, resolveRepo,
+			renovatev1beta1.WebhookDeliveryDecision_IGNORED, ignoreReason(result))
+
+		return
+	}
+
+	repo, err := resolveRepo(ctx, result)
+	if errors.Is(err, ErrGitRepoNotManaged) {
+		receiverLog.Info("Webhook event does not match a managed GitRepo",
+			"namespace", namespace, "name", name, "repository", result.Repository)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted"}`))
+
+		if s.metrics != nil {
+			s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "ignored")
+		}
+
+		return
+	}
+
+	if err != nil {
+		receiverLog.Error(err, "Failed to resolve GitRepo for webhook event")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		s.releaseDelivery(deliveryKey)
+
+		return
+	}
+
+	if s.filterPush(w, r, config, repo, result) {
+		return
+	}
+
+	command, reason := resolveCommand(repo, result)
+	if command == "" {
+		receiverLog.Info("Webhook comment contains no command", "namespace", namespace, "name", repo.Name)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted"}`))
+
+		if s.metrics != nil {
+			s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "ignored")
+		}
+
+		s.recordDelivery(ctx, repo, newWebhookDelivery(
+			r, result, renovatev1beta1.WebhookDeliveryDecision_IGNORED, reason, time.Now(),
+		))
+
 		return
 	}
 
@@ -225,6 +392,7 @@ func (s *Server) handleIncomingWebhook(w http.ResponseWriter, r *http.Request) {
 		if err != nil {
 			receiverLog.Error(err, "Failed to verify webhook user status")
 			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			s.releaseDelivery(deliveryKey)
 
 			return
 		}
@@ -234,6 +402,10 @@ func (s *Server) handleIncomingWebhook(w http.ResponseWriter, r *http.Request) {
 				s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "rejected")
 			}
 
+			s.recordDelivery(ctx, repo, newWebhookDelivery(
+				r, result, renovatev1beta1.WebhookDeliveryDecision_REJECTED, reasonUserMismatch, time.Now(),
+			))
+
 			w.WriteHeader(http.StatusAccepted)
 			_, _ = w.Write([]byte(`{"status":"accepted"}`))
 
@@ -243,31 +415,141 @@ func (s *Server) handleIncomingWebhook(w http.ResponseWriter, r *http.Request) {
 
 	patch := client.MergeFrom(repo.DeepCopy())
 
-	if repo.Annotations == nil {
-		repo.Annotations = make(map[string]string)
-	}
-
-	repo.Annotations[renovatev1beta1.RenovatorOperation] = renovatev1beta1.OperationRenovate
+	now := time.Now()
+	reason = applyCommand(repo, command, now)
 
 	if err := s.client.Patch(ctx, repo, patch); err != nil {
 		receiverLog.Error(err, "Failed to apply trigger annotation")
 		http.Error(w, "Failed to trigger run", http.StatusInternalServerError)
+		s.releaseDelivery(deliveryKey)
 
 		return
 	}
 
-	receiverLog.Info("Renovate run triggered successfully", "namespace", namespace, "name", name)
+	receiverLog.Info("Webhook command applied successfully", "namespace", namespace, "name", repo.Name,
+		"command", command)
+
+	s.recordDelivery(ctx, repo, newWebhookDelivery(
+		r, result, renovatev1beta1.WebhookDeliveryDecision_ACCEPTED, reason, now,
+	))
 
 	if s.metrics != nil {
 		s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "accepted")
+
+		if reason == reasonCoalesced {
+			s.metrics.RecordWebhookCoalesced(string(config.Spec.Platform.Type))
+		}
 	}
 
 	w.WriteHeader(http.StatusAccepted)
 	_, _ = w.Write([]byte(`{"status":"accepted"}`))
 }
 
-func (s *Server) resolveWebhookSecret(ctx context.Context, namespace, name string) ([]byte, error) {
-	secretName := fmt.Sprintf("%s-webhook-secret", name)
+// filterPush ignores push events that do not update the default branch of the
+// GitRepo, if known, and push events that do not change any path of its path
+// filter. It returns true if the event was filtered and the response written.
+func (s *Server) filterPush(
+	w http.ResponseWriter,
+	r *http.Request,
+	config *renovatev1beta1.RenovateConfig,
+	repo *renovatev1beta1.GitRepo,
+	result ParseResult,
+) bool {
+	ctx := r.Context()
+
+	if isBranchFiltered(repo, result) {
+		receiverLog.Info("Webhook push does not update the default branch",
+			"namespace", repo.Namespace, "name", repo.Name, "branches", result.Branches)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted"}`))
+
+		if s.metrics != nil {
+			s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "ignored")
+		}
+
+		s.recordDelivery(ctx, repo, newWebhookDelivery(
+			r, result, renovatev1beta1.WebhookDeliveryDecision_IGNORED, reasonBranchFiltered, time.Now(),
+		))
+
+		return true
+	}
+
+	if isPathFiltered(repo, result) {
+		receiverLog.Info("Webhook push does not change any filtered path",
+			"namespace", repo.Namespace, "name", repo.Name)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"status":"accepted"}`))
+
+		if s.metrics != nil {
+			s.metrics.RecordWebhookRequest(string(config.Spec.Platform.Type), "filtered")
+		}
+
+		s.recordDelivery(ctx, repo, newWebhookDelivery(
+			r, result, renovatev1beta1.WebhookDeliveryDecision_FILTERED, reasonPathFiltered, time.Now(),
+		))
+
+		return true
+	}
+
+	return false
+}
+
+// isBranchFiltered reports whether a push event of a platform without the
+// default branch in the payload does not update the default branch of the
+// GitRepo. Pushes are not filtered while the default branch is unknown.
+func isBranchFiltered(repo *renovatev1beta1.GitRepo, result ParseResult) bool {
+	if len(result.Branches) == 0 || repo.Status.DefaultBranch == "" {
+		return false
+	}
+
+	return !slices.Contains(result.Branches, repo.Status.DefaultBranch)
+}
+
+// isPathFiltered reports whether a push event is ignored by the path filter of
+// the GitRepo. Pushes with unknown changed files are never filtered.
+func isPathFiltered(repo *renovatev1beta1.GitRepo, result ParseResult) bool {
+	filter := repo.Spec.Webhooks.PathFilter
+	if filter == nil || len(result.ChangedFiles) == 0 {
+		return false
+	}
+
+	patterns := filter.Patterns
+	if len(patterns) == 0 {
+		patterns = DefaultPathPatterns
+	}
+
+	return !MatchesPathPatterns(result.ChangedFiles, patterns)
+}
+
+// recordWebhookEvent records the time of a webhook event on a GitRepo that
+// debounces its webhook triggered runs. It reports whether the event was
+// coalesced into an already pending webhook triggered run.
+func recordWebhookEvent(repo *renovatev1beta1.GitRepo, now time.Time) bool {
+	if repo.Spec.Webhooks.Debounce == nil {
+		return false
+	}
+
+	eventTime := now.UTC().Format(time.RFC3339)
+
+	_, pending := repo.Annotations[renovatev1beta1.AnnotationWebhookFirstEvent]
+	if !pending {
+		repo.Annotations[renovatev1beta1.AnnotationWebhookFirstEvent] = eventTime
+	}
+
+	repo.Annotations[renovatev1beta1.AnnotationWebhookLastEvent] = eventTime
+
+	return pending
+}
+
+// readWebhookBody reads the request body, capped at maxWebhookBodyBytes.
+func readWebhookBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, maxWebhookBodyBytes)
+	defer r.Body.Close()
+
+	return io.ReadAll(r.Body)
+}
+
+func (s *Server) resolveWebhookSecret(ctx context.Context, namespace, secretName string) ([]byte, error) {
 	webhookSecret := &corev1.Secret{}
 
 	if err := s.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: secretName}, webhookSecret); err != nil {
@@ -297,11 +579,11 @@ func (s *Server) resolveWebhookSecret(ctx context.Context, namespace, name strin
 func (s *Server) resolveRenovateConfig(
 	ctx context.Context,
 	namespace, name string,
-	repo *renovatev1beta1.GitRepo,
+	labels map[string]string,
 ) (*renovatev1beta1.RenovateConfig, error) {
-	renovatorID, ok := repo.Labels[renovatev1beta1.LabelRenovator]
+	renovatorID, ok := labels[renovatev1beta1.LabelRenovator]
 	if !ok {
-		receiverLog.Info("GitRepo is missing renovator label", "namespace", namespace, "name", name)
+		receiverLog.Info("Resource is missing renovator label", "namespace", namespace, "name", name)
 
 		return nil, ErrGitRepoInvalid
 	}
@@ -322,6 +604,59 @@ func (s *Server) resolveRenovateConfig(
 	return &configList.Items[0], nil
 }
 
+// resolveDiscoveryConfig resolves the RenovateConfig from either .spec.configRef
+// or the renovator label of the Discovery.
+func (s *Server) resolveDiscoveryConfig(
+	ctx context.Context,
+	discovery *renovatev1beta1.Discovery,
+) (*renovatev1beta1.RenovateConfig, error) {
+	if discovery.Spec.ConfigRef == "" {
+		return s.resolveRenovateConfig(ctx, discovery.Namespace, discovery.Name, discovery.Labels)
+	}
+
+	config := &renovatev1beta1.RenovateConfig{}
+	if err := s.client.Get(ctx, types.NamespacedName{
+		Namespace: discovery.Namespace,
+		Name:      discovery.Spec.ConfigRef,
+	}, config); err != nil {
+		if api_errors.IsNotFound(err) {
+			return nil, ErrRenovateConfigNotFound
+		}
+
+		return nil, err
+	}
+
+	return config, nil
+}
+
+// findDiscoveredRepo returns the GitRepo managed by the Discovery for the
+// given repository full name.
+func (s *Server) findDiscoveredRepo(
+	ctx context.Context,
+	discovery *renovatev1beta1.Discovery,
+	repository string,
+) (*renovatev1beta1.GitRepo, error) {
+	if repository == "" {
+		return nil, ErrGitRepoNotManaged
+	}
+
+	repos := &renovatev1beta1.GitRepoList{}
+	if err := s.client.List(ctx, repos, client.InNamespace(discovery.Namespace)); err != nil {
+		return nil, err
+	}
+
+	for i := range repos.Items {
+		repo := &repos.Items[i]
+
+		// Repository names are case-insensitive on all supported platforms.
+		if strings.EqualFold(repo.Spec.Name, repository) && metav1.IsControlledBy(repo, discovery) {
+			return repo, nil
+		}
+	}
+
+	return nil, ErrGitRepoNotManaged
+}
+
 func (s *Server) verifyWebhookUser(
 	ctx context.Context,
 	namespace, name string,
@@ -339,12 +674,24 @@ func (s *Server) verifyWebhookUser(
 		return false, err
 	}
 
+	githubApp, err := factory.LoadGitHubApp(ctx, s.client, namespace, &config.Spec.Platform)
+	if err != nil {
+		receiverLog.Error(err, "Failed to load GitHub App credentials", "namespace", namespace, "name", name)
+
+		if s.metrics != nil {
+			s.metrics.RecordWebhookAuthFailure(string(config.Spec.Platform.Type), "token_resolution")
+		}
+
+		return false, err
+	}
+
 	providerManager, err := s.providerFactory(
 		ctx,
 		factory.PlatformConfig{
-			Type:     string(config.Spec.Platform.Type),
-			Endpoint: config.Spec.Platform.Endpoint,
-			Token:    platformToken,
+			Type:      string(config.Spec.Platform.Type),
+			Endpoint:  config.Spec.Platform.Endpoint,
+			Token:     platformToken,
+			GitHubApp: githubApp,
 		},
 	)
 	if err != nil {
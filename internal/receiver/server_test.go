@@ -2,10 +2,15 @@ package receiver_test
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -16,6 +21,8 @@ import (
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
 	"github.com/thegeeklab/renovate-operator/internal/metrics"
 	"github.com/thegeeklab/renovate-operator/internal/receiver"
+	"github.com/thegeeklab/renovate-operator/internal/receiver/bitbucketserver"
+	bitbucketfixtures "github.com/thegeeklab/renovate-operator/internal/receiver/bitbucketserver/fixtures"
 	"github.com/thegeeklab/renovate-operator/internal/receiver/mocks"
 	batchv1 "k8s.io/api/batch/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -23,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 )
 
 const (
@@ -82,7 +90,7 @@ var _ = Describe("Server", func() {
 			&baseConfig,
 			webhookSecret,
 			platformSecret,
-		).Build()
+		).WithStatusSubresource(repo).Build()
 
 		mockRecv = mocks.NewReceiver(GinkgoT())
 	})
@@ -92,7 +100,7 @@ var _ = Describe("Server", func() {
 			platformType renovatev1beta1.PlatformType,
 		) receiver.Receiver {
 			return mockRecv
-		}, nil)
+		}, nil, nil)
 	})
 
 	DescribeTable(
@@ -120,6 +128,10 @@ var _ = Describe("Server", func() {
 				renovatev1beta1.RenovatorOperation,
 				renovatev1beta1.OperationRenovate,
 			))
+			Expect(repo.Annotations).To(HaveKeyWithValue(
+				renovatev1beta1.AnnotationTriggerSource,
+				renovatev1beta1.TriggerSourceWebhook,
+			))
 		},
 		Entry("GitHub", "github"),
 		Entry("Gitea", "gitea"),
@@ -154,6 +166,599 @@ var _ = Describe("Server", func() {
 		}, repo)).To(Succeed())
 		Expect(repo.Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
 	})
+
+	It("records the event times of debounced webhooks", func() {
+		repo := &renovatev1beta1.GitRepo{}
+		repoKey := client.ObjectKey{Namespace: testNamespace, Name: testGitRepoName}
+		Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+
+		repo.Spec.Webhooks.Debounce = &renovatev1beta1.WebhookDebounceSpec{
+			QuietPeriod: metav1.Duration{Duration: time.Minute},
+		}
+		repo.Annotations = map[string]string{
+			renovatev1beta1.RenovatorOperation:          renovatev1beta1.OperationRenovate,
+			renovatev1beta1.AnnotationTriggerSource:     renovatev1beta1.TriggerSourceWebhook,
+			renovatev1beta1.AnnotationWebhookFirstEvent: "2026-02-27T15:00:00Z",
+			renovatev1beta1.AnnotationWebhookLastEvent:  "2026-02-27T15:00:00Z",
+		}
+		Expect(k8sClient.Update(ctx, repo)).To(Succeed())
+
+		mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockRecv.On("Parse", mock.Anything, mock.Anything).
+			Return(receiver.ParseResult{ShouldTrigger: true}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader("{}"))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, req)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+
+		Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+		Expect(repo.Annotations).To(HaveKeyWithValue(
+			renovatev1beta1.AnnotationWebhookFirstEvent, "2026-02-27T15:00:00Z",
+		))
+
+		last, err := time.Parse(time.RFC3339, repo.Annotations[renovatev1beta1.AnnotationWebhookLastEvent])
+		Expect(err).NotTo(HaveOccurred())
+		Expect(last).To(BeTemporally("~", time.Now(), 5*time.Second))
+	})
+
+	DescribeTable("records webhook deliveries in the GitRepo status",
+		func(result receiver.ParseResult, parseErr error, expected renovatev1beta1.WebhookDelivery) {
+			repo := &renovatev1beta1.GitRepo{}
+			repoKey := client.ObjectKey{Namespace: testNamespace, Name: testGitRepoName}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+
+			repo.Spec.Webhooks.PathFilter = &renovatev1beta1.WebhookPathFilterSpec{}
+			Expect(k8sClient.Update(ctx, repo)).To(Succeed())
+
+			mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockRecv.On("Parse", mock.Anything, mock.Anything).Return(result, parseErr)
+
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader("{}"))
+			req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+			req.Header.Set("X-GitHub-Event", "push")
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, req)
+
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+			Expect(repo.Status.WebhookDeliveries).To(HaveLen(1))
+
+			delivery := repo.Status.WebhookDeliveries[0]
+			Expect(delivery.Time.Time).To(BeTemporally("~", time.Now(), 5*time.Second))
+
+			delivery.Time = metav1.Time{}
+			Expect(delivery).To(Equal(expected))
+		},
+		Entry(
+			"accepted",
+			receiver.ParseResult{ShouldTrigger: true, User: "renovate-bot"},
+			nil,
+			renovatev1beta1.WebhookDelivery{
+				ID:       "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+				Event:    "push",
+				Sender:   "renovate-bot",
+				Decision: renovatev1beta1.WebhookDeliveryDecision_ACCEPTED,
+			},
+		),
+		Entry(
+			"ignored",
+			receiver.ParseResult{User: "renovate-bot", Reason: receiver.ReasonCheckboxUnchecked},
+			nil,
+			renovatev1beta1.WebhookDelivery{
+				ID:       "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+				Event:    "push",
+				Sender:   "renovate-bot",
+				Decision: renovatev1beta1.WebhookDeliveryDecision_IGNORED,
+				Reason:   receiver.ReasonCheckboxUnchecked,
+			},
+		),
+		Entry(
+			"filtered",
+			receiver.ParseResult{ShouldTrigger: true, ChangedFiles: []string{"README.md"}},
+			nil,
+			renovatev1beta1.WebhookDelivery{
+				ID:       "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+				Event:    "push",
+				Decision: renovatev1beta1.WebhookDeliveryDecision_FILTERED,
+				Reason:   "no changed file matches the path filter",
+			},
+		),
+		Entry(
+			"rejected",
+			receiver.ParseResult{},
+			errors.New("unexpected end of JSON input"),
+			renovatev1beta1.WebhookDelivery{
+				ID:       "72d3162e-cc78-11e3-81ab-4c9367dc0958",
+				Event:    "push",
+				Decision: renovatev1beta1.WebhookDeliveryDecision_REJECTED,
+				Reason:   "invalid payload",
+			},
+		),
+	)
+
+	Context("when the delivery history is updated concurrently", func() {
+		var conflicts int
+
+		BeforeEach(func() {
+			conflicts = 0
+
+			k8sClient = interceptor.NewClient(k8sClient.(client.WithWatch), interceptor.Funcs{
+				SubResourcePatch: func(
+					ctx context.Context, c client.Client, subResource string, obj client.Object,
+					patch client.Patch, opts ...client.SubResourcePatchOption,
+				) error {
+					if conflicts == 0 {
+						conflicts++
+
+						concurrent := &renovatev1beta1.GitRepo{}
+						Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), concurrent)).To(Succeed())
+
+						concurrent.Status.WebhookDeliveries = []renovatev1beta1.WebhookDelivery{{ID: "concurrent"}}
+						Expect(c.Status().Update(ctx, concurrent)).To(Succeed())
+					}
+
+					return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+				},
+			})
+		})
+
+		It("keeps the concurrently recorded deliveries", func() {
+			mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockRecv.On("Parse", mock.Anything, mock.Anything).Return(receiver.ParseResult{}, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader("{}"))
+			req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, req)
+			Expect(conflicts).To(Equal(1))
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: testGitRepoName}, repo)).
+				To(Succeed())
+			Expect(repo.Status.WebhookDeliveries).To(HaveLen(2))
+			Expect(repo.Status.WebhookDeliveries[0].ID).To(Equal("72d3162e-cc78-11e3-81ab-4c9367dc0958"))
+			Expect(repo.Status.WebhookDeliveries[1].ID).To(Equal("concurrent"))
+		})
+	})
+
+	Context("Bitbucket Server pushes", func() {
+		var repoKey client.ObjectKey
+
+		BeforeEach(func() {
+			repoKey = client.ObjectKey{Namespace: testNamespace, Name: testGitRepoName}
+
+			config := baseConfig.DeepCopy()
+			config.Spec.Platform.Type = renovatev1beta1.PlatformType_BITBUCKET_SERVER
+			Expect(k8sClient.Update(ctx, config)).To(Succeed())
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+
+			repo.Status.DefaultBranch = "main"
+			Expect(k8sClient.Status().Update(ctx, repo)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			// The sample payloads are signed with a fixed event date.
+			config := receiver.DefaultServerConfig()
+			config.MaxClockSkew = 0
+
+			server = receiver.NewServer(config, k8sClient, func(
+				platformType renovatev1beta1.PlatformType,
+			) receiver.Receiver {
+				return bitbucketserver.NewReceiver()
+			}, nil, nil)
+		})
+
+		send := func(body string) {
+			mac := hmac.New(sha256.New, []byte("webhook-secret"))
+			mac.Write([]byte(body))
+
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader(body))
+			req.Header.Set("X-Event-Key", "repo:refs_changed")
+			req.Header.Set("X-Hub-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, req)
+			Expect(response.Code).To(Equal(http.StatusAccepted))
+		}
+
+		It("triggers a run on a push to the default branch", func() {
+			send(bitbucketfixtures.HookRefsChanged)
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+			Expect(repo.Annotations).To(HaveKeyWithValue(
+				renovatev1beta1.RenovatorOperation,
+				renovatev1beta1.OperationRenovate,
+			))
+		})
+
+		It("ignores a push to a feature branch", func() {
+			send(bitbucketfixtures.HookRefsChangedFeatureBranch)
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+			Expect(repo.Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+			Expect(repo.Status.WebhookDeliveries).To(HaveLen(1))
+			Expect(repo.Status.WebhookDeliveries[0].Decision).To(Equal(renovatev1beta1.WebhookDeliveryDecision_IGNORED))
+			Expect(repo.Status.WebhookDeliveries[0].Reason).To(Equal("push does not update the default branch"))
+		})
+
+		It("triggers a run on a push to a feature branch while the default branch is unknown", func() {
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+
+			repo.Status.DefaultBranch = ""
+			Expect(k8sClient.Status().Update(ctx, repo)).To(Succeed())
+
+			send(bitbucketfixtures.HookRefsChangedFeatureBranch)
+
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+			Expect(repo.Annotations).To(HaveKey(renovatev1beta1.RenovatorOperation))
+		})
+	})
+
+	Context("comment commands", func() {
+		var repoKey client.ObjectKey
+
+		send := func(comment string) {
+			mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockRecv.On("Parse", mock.Anything, mock.Anything).
+				Return(receiver.ParseResult{ShouldTrigger: true, User: "renovate-bot", Comment: comment}, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader("{}"))
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, req)
+			Expect(response.Code).To(Equal(http.StatusAccepted))
+		}
+
+		BeforeEach(func() {
+			repoKey = client.ObjectKey{Namespace: testNamespace, Name: testGitRepoName}
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+
+			repo.Spec.Webhooks.Commands = &renovatev1beta1.WebhookCommandsSpec{}
+			Expect(k8sClient.Update(ctx, repo)).To(Succeed())
+		})
+
+		DescribeTable("annotates the GitRepo to execute the command",
+			func(comment string, expected map[string]string, reason string) {
+				send(comment)
+
+				repo := &renovatev1beta1.GitRepo{}
+				Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+				Expect(repo.Annotations).To(Equal(expected))
+				Expect(repo.Status.WebhookDeliveries).To(HaveLen(1))
+				Expect(repo.Status.WebhookDeliveries[0].Decision).To(Equal(renovatev1beta1.WebhookDeliveryDecision_ACCEPTED))
+				Expect(repo.Status.WebhookDeliveries[0].Reason).To(Equal(reason))
+			},
+			Entry("run", "/renovate run", map[string]string{
+				renovatev1beta1.RenovatorOperation:      renovatev1beta1.OperationRenovate,
+				renovatev1beta1.AnnotationTriggerSource: renovatev1beta1.TriggerSourceWebhook,
+			}, ""),
+			Entry("debug", "/renovate debug", map[string]string{
+				renovatev1beta1.RenovatorOperation:      renovatev1beta1.OperationRenovate,
+				renovatev1beta1.AnnotationTriggerSource: renovatev1beta1.TriggerSourceWebhook,
+				renovatev1beta1.AnnotationDebugRun:      renovatev1beta1.ValueTrue,
+			}, ""),
+			Entry("skip", "/renovate skip", map[string]string{
+				renovatev1beta1.AnnotationSkipScheduledRun: renovatev1beta1.ValueTrue,
+			}, "next scheduled run skipped"),
+		)
+
+		It("ignores comments without a command", func() {
+			send("Looks good to me")
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+			Expect(repo.Annotations).To(BeEmpty())
+			Expect(repo.Status.WebhookDeliveries).To(HaveLen(1))
+			Expect(repo.Status.WebhookDeliveries[0].Decision).To(Equal(renovatev1beta1.WebhookDeliveryDecision_IGNORED))
+			Expect(repo.Status.WebhookDeliveries[0].Reason).To(Equal("comment contains no command"))
+		})
+
+		It("ignores commands of GitRepos without commands enabled", func() {
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+
+			repo.Spec.Webhooks.Commands = nil
+			Expect(k8sClient.Update(ctx, repo)).To(Succeed())
+
+			send("/renovate run")
+
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+			Expect(repo.Annotations).To(BeEmpty())
+			Expect(repo.Status.WebhookDeliveries).To(HaveLen(1))
+			Expect(repo.Status.WebhookDeliveries[0].Reason).To(Equal("commands not enabled"))
+		})
+	})
+
+	It("keeps a bounded history of webhook deliveries", func() {
+		repo := &renovatev1beta1.GitRepo{}
+		repoKey := client.ObjectKey{Namespace: testNamespace, Name: testGitRepoName}
+		Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+
+		for i := range renovatev1beta1.MaxWebhookDeliveries {
+			repo.Status.WebhookDeliveries = append(repo.Status.WebhookDeliveries, renovatev1beta1.WebhookDelivery{
+				ID:       fmt.Sprintf("delivery-%d", i),
+				Decision: renovatev1beta1.WebhookDeliveryDecision_IGNORED,
+			})
+		}
+
+		Expect(k8sClient.Status().Update(ctx, repo)).To(Succeed())
+
+		mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockRecv.On("Parse", mock.Anything, mock.Anything).Return(receiver.ParseResult{}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader("{}"))
+		req.Header.Set("X-Gitea-Delivery", "new-delivery")
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, req)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+
+		Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+		Expect(repo.Status.WebhookDeliveries).To(HaveLen(renovatev1beta1.MaxWebhookDeliveries))
+		Expect(repo.Status.WebhookDeliveries[0].ID).To(Equal("new-delivery"))
+		Expect(repo.Status.WebhookDeliveries[0].Reason).To(Equal("event does not trigger a run"))
+		Expect(repo.Status.WebhookDeliveries[1].ID).To(Equal("delivery-0"))
+	})
+
+	It("does not record deliveries with an invalid signature", func() {
+		mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("invalid signature"))
+
+		req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader("{}"))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, req)
+		Expect(response.Code).To(Equal(http.StatusForbidden))
+
+		repo := &renovatev1beta1.GitRepo{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{
+			Namespace: testNamespace, Name: testGitRepoName,
+		}, repo)).To(Succeed())
+		Expect(repo.Status.WebhookDeliveries).To(BeEmpty())
+	})
+
+	It("ignores repository lifecycle events of GitRepo webhooks", func() {
+		mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockRecv.On("Parse", mock.Anything, mock.Anything).
+			Return(receiver.ParseResult{Discover: true, Repository: "thegeeklab/project"}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader("{}"))
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, req)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+
+		repo := &renovatev1beta1.GitRepo{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: testGitRepoName}, repo)).To(Succeed())
+		Expect(repo.Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+	})
+
+	Context("replay protection", func() {
+		var repoKey client.ObjectKey
+
+		sendEvent := func(id, body string) int {
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader(body))
+			req.Header.Set("X-GitHub-Delivery", id)
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, req)
+
+			return response.Code
+		}
+
+		sendDelivery := func(id string) int {
+			return sendEvent(id, `{"delivery":"`+id+`"}`)
+		}
+
+		removeTrigger := func() {
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+
+			repo.Annotations = nil
+			Expect(k8sClient.Update(ctx, repo)).To(Succeed())
+		}
+
+		BeforeEach(func() {
+			repoKey = client.ObjectKey{Namespace: testNamespace, Name: testGitRepoName}
+			mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		})
+
+		It("ignores duplicate deliveries", func() {
+			mockRecv.On("Parse", mock.Anything, mock.Anything).
+				Return(receiver.ParseResult{ShouldTrigger: true}, nil)
+
+			Expect(sendDelivery("delivery-1")).To(Equal(http.StatusAccepted))
+			removeTrigger()
+
+			Expect(sendDelivery("delivery-1")).To(Equal(http.StatusAccepted))
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+			Expect(repo.Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+			Expect(repo.Status.WebhookDeliveries).To(HaveLen(2))
+			Expect(repo.Status.WebhookDeliveries[0].Decision).To(Equal(renovatev1beta1.WebhookDeliveryDecision_IGNORED))
+			Expect(repo.Status.WebhookDeliveries[0].Reason).To(Equal("duplicate delivery"))
+
+			Expect(sendDelivery("delivery-2")).To(Equal(http.StatusAccepted))
+
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+			Expect(repo.Annotations).To(HaveKeyWithValue(
+				renovatev1beta1.RenovatorOperation,
+				renovatev1beta1.OperationRenovate,
+			))
+		})
+
+		It("ignores deliveries replayed with another delivery ID", func() {
+			mockRecv.On("Parse", mock.Anything, mock.Anything).
+				Return(receiver.ParseResult{ShouldTrigger: true}, nil)
+
+			Expect(sendEvent("delivery-1", `{"after":"abc"}`)).To(Equal(http.StatusAccepted))
+			removeTrigger()
+
+			Expect(sendEvent("delivery-2", `{"after":"abc"}`)).To(Equal(http.StatusAccepted))
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+			Expect(repo.Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+			Expect(repo.Status.WebhookDeliveries[0].Reason).To(Equal("duplicate delivery"))
+		})
+
+		It("does not de-duplicate deliveries if disabled", func() {
+			config := receiver.DefaultServerConfig()
+			config.DeliveryTTL = 0
+			server = receiver.NewServer(config, k8sClient, func(
+				platformType renovatev1beta1.PlatformType,
+			) receiver.Receiver {
+				return mockRecv
+			}, nil, nil)
+
+			mockRecv.On("Parse", mock.Anything, mock.Anything).
+				Return(receiver.ParseResult{ShouldTrigger: true}, nil)
+
+			Expect(sendDelivery("delivery-1")).To(Equal(http.StatusAccepted))
+			removeTrigger()
+
+			Expect(sendDelivery("delivery-1")).To(Equal(http.StatusAccepted))
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+			Expect(repo.Annotations).To(HaveKey(renovatev1beta1.RenovatorOperation))
+		})
+
+		DescribeTable("checks the clock skew of signed event times",
+			func(offset time.Duration, expectedCode int, triggered bool) {
+				mockRecv.On("Parse", mock.Anything, mock.Anything).Return(receiver.ParseResult{
+					ShouldTrigger: true,
+					Timestamp:     time.Now().Add(offset),
+				}, nil)
+
+				Expect(sendDelivery("delivery-1")).To(Equal(expectedCode))
+
+				repo := &renovatev1beta1.GitRepo{}
+				Expect(k8sClient.Get(ctx, repoKey, repo)).To(Succeed())
+				_, ok := repo.Annotations[renovatev1beta1.RenovatorOperation]
+				Expect(ok).To(Equal(triggered))
+			},
+			Entry("current event", -time.Minute, http.StatusAccepted, true),
+			Entry("replayed event", -time.Hour, http.StatusForbidden, false),
+			Entry("event from the future", time.Hour, http.StatusForbidden, false),
+		)
+	})
+
+	Context("organization webhooks", func() {
+		const discoveryName = "discovery"
+
+		BeforeEach(func() {
+			discovery := &renovatev1beta1.Discovery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      discoveryName,
+					Namespace: testNamespace,
+					UID:       "discovery-uid",
+					Labels:    map[string]string{renovatev1beta1.LabelRenovator: testRenovatorID},
+				},
+			}
+			discoveredRepo := &renovatev1beta1.GitRepo{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "thegeeklab-project",
+					Namespace: testNamespace,
+					OwnerReferences: []metav1.OwnerReference{{
+						APIVersion: renovatev1beta1.GroupVersion.String(),
+						Kind:       "Discovery",
+						Name:       discoveryName,
+						UID:        discovery.UID,
+						Controller: new(true),
+					}},
+				},
+				Spec: renovatev1beta1.GitRepoSpec{Name: "thegeeklab/project"},
+			}
+			webhookSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: discoveryName + "-webhook-secret", Namespace: testNamespace},
+				Data:       map[string][]byte{renovatev1beta1.WebhookSecretDataKey: []byte("webhook-secret")},
+			}
+
+			Expect(k8sClient.Create(ctx, discovery)).To(Succeed())
+			Expect(k8sClient.Create(ctx, discoveredRepo)).To(Succeed())
+			Expect(k8sClient.Create(ctx, webhookSecret)).To(Succeed())
+		})
+
+		It("routes events to the GitRepo matching the repository", func() {
+			mockRecv.On("Validate", mock.Anything, []byte("webhook-secret"), mock.Anything).Return(nil)
+			mockRecv.On("Parse", mock.Anything, mock.Anything).
+				Return(receiver.ParseResult{ShouldTrigger: true, Repository: "TheGeekLab/Project"}, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/discoveries/discovery", strings.NewReader("{}"))
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, req)
+			Expect(response.Code).To(Equal(http.StatusAccepted))
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{
+				Namespace: testNamespace, Name: "thegeeklab-project",
+			}, repo)).To(Succeed())
+			Expect(repo.Annotations).To(HaveKeyWithValue(
+				renovatev1beta1.RenovatorOperation,
+				renovatev1beta1.OperationRenovate,
+			))
+		})
+
+		It("ignores events for repositories not managed by the Discovery", func() {
+			mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockRecv.On("Parse", mock.Anything, mock.Anything).
+				Return(receiver.ParseResult{ShouldTrigger: true, Repository: "thegeeklab/other"}, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/discoveries/discovery", strings.NewReader("{}"))
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, req)
+			Expect(response.Code).To(Equal(http.StatusAccepted))
+
+			repo := &renovatev1beta1.GitRepo{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{
+				Namespace: testNamespace, Name: testGitRepoName,
+			}, repo)).To(Succeed())
+			Expect(repo.Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+		})
+
+		It("triggers the Discovery for repository lifecycle events", func() {
+			mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockRecv.On("Parse", mock.Anything, mock.Anything).
+				Return(receiver.ParseResult{Discover: true, Repository: "thegeeklab/new-service"}, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/discoveries/discovery", strings.NewReader("{}"))
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, req)
+			Expect(response.Code).To(Equal(http.StatusAccepted))
+
+			discovery := &renovatev1beta1.Discovery{}
+			Expect(k8sClient.Get(ctx, client.ObjectKey{
+				Namespace: testNamespace, Name: discoveryName,
+			}, discovery)).To(Succeed())
+			Expect(discovery.Annotations).To(HaveKeyWithValue(
+				renovatev1beta1.RenovatorOperation,
+				renovatev1beta1.OperationDiscover,
+			))
+		})
+
+		It("returns 404 for unknown Discoveries", func() {
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/discoveries/unknown", strings.NewReader("{}"))
+			response := httptest.NewRecorder()
+
+			server.ServeHTTP(response, req)
+			Expect(response.Code).To(Equal(http.StatusNotFound))
+		})
+	})
 })
 
 var _ = Describe("Server Metrics", func() {
@@ -215,7 +820,7 @@ var _ = Describe("Server Metrics", func() {
 			platformType renovatev1beta1.PlatformType,
 		) receiver.Receiver {
 			return mockRecv
-		}, metrics.New(reg, reg, 100))
+		}, nil, metrics.New(reg, reg, 100))
 	})
 
 	metricCount := func(name string) int {
@@ -245,6 +850,32 @@ var _ = Describe("Server Metrics", func() {
 		Expect(metricCount("renovate_operator_webhook_requests_total")).To(Equal(1))
 	})
 
+	It("records coalesced webhook events of debounced GitRepos", func() {
+		repo := &renovatev1beta1.GitRepo{}
+		repoKey := client.ObjectKey{Namespace: testNamespace, Name: testGitRepoName}
+		Expect(k8sClient.Get(context.Background(), repoKey, repo)).To(Succeed())
+
+		repo.Spec.Webhooks.Debounce = &renovatev1beta1.WebhookDebounceSpec{
+			QuietPeriod: metav1.Duration{Duration: time.Minute},
+		}
+		Expect(k8sClient.Update(context.Background(), repo)).To(Succeed())
+
+		mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockRecv.On("Parse", mock.Anything, mock.Anything).
+			Return(receiver.ParseResult{ShouldTrigger: true}, nil)
+
+		for i := range 2 {
+			body := fmt.Sprintf(`{"event":%d}`, i)
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader(body))
+			rec := httptest.NewRecorder()
+
+			server.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusAccepted))
+		}
+
+		Expect(metricCount("renovate_operator_webhook_coalesced_events_total")).To(Equal(1))
+	})
+
 	It("records signature verification failure", func() {
 		mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).
 			Return(errors.New("invalid signature"))
@@ -280,6 +911,68 @@ var _ = Describe("Server Metrics", func() {
 		Expect(err).NotTo(HaveOccurred())
 	})
 
+	It("records duplicate webhook deliveries", func() {
+		mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+		mockRecv.On("Parse", mock.Anything, mock.Anything).
+			Return(receiver.ParseResult{ShouldTrigger: true}, nil)
+
+		for range 2 {
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader("{}"))
+			req.Header.Set("X-Gitea-Delivery", "delivery-1")
+			rec := httptest.NewRecorder()
+
+			server.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusAccepted))
+		}
+
+		expected := `
+			# HELP renovate_operator_webhook_requests_total Total number of webhook requests by provider and result.
+			# TYPE renovate_operator_webhook_requests_total counter
+			renovate_operator_webhook_requests_total{provider="github",result="accepted"} 1
+			renovate_operator_webhook_requests_total{provider="github",result="duplicate"} 1
+		`
+
+		err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "renovate_operator_webhook_requests_total")
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	DescribeTable("records push events filtered by the changed files",
+		func(changedFiles []string, result string) {
+			repo := &renovatev1beta1.GitRepo{}
+			repoKey := client.ObjectKey{Namespace: testNamespace, Name: testGitRepoName}
+			Expect(k8sClient.Get(context.Background(), repoKey, repo)).To(Succeed())
+
+			repo.Spec.Webhooks.PathFilter = &renovatev1beta1.WebhookPathFilterSpec{}
+			Expect(k8sClient.Update(context.Background(), repo)).To(Succeed())
+
+			mockRecv.On("Validate", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+			mockRecv.On("Parse", mock.Anything, mock.Anything).
+				Return(receiver.ParseResult{ShouldTrigger: true, ChangedFiles: changedFiles}, nil)
+
+			req := httptest.NewRequest(http.MethodPost, "/hooks/default/project", strings.NewReader("{}"))
+			rec := httptest.NewRecorder()
+
+			server.ServeHTTP(rec, req)
+			Expect(rec.Code).To(Equal(http.StatusAccepted))
+
+			expected := `
+				# HELP renovate_operator_webhook_requests_total Total number of webhook requests by provider and result.
+				# TYPE renovate_operator_webhook_requests_total counter
+				renovate_operator_webhook_requests_total{provider="github",result="` + result + `"} 1
+			`
+
+			err := testutil.GatherAndCompare(reg, strings.NewReader(expected), "renovate_operator_webhook_requests_total")
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(context.Background(), repoKey, repo)).To(Succeed())
+			Expect(repo.Annotations[renovatev1beta1.RenovatorOperation] == renovatev1beta1.OperationRenovate).
+				To(Equal(result == "accepted"))
+		},
+		Entry("unrelated files", []string{"README.md"}, "filtered"),
+		Entry("dependency manifest", []string{"README.md", "go.mod"}, "accepted"),
+		Entry("unknown files", nil, "accepted"),
+	)
+
 	It("records secret resolution errors", func() {
 		req := httptest.NewRequest(http.MethodPost, "/hooks/nonexistent/project", strings.NewReader("{}"))
 		rec := httptest.NewRecorder()
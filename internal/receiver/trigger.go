@@ -0,0 +1,404 @@
+package receiver
+
+import (
+	"bytes"
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/component/renovator"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// triggerProvider is the provider label of trigger API requests in the webhook
+// request metrics.
+const triggerProvider = "api"
+
+var (
+	ErrTriggerRequestEmpty           = errors.New("trigger request selects neither GitRepos nor a discovery")
+	ErrTriggerTokenSecretFetchFailed = errors.New("failed to fetch trigger token secret")
+)
+
+// TriggerRequest is the body of a trigger API request. It must name at least
+// one GitRepo, set a selector or request a discovery.
+type TriggerRequest struct {
+	// GitRepos lists the names of the GitRepos to renovate.
+	GitRepos []string `json:"gitRepos,omitempty"`
+	// Selector is a label selector matching the GitRepos to renovate.
+	Selector string `json:"selector,omitempty"`
+	// Discovery requests a discovery of the Renovator.
+	Discovery bool `json:"discovery,omitempty"`
+}
+
+// TriggerResponse reports the runs queued by a trigger API request.
+type TriggerResponse struct {
+	// Queued lists the names of the GitRepos a Renovate run was queued for.
+	Queued []string `json:"queued"`
+	// Failed lists the names of the GitRepos a Renovate run failed to be queued for.
+	Failed []string `json:"failed,omitempty"`
+	// NotFound lists the requested GitRepo names not managed by the Renovator.
+	NotFound []string `json:"notFound,omitempty"`
+	// Discovery reports whether a discovery was queued.
+	Discovery bool `json:"discovery"`
+	// DiscoveryFailed reports whether a requested discovery failed to be queued.
+	DiscoveryFailed bool `json:"discoveryFailed,omitempty"`
+}
+
+// failed reports whether a run or discovery failed to be queued.
+func (r TriggerResponse) failed() bool {
+	return len(r.Failed) > 0 || r.DiscoveryFailed
+}
+
+// statusCode returns 202 if all requested runs were queued, 207 if some of them
+// failed to be queued and 500 if none was queued.
+func (r TriggerResponse) statusCode() int {
+	switch {
+	case !r.failed():
+		return http.StatusAccepted
+	case len(r.Queued) > 0 || r.Discovery:
+		return http.StatusMultiStatus
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// result returns the metrics result of the request, i.e. the given result if
+// no run failed to be queued.
+func (r TriggerResponse) result(result string) string {
+	if r.failed() {
+		return "failed"
+	}
+
+	return result
+}
+
+// handleTrigger queues Renovate runs for the GitRepos of a Renovator and
+// optionally a discovery. Requests are authenticated by the bearer token of the
+// Renovator or, failing that, by a Kubernetes TokenReview.
+func (s *Server) handleTrigger(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	namespace := chi.URLParam(r, "namespace")
+	name := chi.URLParam(r, "name")
+
+	instance := &renovatev1beta1.Renovator{}
+	if err := s.client.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, instance); err != nil {
+		http.Error(w, "Renovator not found", http.StatusNotFound)
+
+		return
+	}
+
+	if !s.authenticateTrigger(w, r, instance, triggerProvider) {
+		return
+	}
+
+	request, selector, err := readTriggerRequest(w, r)
+	if err != nil {
+		receiverLog.Info("Invalid trigger request", "namespace", namespace, "name", name, "error", err.Error())
+		http.Error(w, "Bad Request: "+err.Error(), http.StatusBadRequest)
+		s.recordTriggerRequest(triggerProvider, "rejected")
+
+		return
+	}
+
+	repos, notFound, err := s.selectTriggerRepos(ctx, instance, request, selector)
+	if err != nil {
+		receiverLog.Error(err, "Failed to list GitRepos for trigger request")
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+
+		return
+	}
+
+	response := s.triggerRepos(ctx, repos, renovatev1beta1.TriggerSourceAPI)
+	response.NotFound = notFound
+
+	if request.Discovery {
+		patch := client.MergeFrom(instance.DeepCopy())
+		instance.Annotations = renovator.AddOperation(instance.Annotations, renovatev1beta1.OperationDiscover)
+
+		if err := s.client.Patch(ctx, instance, patch); err != nil {
+			receiverLog.Error(err, "Failed to apply discovery annotation", "namespace", namespace, "name", name)
+
+			response.DiscoveryFailed = true
+		} else {
+			response.Discovery = true
+		}
+	}
+
+	receiverLog.Info("Trigger request processed", "namespace", namespace, "name", name,
+		"queued", response.Queued, "failed", response.Failed, "notFound", response.NotFound,
+		"discovery", response.Discovery)
+	s.recordTriggerRequest(triggerProvider, response.result("accepted"))
+	writeTriggerResponse(w, response)
+}
+
+// authenticateTrigger verifies the bearer token of a trigger request. It writes
+// the response and returns false if the request is not authorized. Rejected
+// requests are recorded with the given provider label.
+func (s *Server) authenticateTrigger(
+	w http.ResponseWriter,
+	r *http.Request,
+	instance *renovatev1beta1.Renovator,
+	provider string,
+) bool {
+	token, ok := bearerToken(r)
+	if !ok {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		s.recordTriggerRequest(provider, "rejected")
+
+		return false
+	}
+
+	allowed, err := s.authorizeTrigger(r.Context(), instance, token)
+	if err != nil {
+		receiverLog.Error(err, "Failed to authorize trigger request",
+			"namespace", instance.Namespace, "name", instance.Name)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+
+		return false
+	}
+
+	if !allowed {
+		receiverLog.Info("Trigger request not authorized", "namespace", instance.Namespace, "name", instance.Name)
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		s.recordTriggerRequest(provider, "rejected")
+
+		return false
+	}
+
+	return true
+}
+
+// authorizeTrigger reports whether the token may trigger the Renovator. The
+// token is accepted if it matches the trigger token of the Renovator or if it
+// authenticates a Kubernetes user allowed to update the Renovator.
+func (s *Server) authorizeTrigger(
+	ctx context.Context,
+	instance *renovatev1beta1.Renovator,
+	token string,
+) (bool, error) {
+	if ref := instance.Spec.Trigger.TokenSecret; ref != nil {
+		secret := &corev1.Secret{}
+		if err := s.client.Get(ctx, types.NamespacedName{Namespace: instance.Namespace, Name: ref.Name}, secret); err != nil {
+			return false, fmt.Errorf("%w: %w", ErrTriggerTokenSecretFetchFailed, err)
+		}
+
+		expected := secret.Data[ref.Key]
+		if len(expected) > 0 && subtle.ConstantTimeCompare(expected, []byte(token)) == 1 {
+			return true, nil
+		}
+	}
+
+	return s.reviewTriggerToken(ctx, instance, token)
+}
+
+// reviewTriggerToken authenticates the token by a TokenReview and checks by a
+// SubjectAccessReview that the user may update the Renovator.
+func (s *Server) reviewTriggerToken(
+	ctx context.Context,
+	instance *renovatev1beta1.Renovator,
+	token string,
+) (bool, error) {
+	review := &authenticationv1.TokenReview{
+		Spec: authenticationv1.TokenReviewSpec{Token: token},
+	}
+	if err := s.client.Create(ctx, review); err != nil {
+		return false, fmt.Errorf("failed to review token: %w", err)
+	}
+
+	if !review.Status.Authenticated {
+		return false, nil
+	}
+
+	user := review.Status.User
+
+	extra := make(map[string]authorizationv1.ExtraValue, len(user.Extra))
+	for key, value := range user.Extra {
+		extra[key] = authorizationv1.ExtraValue(value)
+	}
+
+	access := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   user.Username,
+			UID:    user.UID,
+			Groups: user.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: instance.Namespace,
+				Verb:      "update",
+				Group:     renovatev1beta1.GroupVersion.Group,
+				Resource:  "renovators",
+				Name:      instance.Name,
+			},
+		},
+	}
+	if err := s.client.Create(ctx, access); err != nil {
+		return false, fmt.Errorf("failed to review access: %w", err)
+	}
+
+	return access.Status.Allowed, nil
+}
+
+// readTriggerRequest decodes and validates the body of a trigger request. The
+// returned selector is nil if the request does not set one.
+func readTriggerRequest(w http.ResponseWriter, r *http.Request) (TriggerRequest, labels.Selector, error) {
+	var request TriggerRequest
+
+	body, err := readWebhookBody(w, r)
+	if err != nil {
+		return request, nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&request); err != nil {
+		return request, nil, err
+	}
+
+	if len(request.GitRepos) == 0 && request.Selector == "" && !request.Discovery {
+		return request, nil, ErrTriggerRequestEmpty
+	}
+
+	if request.Selector == "" {
+		return request, nil, nil
+	}
+
+	selector, err := labels.Parse(request.Selector)
+	if err != nil {
+		return request, nil, err
+	}
+
+	return request, selector, nil
+}
+
+// selectTriggerRepos returns the GitRepos of the Renovator named by the
+// request or matching its selector, ordered by name, and the requested names
+// without a GitRepo.
+func (s *Server) selectTriggerRepos(
+	ctx context.Context,
+	instance *renovatev1beta1.Renovator,
+	request TriggerRequest,
+	selector labels.Selector,
+) ([]*renovatev1beta1.GitRepo, []string, error) {
+	if len(request.GitRepos) == 0 && selector == nil {
+		return nil, nil, nil
+	}
+
+	repos := &renovatev1beta1.GitRepoList{}
+	if err := s.client.List(ctx, repos, client.InNamespace(instance.Namespace), client.MatchingLabels{
+		renovatev1beta1.LabelRenovator: string(instance.UID),
+	}); err != nil {
+		return nil, nil, err
+	}
+
+	byName := make(map[string]*renovatev1beta1.GitRepo, len(repos.Items))
+	selected := make(map[string]*renovatev1beta1.GitRepo)
+
+	for i := range repos.Items {
+		repo := &repos.Items[i]
+		byName[repo.Name] = repo
+
+		if selector != nil && selector.Matches(labels.Set(repo.Labels)) {
+			selected[repo.Name] = repo
+		}
+	}
+
+	var notFound []string
+
+	for _, name := range request.GitRepos {
+		repo, ok := byName[name]
+		if !ok {
+			notFound = append(notFound, name)
+
+			continue
+		}
+
+		selected[name] = repo
+	}
+
+	result := make([]*renovatev1beta1.GitRepo, 0, len(selected))
+	for _, name := range slices.Sorted(maps.Keys(selected)) {
+		result = append(result, selected[name])
+	}
+
+	return result, notFound, nil
+}
+
+// triggerRepo annotates the GitRepo to start a Renovate run from the given
+// trigger source.
+func (s *Server) triggerRepo(ctx context.Context, repo *renovatev1beta1.GitRepo, source string) error {
+	patch := client.MergeFrom(repo.DeepCopy())
+
+	if repo.Annotations == nil {
+		repo.Annotations = make(map[string]string)
+	}
+
+	repo.Annotations[renovatev1beta1.RenovatorOperation] = renovatev1beta1.OperationRenovate
+	repo.Annotations[renovatev1beta1.AnnotationTriggerSource] = source
+
+	return s.client.Patch(ctx, repo, patch)
+}
+
+// triggerRepos annotates the GitRepos to start a Renovate run from the given
+// trigger source. GitRepos failing to be annotated do not stop the others from
+// being triggered and are reported as failed.
+func (s *Server) triggerRepos(
+	ctx context.Context, repos []*renovatev1beta1.GitRepo, source string,
+) TriggerResponse {
+	response := TriggerResponse{Queued: make([]string, 0, len(repos))}
+
+	for _, repo := range repos {
+		if err := s.triggerRepo(ctx, repo, source); err != nil {
+			receiverLog.Error(err, "Failed to apply trigger annotation", "namespace", repo.Namespace, "name", repo.Name)
+
+			response.Failed = append(response.Failed, repo.Name)
+
+			continue
+		}
+
+		response.Queued = append(response.Queued, repo.Name)
+	}
+
+	return response
+}
+
+// recordTriggerRequest records the result of a trigger request.
+func (s *Server) recordTriggerRequest(provider, result string) {
+	if s.metrics != nil {
+		s.metrics.RecordWebhookRequest(provider, result)
+	}
+}
+
+// writeTriggerResponse writes the runs queued by a trigger request with a status
+// code matching the runs failed to be queued.
+func writeTriggerResponse(w http.ResponseWriter, response TriggerResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(response.statusCode())
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// bearerToken returns the bearer token of the Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") {
+		return "", false
+	}
+
+	token = strings.TrimSpace(token)
+
+	return token, token != ""
+}
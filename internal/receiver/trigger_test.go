@@ -0,0 +1,292 @@
+package receiver_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/receiver"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+const (
+	testRenovatorName = "renovator"
+	testTriggerToken  = "trigger-token"
+	testReviewedToken = "service-account-token"
+)
+
+var _ = Describe("Trigger API", func() {
+	var (
+		ctx           context.Context
+		k8sClient     client.Client
+		server        *receiver.Server
+		authenticated bool
+		allowed       bool
+		accessReview  *authorizationv1.SubjectAccessReview
+		patchFailures map[string]bool
+	)
+
+	newGitRepo := func(name, renovatorID string, labels map[string]string) *renovatev1beta1.GitRepo {
+		repo := &renovatev1beta1.GitRepo{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: testNamespace,
+				Labels:    map[string]string{renovatev1beta1.LabelRenovator: renovatorID},
+			},
+		}
+		for key, value := range labels {
+			repo.Labels[key] = value
+		}
+
+		return repo
+	}
+
+	trigger := func(token, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/trigger/default/"+testRenovatorName, strings.NewReader(body))
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		response := httptest.NewRecorder()
+		server.ServeHTTP(response, req)
+
+		return response
+	}
+
+	decode := func(response *httptest.ResponseRecorder) receiver.TriggerResponse {
+		var result receiver.TriggerResponse
+		Expect(json.Unmarshal(response.Body.Bytes(), &result)).To(Succeed())
+
+		return result
+	}
+
+	getRepo := func(name string) *renovatev1beta1.GitRepo {
+		repo := &renovatev1beta1.GitRepo{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: name}, repo)).To(Succeed())
+
+		return repo
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		authenticated = false
+		allowed = false
+		accessReview = nil
+		patchFailures = map[string]bool{}
+
+		scheme := runtime.NewScheme()
+		Expect(renovatev1beta1.AddToScheme(scheme)).To(Succeed())
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(authenticationv1.AddToScheme(scheme)).To(Succeed())
+		Expect(authorizationv1.AddToScheme(scheme)).To(Succeed())
+
+		instance := &renovatev1beta1.Renovator{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        testRenovatorName,
+				Namespace:   testNamespace,
+				UID:         testRenovatorID,
+				Annotations: map[string]string{renovatev1beta1.RenovatorOperation: "custom"},
+			},
+			Spec: renovatev1beta1.RenovatorSpec{
+				Trigger: renovatev1beta1.TriggerSpec{TokenSecret: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "trigger-token"},
+					Key:                  "token",
+				}},
+			},
+		}
+		tokenSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "trigger-token", Namespace: testNamespace},
+			Data:       map[string][]byte{"token": []byte(testTriggerToken)},
+		}
+
+		k8sClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			instance,
+			tokenSecret,
+			newGitRepo("lib-a", testRenovatorID, map[string]string{"team": "core"}),
+			newGitRepo("lib-b", testRenovatorID, map[string]string{"team": "core"}),
+			newGitRepo("app", testRenovatorID, map[string]string{"team": "web"}),
+			newGitRepo("foreign", "other-renovator", map[string]string{"team": "core"}),
+		).WithInterceptorFuncs(interceptor.Funcs{
+			Create: func(
+				ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption,
+			) error {
+				switch review := obj.(type) {
+				case *authenticationv1.TokenReview:
+					review.Status.Authenticated = authenticated && review.Spec.Token == testReviewedToken
+					review.Status.User = authenticationv1.UserInfo{
+						Username: "system:serviceaccount:ci:release",
+						Groups:   []string{"system:serviceaccounts"},
+					}
+
+					return nil
+				case *authorizationv1.SubjectAccessReview:
+					accessReview = review
+					review.Status.Allowed = allowed
+
+					return nil
+				}
+
+				return c.Create(ctx, obj, opts...)
+			},
+			Patch: func(
+				ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption,
+			) error {
+				if patchFailures[obj.GetName()] {
+					return errors.New("patch failed")
+				}
+
+				return c.Patch(ctx, obj, patch, opts...)
+			},
+		}).Build()
+	})
+
+	JustBeforeEach(func() {
+		server = receiver.NewServer(receiver.DefaultServerConfig(), k8sClient, nil, nil, nil)
+	})
+
+	It("queues runs for the named GitRepos", func() {
+		response := trigger(testTriggerToken, `{"gitRepos":["lib-b","lib-a","missing","foreign"]}`)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+
+		result := decode(response)
+		Expect(result.Queued).To(Equal([]string{"lib-a", "lib-b"}))
+		Expect(result.NotFound).To(Equal([]string{"missing", "foreign"}))
+		Expect(result.Discovery).To(BeFalse())
+
+		for _, name := range []string{"lib-a", "lib-b"} {
+			repo := getRepo(name)
+			Expect(repo.Annotations).To(HaveKeyWithValue(
+				renovatev1beta1.RenovatorOperation, renovatev1beta1.OperationRenovate,
+			))
+			Expect(repo.Annotations).To(HaveKeyWithValue(
+				renovatev1beta1.AnnotationTriggerSource, renovatev1beta1.TriggerSourceAPI,
+			))
+		}
+
+		Expect(getRepo("app").Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+		Expect(getRepo("foreign").Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+	})
+
+	It("queues runs for the GitRepos matching the selector", func() {
+		response := trigger(testTriggerToken, `{"gitRepos":["app"],"selector":"team=core"}`)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+
+		result := decode(response)
+		Expect(result.Queued).To(Equal([]string{"app", "lib-a", "lib-b"}))
+		Expect(result.NotFound).To(BeEmpty())
+		Expect(getRepo("foreign").Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+	})
+
+	It("queues a discovery of the Renovator", func() {
+		response := trigger(testTriggerToken, `{"discovery":true}`)
+		Expect(response.Code).To(Equal(http.StatusAccepted))
+
+		result := decode(response)
+		Expect(result.Queued).To(BeEmpty())
+		Expect(result.Discovery).To(BeTrue())
+
+		instance := &renovatev1beta1.Renovator{}
+		Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: testNamespace, Name: testRenovatorName}, instance)).
+			To(Succeed())
+		Expect(instance.Annotations).To(HaveKeyWithValue(
+			renovatev1beta1.RenovatorOperation, "custom;"+renovatev1beta1.OperationDiscover,
+		))
+	})
+
+	It("queues the remaining runs if a GitRepo fails to be triggered", func() {
+		patchFailures["lib-a"] = true
+
+		response := trigger(testTriggerToken, `{"selector":"team=core"}`)
+		Expect(response.Code).To(Equal(http.StatusMultiStatus))
+
+		result := decode(response)
+		Expect(result.Queued).To(Equal([]string{"lib-b"}))
+		Expect(result.Failed).To(Equal([]string{"lib-a"}))
+		Expect(getRepo("lib-a").Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+		Expect(getRepo("lib-b").Annotations).To(HaveKeyWithValue(
+			renovatev1beta1.RenovatorOperation, renovatev1beta1.OperationRenovate,
+		))
+	})
+
+	It("returns 500 if no run could be queued", func() {
+		patchFailures["app"] = true
+		patchFailures[testRenovatorName] = true
+
+		response := trigger(testTriggerToken, `{"gitRepos":["app"],"discovery":true}`)
+		Expect(response.Code).To(Equal(http.StatusInternalServerError))
+
+		result := decode(response)
+		Expect(result.Queued).To(BeEmpty())
+		Expect(result.Failed).To(Equal([]string{"app"}))
+		Expect(result.Discovery).To(BeFalse())
+		Expect(result.DiscoveryFailed).To(BeTrue())
+	})
+
+	It("returns 404 for an unknown Renovator", func() {
+		req := httptest.NewRequest(http.MethodPost, "/trigger/default/unknown", strings.NewReader(`{"discovery":true}`))
+		req.Header.Set("Authorization", "Bearer "+testTriggerToken)
+		response := httptest.NewRecorder()
+
+		server.ServeHTTP(response, req)
+		Expect(response.Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("returns 401 without a bearer token", func() {
+		response := trigger("", `{"discovery":true}`)
+		Expect(response.Code).To(Equal(http.StatusUnauthorized))
+		Expect(response.Header().Get("WWW-Authenticate")).To(Equal("Bearer"))
+	})
+
+	It("returns 403 for an unknown token", func() {
+		response := trigger("invalid", `{"gitRepos":["lib-a"]}`)
+		Expect(response.Code).To(Equal(http.StatusForbidden))
+		Expect(getRepo("lib-a").Annotations).NotTo(HaveKey(renovatev1beta1.RenovatorOperation))
+	})
+
+	DescribeTable("authenticates tokens by a TokenReview",
+		func(userAllowed bool, expectedCode int) {
+			authenticated = true
+			allowed = userAllowed
+
+			response := trigger(testReviewedToken, `{"gitRepos":["lib-a"]}`)
+			Expect(response.Code).To(Equal(expectedCode))
+
+			Expect(accessReview).NotTo(BeNil())
+			Expect(accessReview.Spec.User).To(Equal("system:serviceaccount:ci:release"))
+			Expect(accessReview.Spec.ResourceAttributes).To(Equal(&authorizationv1.ResourceAttributes{
+				Namespace: testNamespace,
+				Verb:      "update",
+				Group:     renovatev1beta1.GroupVersion.Group,
+				Resource:  "renovators",
+				Name:      testRenovatorName,
+			}))
+		},
+		Entry("user allowed to update the Renovator", true, http.StatusAccepted),
+		Entry("user not allowed to update the Renovator", false, http.StatusForbidden),
+	)
+
+	DescribeTable("returns 400 for invalid requests",
+		func(body string) {
+			response := trigger(testTriggerToken, body)
+			Expect(response.Code).To(Equal(http.StatusBadRequest))
+		},
+		Entry("malformed body", `{"gitRepos":`),
+		Entry("unknown field", `{"repos":["lib-a"]}`),
+		Entry("empty request", `{}`),
+		Entry("invalid selector", `{"selector":"team in (core"}`),
+	)
+})
@@ -2,6 +2,8 @@ package renovate
 
 import (
 	"context"
+	"slices"
+	"strings"
 
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
 	containers "github.com/thegeeklab/renovate-operator/internal/resource/container"
@@ -17,6 +19,8 @@ type jobConfig struct {
 	RenovateCM              string
 	BackoffLimit            *int32
 	TTLSecondsAfterFinished *int32
+	ActiveDeadlineSeconds   *int64
+	Image                   string
 
 	InitContainers            []corev1.Container
 	VolumeMutators            []containers.VolumeMutator
@@ -45,6 +49,7 @@ func DefaultJobSpec(
 	cfg := &jobConfig{
 		Renovate:         renovate,
 		RenovateCM:       renovateCM,
+		Image:            renovate.Spec.Image,
 		VolumeMutators:   []containers.VolumeMutator{containers.WithConfigMapVolume(VolumeRenovateConfig, renovateCM)},
 		EnvVars:          DefaultEnvVars(&renovate.Spec),
 		ImagePullSecrets: append([]corev1.LocalObjectReference(nil), renovate.Spec.ImagePullSecrets...),
@@ -72,6 +77,7 @@ func DefaultJobSpec(
 	spec.Parallelism = new(int32(1))
 	spec.BackoffLimit = cfg.BackoffLimit
 	spec.TTLSecondsAfterFinished = cfg.TTLSecondsAfterFinished
+	spec.ActiveDeadlineSeconds = cfg.ActiveDeadlineSeconds
 
 	spec.Template.Labels = cfg.PodLabels
 	spec.Template.Annotations = cfg.PodAnnotations
@@ -110,7 +116,7 @@ func DefaultJobSpec(
 	spec.Template.Spec.Containers = []corev1.Container{
 		containers.ContainerTemplate(
 			ContainerName,
-			renovate.Spec.Image,
+			cfg.Image,
 			renovate.Spec.ImagePullPolicy,
 			containerMutators...,
 		),
@@ -170,6 +176,40 @@ func WithRepository(targetRepo string) JobOption {
 	}
 }
 
+// WithRepositories configures the job to run against several repositories in
+// a single renovate process.
+func WithRepositories(targetRepos []string) JobOption {
+	return func(c *jobConfig) {
+		c.EnvVars = append(c.EnvVars, corev1.EnvVar{
+			Name:  "RENOVATE_REPOSITORIES",
+			Value: strings.Join(targetRepos, ","),
+		})
+	}
+}
+
+// BatchLabel returns the label key associating a batch job with the GitRepo of
+// the given sanitized label value.
+func BatchLabel(repoLabel string) string {
+	return renovatev1beta1.LabelBatchGitRepoPrefix + repoLabel
+}
+
+// BatchRepoLabels returns the sanitized labels of the GitRepos processed by a
+// batch job or nil if the labels do not belong to a batch job.
+func BatchRepoLabels(labels map[string]string) []string {
+	var repoLabels []string
+
+	for key, value := range labels {
+		if repoLabel, ok := strings.CutPrefix(key, renovatev1beta1.LabelBatchGitRepoPrefix); ok &&
+			value == renovatev1beta1.ValueTrue {
+			repoLabels = append(repoLabels, repoLabel)
+		}
+	}
+
+	slices.Sort(repoLabels)
+
+	return repoLabels
+}
+
 // WithInitContainer allows injecting an InitContainer.
 func WithInitContainer(container corev1.Container) JobOption {
 	return func(c *jobConfig) {
@@ -204,6 +244,50 @@ func WithRenovateJobSpec(js renovatev1beta1.JobSpec) JobOption {
 	}
 }
 
+// WithGitRepoOverrides applies the per-repository overrides of a GitRepo. It
+// must be applied after the options of the Runner to take precedence.
+func WithGitRepoOverrides(overrides *renovatev1beta1.GitRepoOverrides) JobOption {
+	return func(c *jobConfig) {
+		if overrides == nil {
+			return
+		}
+
+		if overrides.Image != "" {
+			c.Image = overrides.Image
+		}
+
+		if overrides.Resources != nil {
+			c.Resources = *overrides.Resources
+		}
+
+		if overrides.NodeSelector != nil {
+			c.NodeSelector = overrides.NodeSelector
+		}
+
+		if overrides.Tolerations != nil {
+			c.Tolerations = overrides.Tolerations
+		}
+
+		if overrides.Timeout != nil {
+			c.ActiveDeadlineSeconds = new(int64(overrides.Timeout.Seconds()))
+		}
+
+		c.EnvVars = append(c.EnvVars, overrides.ExtraEnv...)
+	}
+}
+
+// WithLogLevel sets the Renovate log level, replacing the level of the
+// RenovateConfig and of any extra environment variable. It must be applied
+// after all options adding environment variables.
+func WithLogLevel(level renovatev1beta1.LogLevel) JobOption {
+	return func(c *jobConfig) {
+		c.EnvVars = slices.DeleteFunc(c.EnvVars, func(env corev1.EnvVar) bool {
+			return env.Name == EnvRenovateLogLevel
+		})
+		c.EnvVars = append(c.EnvVars, corev1.EnvVar{Name: EnvRenovateLogLevel, Value: string(level)})
+	}
+}
+
 // GetActiveJobs returns a list of running jobs matching the given labels.
 func GetActiveJobs(
 	ctx context.Context, c client.Client, namespace string, labels map[string]string,
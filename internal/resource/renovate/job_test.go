@@ -2,6 +2,7 @@ package renovate_test
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
@@ -173,6 +174,16 @@ var _ = Describe("Renovate Job Library", func() {
 					Expect(env).To(ContainElement(HaveField("Value", "org/repo")))
 				},
 			),
+			Entry(
+				"WithRepositories",
+				[]renovate.JobOption{renovate.WithRepositories([]string{"org/repo-a", "org/repo-b"})},
+				func(spec *batchv1.JobSpec) {
+					env := spec.Template.Spec.Containers[0].Env
+					Expect(env).To(ContainElement(corev1.EnvVar{
+						Name: "RENOVATE_REPOSITORIES", Value: "org/repo-a,org/repo-b",
+					}))
+				},
+			),
 			Entry(
 				"WithInitContainer",
 				[]renovate.JobOption{renovate.WithInitContainer(corev1.Container{Name: "init", Image: "busybox"})},
@@ -432,6 +443,43 @@ var _ = Describe("Renovate Job Library", func() {
 					Expect(env).To(ContainElement(HaveField("Name", "A")))
 				},
 			),
+			Entry(
+				"GitRepo Overrides",
+				[]renovate.JobOption{
+					renovate.WithPodSpec(renovatev1beta1.PodSpec{
+						NodeSelector: map[string]string{"pool": "default"},
+					}),
+					renovate.WithGitRepoOverrides(&renovatev1beta1.GitRepoOverrides{
+						Image:        "renovate/renovate:full",
+						NodeSelector: map[string]string{"pool": "large"},
+						Resources: &corev1.ResourceRequirements{
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("4Gi")},
+						},
+						ExtraEnv: []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "trace"}},
+						Timeout:  &metav1.Duration{Duration: 30 * time.Minute},
+					}),
+				},
+				func(spec *batchv1.JobSpec) {
+					container := spec.Template.Spec.Containers[0]
+					Expect(container.Image).To(Equal("renovate/renovate:full"))
+					Expect(container.Resources.Limits.Memory().String()).To(Equal("4Gi"))
+					Expect(container.Env).To(ContainElement(HaveField("Name", "LOG_LEVEL")))
+					Expect(spec.Template.Spec.NodeSelector).To(HaveKeyWithValue("pool", "large"))
+					Expect(spec.ActiveDeadlineSeconds).To(Equal(new(int64(1800))))
+				},
+			),
+			Entry(
+				"Log Level",
+				[]renovate.JobOption{
+					renovate.WithExtraEnv([]corev1.EnvVar{{Name: "RENOVATE_LOG_LEVEL", Value: "warn"}}),
+					renovate.WithLogLevel(renovatev1beta1.LogLevel_DEBUG),
+				},
+				func(spec *batchv1.JobSpec) {
+					env := spec.Template.Spec.Containers[0].Env
+					Expect(env).To(ContainElement(corev1.EnvVar{Name: "RENOVATE_LOG_LEVEL", Value: "debug"}))
+					Expect(env).NotTo(ContainElement(HaveField("Value", "warn")))
+				},
+			),
 		)
 	})
 
@@ -472,4 +520,22 @@ var _ = Describe("Renovate Job Library", func() {
 			}, 1),
 		)
 	})
+
+	Describe("Batch Labels", func() {
+		It("returns the GitRepos of a batch job", func() {
+			labels := map[string]string{
+				renovatev1beta1.LabelRenovator: "renovator",
+				renovate.BatchLabel("repo-b"):  renovatev1beta1.ValueTrue,
+				renovate.BatchLabel("repo-a"):  renovatev1beta1.ValueTrue,
+			}
+
+			Expect(renovate.BatchRepoLabels(labels)).To(Equal([]string{"repo-a", "repo-b"}))
+		})
+
+		It("returns nil for a single repository job", func() {
+			labels := map[string]string{renovatev1beta1.LabelGitRepo: "repo-a"}
+
+			Expect(renovate.BatchRepoLabels(labels)).To(BeNil())
+		})
+	})
 })
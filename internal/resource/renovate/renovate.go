@@ -16,7 +16,8 @@ const (
 	FilenameRenovateConfig = "renovate.json"
 	FilenameRepositories   = "repositories.json"
 
-	EnvRenovateConfig = "RENOVATE_CONFIG_FILE"
+	EnvRenovateConfig   = "RENOVATE_CONFIG_FILE"
+	EnvRenovateLogLevel = "RENOVATE_LOG_LEVEL"
 
 	// ContainerName is the name of the main container in the Renovate Job pod.
 	ContainerName = "renovate"
@@ -35,7 +36,7 @@ func DefaultEnvVars(renovate *renovatev1beta1.RenovateConfigSpec) []corev1.EnvVa
 			Value: "json",
 		},
 		{
-			Name:  "RENOVATE_LOG_LEVEL",
+			Name:  EnvRenovateLogLevel,
 			Value: string(renovate.Logging.Level),
 		},
 		{
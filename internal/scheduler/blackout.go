@@ -0,0 +1,140 @@
+package scheduler
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+)
+
+// maxBlackoutChain limits how many overlapping blackout windows are followed
+// to find the end of a blackout.
+const maxBlackoutChain = 32
+
+var ErrInvalidBlackoutWindow = errors.New("invalid blackout window")
+
+// BlackoutSchedulable is implemented by Schedulable objects supporting blackout windows.
+type BlackoutSchedulable interface {
+	GetBlackoutWindows() []renovatev1beta1.BlackoutWindowSpec
+}
+
+// Blackout describes the blackout windows active at a point in time.
+type Blackout struct {
+	// Name is the name of the first active window.
+	Name string
+	// End is the earliest time at which no window is active anymore. Overlapping
+	// and adjacent windows are merged.
+	End time.Time
+	// BlockWebhooks is true if any active window suppresses webhook triggered runs.
+	BlockWebhooks bool
+}
+
+// ActiveBlackout returns the blackout of obj active at the current time.
+func (m *Manager) ActiveBlackout(obj Schedulable) (Blackout, bool, error) {
+	return ActiveBlackoutAt(obj, m.clock.Now())
+}
+
+// ValidateBlackoutWindows returns an error if a window is neither a valid
+// recurring nor a valid fixed window.
+func ValidateBlackoutWindows(windows []renovatev1beta1.BlackoutWindowSpec, tz string) error {
+	for _, window := range windows {
+		if _, _, err := windowEnd(window, tz, time.Time{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ActiveBlackoutAt returns the blackout of obj active at the given time.
+func ActiveBlackoutAt(obj Schedulable, t time.Time) (Blackout, bool, error) {
+	bs, ok := obj.(BlackoutSchedulable)
+	if !ok {
+		return Blackout{}, false, nil
+	}
+
+	windows := bs.GetBlackoutWindows()
+	tz := obj.GetTimezone()
+
+	var (
+		blackout Blackout
+		active   bool
+	)
+
+	at := t
+
+	for range maxBlackoutChain {
+		extended := false
+
+		for _, window := range windows {
+			end, isActive, err := windowEnd(window, tz, at)
+			if err != nil {
+				return Blackout{}, false, err
+			}
+
+			if !isActive {
+				continue
+			}
+
+			if !active {
+				blackout.Name = window.Name
+				active = true
+			}
+
+			if at.Equal(t) && window.BlockWebhooks {
+				blackout.BlockWebhooks = true
+			}
+
+			if end.After(blackout.End) {
+				blackout.End = end
+				extended = true
+			}
+		}
+
+		if !extended {
+			break
+		}
+
+		at = blackout.End
+	}
+
+	return blackout, active, nil
+}
+
+// windowEnd returns the end of the window if it is active at t.
+func windowEnd(window renovatev1beta1.BlackoutWindowSpec, tz string, t time.Time) (time.Time, bool, error) {
+	if window.Schedule != "" {
+		if window.Duration == nil || window.Duration.Duration <= 0 {
+			return time.Time{}, false, fmt.Errorf("%w %q: recurring window requires a positive duration",
+				ErrInvalidBlackoutWindow, window.Name)
+		}
+
+		schedule, err := parseSchedule(window.Schedule, tz)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("%w %q: %w", ErrInvalidBlackoutWindow, window.Name, err)
+		}
+
+		start := schedule.Next(t.Add(-window.Duration.Duration))
+		if start.IsZero() || start.After(t) {
+			return time.Time{}, false, nil
+		}
+
+		return start.Add(window.Duration.Duration), true, nil
+	}
+
+	if window.End == nil {
+		return time.Time{}, false, fmt.Errorf("%w %q: requires either a schedule or an end",
+			ErrInvalidBlackoutWindow, window.Name)
+	}
+
+	if window.Start != nil && !window.Start.Before(window.End) {
+		return time.Time{}, false, fmt.Errorf("%w %q: start must be before end", ErrInvalidBlackoutWindow, window.Name)
+	}
+
+	if (window.Start != nil && t.Before(window.Start.Time)) || !t.Before(window.End.Time) {
+		return time.Time{}, false, nil
+	}
+
+	return window.End.Time, true, nil
+}
@@ -0,0 +1,158 @@
+package scheduler
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	fakeclock "k8s.io/utils/clock/testing"
+)
+
+var _ = Describe("Blackout Windows", func() {
+	var (
+		mgr *Manager
+		obj *MockSchedulable
+		now time.Time
+	)
+
+	BeforeEach(func() {
+		now = time.Date(2026, 2, 25, 12, 0, 0, 0, time.UTC)
+		mgr = NewManager(nil, nil, fakeclock.NewFakeClock(now))
+
+		obj = &MockSchedulable{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-task"},
+			Schedule:   "*/5 * * * *",
+		}
+	})
+
+	Describe("Evaluate", func() {
+		Context("when a recurring window is active", func() {
+			BeforeEach(func() {
+				obj.BlackoutWindows = []renovatev1beta1.BlackoutWindowSpec{
+					{Name: "daily", Schedule: "0 11 * * *", Duration: &metav1.Duration{Duration: 2 * time.Hour}},
+				}
+			})
+
+			It("should suppress the scheduled run until the window ends", func() {
+				res, err := mgr.Evaluate(obj, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.ShouldRun).To(BeFalse())
+				Expect(res.Trigger).To(Equal(TriggerBlackout))
+				Expect(res.NextRun).To(Equal(time.Date(2026, 2, 25, 13, 0, 0, 0, time.UTC)))
+			})
+
+			It("should still trigger if a manual override is present", func() {
+				manualTrigger := func(ann map[string]string) bool { return true }
+				res, err := mgr.Evaluate(obj, manualTrigger)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.ShouldRun).To(BeTrue())
+				Expect(res.Trigger).To(Equal(TriggerManual))
+			})
+		})
+
+		Context("when a recurring window is not active", func() {
+			It("should trigger the job", func() {
+				obj.BlackoutWindows = []renovatev1beta1.BlackoutWindowSpec{
+					{Name: "nightly", Schedule: "0 22 * * *", Duration: &metav1.Duration{Duration: 8 * time.Hour}},
+				}
+
+				res, err := mgr.Evaluate(obj, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.ShouldRun).To(BeTrue())
+				Expect(res.Trigger).To(Equal(TriggerSchedule))
+			})
+		})
+
+		Context("when the next run falls into a fixed window", func() {
+			It("should defer the next run to the end of the window", func() {
+				lastRun := metav1.NewTime(now)
+				obj.LastScheduleTime = &lastRun
+				obj.BlackoutWindows = []renovatev1beta1.BlackoutWindowSpec{
+					{
+						Name:  "freeze",
+						Start: &metav1.Time{Time: now.Add(-time.Hour)},
+						End:   &metav1.Time{Time: now.Add(30 * time.Minute)},
+					},
+				}
+
+				res, err := mgr.Evaluate(obj, nil)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.ShouldRun).To(BeFalse())
+				Expect(res.Trigger).To(Equal(TriggerWait))
+				Expect(res.NextRun).To(Equal(now.Add(30 * time.Minute)))
+			})
+		})
+
+		Context("with an invalid blackout window", func() {
+			It("should return an error", func() {
+				obj.BlackoutWindows = []renovatev1beta1.BlackoutWindowSpec{{Name: "broken"}}
+
+				_, err := mgr.Evaluate(obj, nil)
+				Expect(err).To(MatchError(ErrInvalidBlackoutWindow))
+			})
+		})
+	})
+
+	Describe("ActiveBlackout", func() {
+		It("should merge overlapping windows", func() {
+			obj.BlackoutWindows = []renovatev1beta1.BlackoutWindowSpec{
+				{Name: "freeze", End: &metav1.Time{Time: now.Add(30 * time.Minute)}},
+				{Name: "maintenance", Schedule: "30 12 * * *", Duration: &metav1.Duration{Duration: time.Hour}},
+			}
+
+			blackout, active, err := mgr.ActiveBlackout(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeTrue())
+			Expect(blackout.Name).To(Equal("freeze"))
+			Expect(blackout.End).To(Equal(now.Add(90 * time.Minute)))
+			Expect(blackout.BlockWebhooks).To(BeFalse())
+		})
+
+		It("should report windows blocking webhooks", func() {
+			obj.BlackoutWindows = []renovatev1beta1.BlackoutWindowSpec{
+				{Name: "freeze", End: &metav1.Time{Time: now.Add(time.Hour)}, BlockWebhooks: true},
+			}
+
+			blackout, active, err := mgr.ActiveBlackout(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeTrue())
+			Expect(blackout.BlockWebhooks).To(BeTrue())
+		})
+
+		It("should ignore windows that have ended", func() {
+			obj.BlackoutWindows = []renovatev1beta1.BlackoutWindowSpec{
+				{Name: "freeze", End: &metav1.Time{Time: now}},
+			}
+
+			_, active, err := mgr.ActiveBlackout(obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeFalse())
+		})
+	})
+
+	Describe("ValidateBlackoutWindows", func() {
+		It("should accept recurring and fixed windows", func() {
+			err := ValidateBlackoutWindows([]renovatev1beta1.BlackoutWindowSpec{
+				{Name: "weekend", Schedule: "0 0 * * 6", Duration: &metav1.Duration{Duration: 48 * time.Hour}},
+				{Name: "freeze", Start: &metav1.Time{Time: now}, End: &metav1.Time{Time: now.Add(time.Hour)}},
+			}, "Europe/Berlin")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should reject a fixed window ending before its start", func() {
+			err := ValidateBlackoutWindows([]renovatev1beta1.BlackoutWindowSpec{
+				{Name: "freeze", Start: &metav1.Time{Time: now}, End: &metav1.Time{Time: now.Add(-time.Hour)}},
+			}, "")
+			Expect(err).To(MatchError(ErrInvalidBlackoutWindow))
+		})
+
+		It("should reject an invalid window schedule", func() {
+			err := ValidateBlackoutWindows([]renovatev1beta1.BlackoutWindowSpec{
+				{Name: "weekend", Schedule: "invalid-cron", Duration: &metav1.Duration{Duration: time.Hour}},
+			}, "")
+			Expect(err).To(MatchError(ErrInvalidBlackoutWindow))
+		})
+	})
+})
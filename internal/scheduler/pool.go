@@ -0,0 +1,291 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
+	batchv1 "k8s.io/api/batch/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// PoolRetryInterval is the interval in which Jobs denied by a RunnerPool
+	// should be requested again.
+	PoolRetryInterval = 30 * time.Second
+
+	// poolWaitTimeout is the time a waiting Renovator is considered for the fair
+	// share of a RunnerPool after its last request.
+	poolWaitTimeout = 4 * PoolRetryInterval
+
+	// poolReservationTimeout is the time a granted job slot is reserved for a
+	// Job that has not been observed, e.g. because the cache lags behind.
+	poolReservationTimeout = PoolRetryInterval
+)
+
+var (
+	ErrRunnerPoolFull       = errors.New("runner pool has no free job slot")
+	ErrRunnerPoolNotAllowed = errors.New("runner pool does not allow the namespace")
+)
+
+// PoolSchedulable is implemented by Schedulable objects whose Jobs are limited
+// by a RunnerPool.
+type PoolSchedulable interface {
+	GetRunnerPool() *types.NamespacedName
+}
+
+// CountActivePoolJobs returns the number of active Jobs in the RunnerPool per
+// owning Runner.
+func (m *Manager) CountActivePoolJobs(
+	ctx context.Context, pool types.NamespacedName,
+) (map[types.NamespacedName]int32, error) {
+	jobs, err := m.listPoolJobs(ctx, pool)
+	if err != nil {
+		return nil, err
+	}
+
+	return countActivePoolJobs(jobs), nil
+}
+
+func (m *Manager) listPoolJobs(ctx context.Context, pool types.NamespacedName) ([]batchv1.Job, error) {
+	label, err := poolLabel(pool)
+	if err != nil {
+		return nil, err
+	}
+
+	var jobList batchv1.JobList
+	if err := m.List(ctx, &jobList, client.MatchingLabels{renovatev1beta1.LabelRunnerPool: label}); err != nil {
+		return nil, fmt.Errorf("failed to list jobs of runner pool %s: %w", pool, err)
+	}
+
+	return jobList.Items, nil
+}
+
+func countActivePoolJobs(jobs []batchv1.Job) map[types.NamespacedName]int32 {
+	active := make(map[types.NamespacedName]int32)
+
+	for i := range jobs {
+		job := &jobs[i]
+		if IsJobFinished(job) {
+			continue
+		}
+
+		ownerRef := metav1.GetControllerOf(job)
+		if ownerRef == nil {
+			continue
+		}
+
+		active[types.NamespacedName{Namespace: job.Namespace, Name: ownerRef.Name}]++
+	}
+
+	return active
+}
+
+// admitPoolJob grants a job slot of the RunnerPool to the owner and labels the
+// Job as a member of the pool. The granted slot is reserved in the status of
+// the RunnerPool, which is patched with an optimistic lock so concurrent
+// admissions are decided one after another. The admission is retried on a
+// conflict before the Job is created.
+func (m *Manager) admitPoolJob(
+	ctx context.Context, owner Schedulable, key types.NamespacedName, job *batchv1.Job,
+) error {
+	label, err := poolLabel(key)
+	if err != nil {
+		return err
+	}
+
+	reservation := renovatev1beta1.RunnerPoolReservation{
+		ID:        string(uuid.NewUUID()),
+		Namespace: owner.GetNamespace(),
+		Name:      owner.GetName(),
+	}
+
+	var admitted bool
+
+	err = retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		pool := &renovatev1beta1.RunnerPool{}
+		if err := m.Get(ctx, key, pool); err != nil {
+			return fmt.Errorf("failed to get runner pool %s: %w", key, err)
+		}
+
+		if !pool.AllowsNamespace(owner.GetNamespace()) {
+			return fmt.Errorf("%w: %s: %s", ErrRunnerPoolNotAllowed, key, owner.GetNamespace())
+		}
+
+		jobs, err := m.listPoolJobs(ctx, key)
+		if err != nil {
+			return err
+		}
+
+		reservation.Time = metav1.NewTime(m.clock.Now())
+
+		admitted, err = m.reservePoolSlot(ctx, pool, jobs, reservation)
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if !admitted {
+		return fmt.Errorf("%w: %s", ErrRunnerPoolFull, key)
+	}
+
+	labels := make(map[string]string, len(job.Labels)+1)
+	maps.Copy(labels, job.Labels)
+	labels[renovatev1beta1.LabelRunnerPool] = label
+	job.Labels = labels
+
+	annotations := make(map[string]string, len(job.Annotations)+1)
+	maps.Copy(annotations, job.Annotations)
+	annotations[renovatev1beta1.AnnotationPoolReservation] = reservation.ID
+	job.Annotations = annotations
+
+	return nil
+}
+
+// reservePoolSlot decides whether the member of the reservation is granted a
+// job slot and records the decision in the status of the RunnerPool. Pending
+// reservations count as active Jobs, so Jobs not yet observed in the cache do
+// not free their slot. While Renovators wait for free slots, each of them is
+// granted a share of the slots proportional to its weight. Slots not claimed by
+// a waiting Renovator below its share are available to all.
+func (m *Manager) reservePoolSlot(
+	ctx context.Context,
+	pool *renovatev1beta1.RunnerPool,
+	jobs []batchv1.Job,
+	reservation renovatev1beta1.RunnerPoolReservation,
+) (bool, error) {
+	member := types.NamespacedName{Namespace: reservation.Namespace, Name: reservation.Name}
+	now := reservation.Time.Time
+
+	active := countActivePoolJobs(jobs)
+	reservations := pendingReservations(pool.Status.Reservations, jobs, now)
+
+	for _, pending := range reservations {
+		active[types.NamespacedName{Namespace: pending.Namespace, Name: pending.Name}]++
+	}
+
+	waiting := make(map[types.NamespacedName]struct{})
+
+	for _, waiter := range pool.Status.Waiting {
+		if now.Sub(waiter.LastRequestTime.Time) < poolWaitTimeout {
+			waiting[types.NamespacedName{Namespace: waiter.Namespace, Name: waiter.Name}] = struct{}{}
+		}
+	}
+
+	admitted := isPoolSlotGranted(pool, active, waiting, member)
+
+	patch := client.MergeFromWithOptions(pool.DeepCopy(), client.MergeFromWithOptimisticLock{})
+
+	pool.Status.Waiting = slices.DeleteFunc(pool.Status.Waiting, func(waiter renovatev1beta1.RunnerPoolWaiter) bool {
+		return (waiter.Namespace == member.Namespace && waiter.Name == member.Name) ||
+			now.Sub(waiter.LastRequestTime.Time) >= poolWaitTimeout
+	})
+
+	pool.Status.Active = 0
+	for _, count := range active {
+		pool.Status.Active += count
+	}
+
+	if admitted {
+		pool.Status.Active++
+		reservations = append(reservations, reservation)
+	} else {
+		pool.Status.Waiting = append(pool.Status.Waiting, renovatev1beta1.RunnerPoolWaiter{
+			Namespace:       member.Namespace,
+			Name:            member.Name,
+			LastRequestTime: reservation.Time,
+		})
+	}
+
+	pool.Status.Reservations = reservations
+
+	if err := m.Status().Patch(ctx, pool, patch); err != nil {
+		return false, fmt.Errorf("failed to patch status of runner pool %s: %w", client.ObjectKeyFromObject(pool), err)
+	}
+
+	return admitted, nil
+}
+
+// pendingReservations returns the reservations whose Job has not been observed
+// yet. Reservations expire after poolReservationTimeout, e.g. if the Job could
+// not be created.
+func pendingReservations(
+	reservations []renovatev1beta1.RunnerPoolReservation, jobs []batchv1.Job, now time.Time,
+) []renovatev1beta1.RunnerPoolReservation {
+	observed := make(map[string]struct{}, len(jobs))
+
+	for i := range jobs {
+		if id := jobs[i].Annotations[renovatev1beta1.AnnotationPoolReservation]; id != "" {
+			observed[id] = struct{}{}
+		}
+	}
+
+	return slices.DeleteFunc(slices.Clone(reservations), func(reservation renovatev1beta1.RunnerPoolReservation) bool {
+		_, ok := observed[reservation.ID]
+
+		return ok || now.Sub(reservation.Time.Time) >= poolReservationTimeout
+	})
+}
+
+// isPoolSlotGranted reports whether a free job slot of the RunnerPool is
+// granted to the member given the active Jobs per member.
+func isPoolSlotGranted(
+	pool *renovatev1beta1.RunnerPool,
+	active map[types.NamespacedName]int32,
+	waiting map[types.NamespacedName]struct{},
+	member types.NamespacedName,
+) bool {
+	var total int32
+
+	members := map[types.NamespacedName]struct{}{member: {}}
+
+	for other, count := range active {
+		total += count
+		members[other] = struct{}{}
+	}
+
+	for other := range waiting {
+		members[other] = struct{}{}
+	}
+
+	if total >= pool.Spec.MaxParallel {
+		return false
+	}
+
+	var totalWeight int32
+	for other := range members {
+		totalWeight += pool.GetWeight(other)
+	}
+
+	share := func(other types.NamespacedName) int32 {
+		return max(1, pool.Spec.MaxParallel*pool.GetWeight(other)/totalWeight)
+	}
+
+	if active[member] < share(member) {
+		return true
+	}
+
+	for other := range waiting {
+		if other != member && active[other] < share(other) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// poolLabel returns the label value identifying the Jobs of a RunnerPool.
+func poolLabel(pool types.NamespacedName) (string, error) {
+	return k8s.SanitizeLabel(pool.Namespace + "." + pool.Name)
+}
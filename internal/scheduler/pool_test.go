@@ -0,0 +1,247 @@
+package scheduler
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	fakeclock "k8s.io/utils/clock/testing"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+var _ = Describe("RunnerPool", func() {
+	var (
+		ctx       context.Context
+		scheme    *runtime.Scheme
+		mgr       *Manager
+		fakeClock *fakeclock.FakeClock
+		pool      *renovatev1beta1.RunnerPool
+		runnerA   *renovatev1beta1.Runner
+		runnerB   *renovatev1beta1.Runner
+	)
+
+	newRunner := func(namespace string) *renovatev1beta1.Runner {
+		return &renovatev1beta1.Runner{
+			ObjectMeta: metav1.ObjectMeta{Name: "renovator", Namespace: namespace},
+			Spec: renovatev1beta1.RunnerSpec{
+				Pool: &renovatev1beta1.RunnerPoolRef{Name: "shared", Namespace: "renovate-system"},
+			},
+		}
+	}
+
+	ensureJob := func(runner *renovatev1beta1.Runner, repo string) (bool, error) {
+		labels := map[string]string{renovatev1beta1.LabelGitRepo: repo}
+		job := &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{GenerateName: repo + "-", Namespace: runner.Namespace, Labels: labels},
+		}
+
+		return mgr.EnsureJob(ctx, runner, job, labels)
+	}
+
+	finishJob := func(namespace, repo string) {
+		jobList := &batchv1.JobList{}
+		Expect(mgr.List(ctx, jobList, client.InNamespace(namespace),
+			client.MatchingLabels{renovatev1beta1.LabelGitRepo: repo})).To(Succeed())
+		Expect(jobList.Items).To(HaveLen(1))
+
+		job := &jobList.Items[0]
+		job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+		Expect(mgr.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(renovatev1beta1.AddToScheme(scheme)).To(Succeed())
+
+		pool = &renovatev1beta1.RunnerPool{
+			ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "renovate-system"},
+			Spec: renovatev1beta1.RunnerPoolSpec{
+				MaxParallel:       2,
+				AllowedNamespaces: []string{"team-a", "team-b"},
+			},
+		}
+		runnerA = newRunner("team-a")
+		runnerB = newRunner("team-b")
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(pool, runnerA, runnerB).
+			WithStatusSubresource(pool, &batchv1.Job{}).
+			Build()
+
+		fakeClock = fakeclock.NewFakeClock(time.Date(2026, 2, 25, 12, 0, 0, 0, time.UTC))
+		mgr = NewManager(fakeClient, scheme, fakeClock)
+	})
+
+	It("should limit the jobs of all runners referencing the pool", func() {
+		Expect(ensureJob(runnerA, "repo-1")).To(BeTrue())
+		Expect(ensureJob(runnerA, "repo-2")).To(BeTrue())
+
+		_, err := ensureJob(runnerB, "repo-1")
+		Expect(err).To(MatchError(ErrRunnerPoolFull))
+
+		active, err := mgr.CountActivePoolJobs(ctx, client.ObjectKeyFromObject(pool))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(active).To(HaveKeyWithValue(client.ObjectKeyFromObject(runnerA), int32(2)))
+
+		updated := &renovatev1beta1.RunnerPool{}
+		Expect(mgr.Get(ctx, client.ObjectKeyFromObject(pool), updated)).To(Succeed())
+		Expect(updated.Status.Active).To(Equal(int32(2)))
+		Expect(updated.Status.Waiting).To(HaveLen(1))
+		Expect(updated.Status.Waiting[0].Namespace).To(Equal("team-b"))
+	})
+
+	It("should grant free slots to waiting renovators below their share", func() {
+		Expect(ensureJob(runnerA, "repo-1")).To(BeTrue())
+		Expect(ensureJob(runnerA, "repo-2")).To(BeTrue())
+
+		_, err := ensureJob(runnerB, "repo-1")
+		Expect(err).To(MatchError(ErrRunnerPoolFull))
+
+		finishJob("team-a", "repo-1")
+
+		_, err = ensureJob(runnerA, "repo-3")
+		Expect(err).To(MatchError(ErrRunnerPoolFull))
+
+		Expect(ensureJob(runnerB, "repo-1")).To(BeTrue())
+
+		updated := &renovatev1beta1.RunnerPool{}
+		Expect(mgr.Get(ctx, client.ObjectKeyFromObject(pool), updated)).To(Succeed())
+		Expect(updated.Status.Waiting).To(HaveLen(1))
+		Expect(updated.Status.Waiting[0].Namespace).To(Equal("team-a"))
+	})
+
+	It("should ignore renovators that stopped waiting", func() {
+		Expect(ensureJob(runnerA, "repo-1")).To(BeTrue())
+		Expect(ensureJob(runnerA, "repo-2")).To(BeTrue())
+
+		_, err := ensureJob(runnerB, "repo-1")
+		Expect(err).To(MatchError(ErrRunnerPoolFull))
+
+		finishJob("team-a", "repo-1")
+		fakeClock.Step(poolWaitTimeout)
+
+		Expect(ensureJob(runnerA, "repo-3")).To(BeTrue())
+	})
+
+	It("should grant shares proportional to the weights", func() {
+		pool.Spec.MaxParallel = 4
+		pool.Spec.Weights = []renovatev1beta1.RunnerPoolWeightSpec{
+			{Name: "renovator", Namespace: "team-a", Weight: 3},
+		}
+		Expect(mgr.Update(ctx, pool)).To(Succeed())
+
+		Expect(ensureJob(runnerB, "repo-1")).To(BeTrue())
+		Expect(ensureJob(runnerB, "repo-2")).To(BeTrue())
+		Expect(ensureJob(runnerB, "repo-3")).To(BeTrue())
+		Expect(ensureJob(runnerA, "repo-1")).To(BeTrue())
+
+		_, err := ensureJob(runnerA, "repo-2")
+		Expect(err).To(MatchError(ErrRunnerPoolFull))
+
+		finishJob("team-b", "repo-1")
+
+		_, err = ensureJob(runnerB, "repo-4")
+		Expect(err).To(MatchError(ErrRunnerPoolFull))
+		Expect(ensureJob(runnerA, "repo-2")).To(BeTrue())
+	})
+
+	It("should count reserved job slots until their job is observed", func() {
+		pool.Status.Reservations = []renovatev1beta1.RunnerPoolReservation{{
+			ID: "concurrent", Name: "renovator", Namespace: "team-b", Time: metav1.NewTime(fakeClock.Now()),
+		}}
+		Expect(mgr.Status().Update(ctx, pool)).To(Succeed())
+
+		Expect(ensureJob(runnerA, "repo-1")).To(BeTrue())
+
+		_, err := ensureJob(runnerA, "repo-2")
+		Expect(err).To(MatchError(ErrRunnerPoolFull))
+
+		updated := &renovatev1beta1.RunnerPool{}
+		Expect(mgr.Get(ctx, client.ObjectKeyFromObject(pool), updated)).To(Succeed())
+		Expect(updated.Status.Active).To(Equal(int32(2)))
+		Expect(updated.Status.Reservations).To(HaveLen(1))
+		Expect(updated.Status.Reservations[0].ID).To(Equal("concurrent"))
+
+		fakeClock.Step(poolReservationTimeout)
+
+		Expect(ensureJob(runnerA, "repo-2")).To(BeTrue())
+
+		jobList := &batchv1.JobList{}
+		Expect(mgr.List(ctx, jobList, client.InNamespace("team-a"))).To(Succeed())
+		Expect(jobList.Items).To(HaveLen(2))
+		Expect(jobList.Items[0].Annotations).To(HaveKey(renovatev1beta1.AnnotationPoolReservation))
+	})
+
+	It("should retry the admission on a conflicting status update", func() {
+		var patches int
+
+		fakeClient := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(pool, runnerA, runnerB).
+			WithStatusSubresource(pool, &batchv1.Job{}).
+			WithInterceptorFuncs(interceptor.Funcs{
+				SubResourcePatch: func(
+					ctx context.Context, c client.Client, subResource string, obj client.Object,
+					patch client.Patch, opts ...client.SubResourcePatchOption,
+				) error {
+					patches++
+
+					if patches == 1 {
+						// A concurrent admission records a waiting Renovator first.
+						current := &renovatev1beta1.RunnerPool{}
+						Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), current)).To(Succeed())
+
+						current.Status.Waiting = append(current.Status.Waiting, renovatev1beta1.RunnerPoolWaiter{
+							Name: "renovator", Namespace: "team-b", LastRequestTime: metav1.NewTime(fakeClock.Now()),
+						})
+						Expect(c.Status().Update(ctx, current)).To(Succeed())
+					}
+
+					return c.SubResource(subResource).Patch(ctx, obj, patch, opts...)
+				},
+			}).
+			Build()
+		mgr = NewManager(fakeClient, scheme, fakeClock)
+
+		Expect(ensureJob(runnerA, "repo-1")).To(BeTrue())
+		Expect(patches).To(Equal(2))
+
+		updated := &renovatev1beta1.RunnerPool{}
+		Expect(mgr.Get(ctx, client.ObjectKeyFromObject(pool), updated)).To(Succeed())
+		Expect(updated.Status.Active).To(Equal(int32(1)))
+		Expect(updated.Status.Waiting).To(HaveLen(1))
+		Expect(updated.Status.Waiting[0].Namespace).To(Equal("team-b"))
+	})
+
+	It("should deny runners of namespaces not allowed by the pool", func() {
+		runnerC := newRunner("team-c")
+
+		_, err := ensureJob(runnerC, "repo-1")
+		Expect(err).To(MatchError(ErrRunnerPoolNotAllowed))
+
+		jobList := &batchv1.JobList{}
+		Expect(mgr.List(ctx, jobList, client.InNamespace("team-c"))).To(Succeed())
+		Expect(jobList.Items).To(BeEmpty())
+	})
+
+	It("should return an error if the pool does not exist", func() {
+		runnerA.Spec.Pool.Name = "missing"
+
+		_, err := ensureJob(runnerA, "repo-1")
+		Expect(err).To(HaveOccurred())
+		Expect(err).NotTo(MatchError(ErrRunnerPoolFull))
+	})
+})
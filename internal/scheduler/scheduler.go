@@ -22,6 +22,7 @@ const (
 	TriggerManual    = "manual"
 	TriggerSchedule  = "schedule"
 	TriggerWait      = "wait"
+	TriggerBlackout  = "blackout"
 )
 
 var ErrInvalidClientObject = errors.New("failed to convert object deep copy to client.Object")
@@ -57,24 +58,20 @@ func NewManager(c client.Client, s *runtime.Scheme, clock clock.Clock) *Manager
 	}
 }
 
+// Now returns the current time of the scheduler clock.
+func (m *Manager) Now() time.Time {
+	return m.clock.Now()
+}
+
 func (m *Manager) Evaluate(obj Schedulable, checkManualTrigger func(map[string]string) bool) (DecisionResult, error) {
 	hasAnnotation := false
 	if checkManualTrigger != nil {
 		hasAnnotation = checkManualTrigger(obj.GetAnnotations())
 	}
 
-	scheduleStr := obj.GetSchedule()
-	if tz := obj.GetTimezone(); tz != "" {
-		if _, err := time.LoadLocation(tz); err != nil {
-			return DecisionResult{}, fmt.Errorf("invalid timezone %q: %w", tz, err)
-		}
-
-		scheduleStr = "CRON_TZ=" + tz + " " + scheduleStr
-	}
-
-	schedule, err := cron.ParseStandard(scheduleStr)
+	schedule, err := parseSchedule(obj.GetSchedule(), obj.GetTimezone())
 	if err != nil {
-		return DecisionResult{}, fmt.Errorf("invalid schedule: %w", err)
+		return DecisionResult{}, err
 	}
 
 	var lastRun time.Time
@@ -100,12 +97,49 @@ func (m *Manager) Evaluate(obj Schedulable, checkManualTrigger func(map[string]s
 	}
 
 	if isScheduleDue {
+		blackout, inBlackout, err := ActiveBlackoutAt(obj, now)
+		if err != nil {
+			return DecisionResult{}, err
+		}
+
+		if inBlackout {
+			return DecisionResult{ShouldRun: false, NextRun: blackout.End, Trigger: TriggerBlackout}, nil
+		}
+
 		return DecisionResult{ShouldRun: true, NextRun: nextRun, Trigger: TriggerSchedule}, nil
 	}
 
+	// Scheduled runs falling into a blackout are deferred to its end.
+	blackout, inBlackout, err := ActiveBlackoutAt(obj, nextRun)
+	if err != nil {
+		return DecisionResult{}, err
+	}
+
+	if inBlackout {
+		nextRun = blackout.End
+	}
+
 	return DecisionResult{ShouldRun: false, NextRun: nextRun, Trigger: TriggerWait}, nil
 }
 
+// parseSchedule parses a cron schedule evaluated in the given timezone.
+func parseSchedule(spec, tz string) (cron.Schedule, error) {
+	if tz != "" {
+		if _, err := time.LoadLocation(tz); err != nil {
+			return nil, fmt.Errorf("invalid timezone %q: %w", tz, err)
+		}
+
+		spec = "CRON_TZ=" + tz + " " + spec
+	}
+
+	schedule, err := cron.ParseStandard(spec)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schedule: %w", err)
+	}
+
+	return schedule, nil
+}
+
 func (m *Manager) EnsureJob(
 	ctx context.Context, owner Schedulable, job *batchv1.Job, lockLabels map[string]string,
 ) (bool, error) {
@@ -118,6 +152,14 @@ func (m *Manager) EnsureJob(
 		return false, nil
 	}
 
+	if ps, ok := owner.(PoolSchedulable); ok {
+		if pool := ps.GetRunnerPool(); pool != nil {
+			if err := m.admitPoolJob(ctx, owner, *pool, job); err != nil {
+				return false, err
+			}
+		}
+	}
+
 	if err := controllerutil.SetControllerReference(owner, job, m.scheme); err != nil {
 		return false, fmt.Errorf("failed to set controller reference: %w", err)
 	}
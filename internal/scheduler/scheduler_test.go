@@ -5,6 +5,7 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	fakeclock "k8s.io/utils/clock/testing"
@@ -18,6 +19,7 @@ type MockSchedulable struct {
 	Timezone         string
 	Suspend          bool
 	LastScheduleTime *metav1.Time
+	BlackoutWindows  []renovatev1beta1.BlackoutWindowSpec
 }
 
 func (m *MockSchedulable) GetSchedule() string                { return m.Schedule }
@@ -27,6 +29,9 @@ func (m *MockSchedulable) GetLastScheduleTime() *metav1.Time  { return m.LastSch
 func (m *MockSchedulable) SetLastScheduleTime(t *metav1.Time) { m.LastScheduleTime = t }
 func (m *MockSchedulable) GetSuccessLimit() int               { return 3 }
 func (m *MockSchedulable) GetFailedLimit() int                { return 1 }
+func (m *MockSchedulable) GetBlackoutWindows() []renovatev1beta1.BlackoutWindowSpec {
+	return m.BlackoutWindows
+}
 func (m *MockSchedulable) DeepCopyObject() runtime.Object {
 	return &MockSchedulable{
 		TypeMeta:         m.TypeMeta,
@@ -35,6 +40,7 @@ func (m *MockSchedulable) DeepCopyObject() runtime.Object {
 		Timezone:         m.Timezone,
 		Suspend:          m.Suspend,
 		LastScheduleTime: m.LastScheduleTime,
+		BlackoutWindows:  m.BlackoutWindows,
 	}
 }
 
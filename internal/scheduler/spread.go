@@ -0,0 +1,28 @@
+package scheduler
+
+import (
+	"hash/fnv"
+	"math/rand/v2"
+	"time"
+
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+)
+
+// SpreadOffset returns the delay of a run inside the spread window. The hash
+// strategy derives a stable delay from key, the random strategy picks a new one
+// on every call.
+func SpreadOffset(spread *renovatev1beta1.SpreadSpec, key string) time.Duration {
+	window := spread.Window.Duration
+	if window <= 0 {
+		return 0
+	}
+
+	if spread.Strategy == renovatev1beta1.SpreadStrategy_RANDOM {
+		return rand.N(window) //nolint:gosec
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+
+	return time.Duration(h.Sum64() % uint64(window)) //nolint:gosec
+}
@@ -0,0 +1,42 @@
+package scheduler
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("SpreadOffset", func() {
+	var spread *renovatev1beta1.SpreadSpec
+
+	BeforeEach(func() {
+		spread = &renovatev1beta1.SpreadSpec{Window: metav1.Duration{Duration: 2 * time.Hour}}
+	})
+
+	It("should derive a stable offset inside the window from the key", func() {
+		offset := SpreadOffset(spread, "repo-1")
+		Expect(offset).To(BeNumerically(">=", 0))
+		Expect(offset).To(BeNumerically("<", 2*time.Hour))
+		Expect(SpreadOffset(spread, "repo-1")).To(Equal(offset))
+		Expect(SpreadOffset(spread, "repo-2")).NotTo(Equal(offset))
+	})
+
+	It("should pick a random offset inside the window", func() {
+		spread.Strategy = renovatev1beta1.SpreadStrategy_RANDOM
+
+		for range 10 {
+			offset := SpreadOffset(spread, "repo-1")
+			Expect(offset).To(BeNumerically(">=", 0))
+			Expect(offset).To(BeNumerically("<", 2*time.Hour))
+		}
+	})
+
+	It("should not delay runs without a window", func() {
+		spread.Window = metav1.Duration{}
+
+		Expect(SpreadOffset(spread, "repo-1")).To(BeZero())
+	})
+})
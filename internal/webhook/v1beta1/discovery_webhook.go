@@ -123,6 +123,14 @@ func (v *DiscoveryCustomValidator) ValidateCreate(
 		return nil, err
 	}
 
+	if err := validateBlackoutWindows(&discovery.Spec.JobSpec); err != nil {
+		return nil, err
+	}
+
+	if err := validateRepositories(&discovery.Spec); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
@@ -145,6 +153,14 @@ func (v *DiscoveryCustomValidator) ValidateUpdate(
 		return nil, err
 	}
 
+	if err := validateBlackoutWindows(&newDiscovery.Spec.JobSpec); err != nil {
+		return nil, err
+	}
+
+	if err := validateRepositories(&newDiscovery.Spec); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
@@ -137,6 +137,31 @@ var _ = Describe("Discovery Webhook", func() {
 			Expect(warnings).To(BeNil())
 		})
 
+		It("Should reject static mode without repositories", func() {
+			By("enabling static mode without repositories")
+
+			obj.Spec.Mode = renovatev1beta1.DiscoveryMode_STATIC
+
+			By("calling the ValidateCreate method")
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ErrInvalidRepositories))
+		})
+
+		It("Should reject repositories mapping to the same GitRepo", func() {
+			By("listing a repository twice with different case")
+
+			obj.Spec.Repositories = []renovatev1beta1.RepositorySpec{
+				{Name: "org/repo"},
+				{Name: "Org/Repo"},
+			}
+
+			By("calling the ValidateCreate method")
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ErrInvalidRepositories))
+		})
+
 		It("Should return error when object is nil on ValidateCreate", func() {
 			By("calling the ValidateCreate method with nil object")
 
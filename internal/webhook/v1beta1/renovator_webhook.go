@@ -9,6 +9,7 @@ import (
 	k8s "github.com/thegeeklab/renovate-operator/pkg/util/k8s"
 	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
@@ -23,7 +24,7 @@ var (
 func SetupRenovatorWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &renovatev1beta1.Renovator{}).
 		WithDefaulter(&RenovatorCustomDefaulter{}).
-		WithValidator(&RenovatorCustomValidator{}).
+		WithValidator(&RenovatorCustomValidator{Client: mgr.GetAPIReader()}).
 		Complete()
 }
 
@@ -116,11 +117,14 @@ func (d *RenovatorCustomDefaulter) Default(_ context.Context, renovator *renovat
 
 // RenovatorCustomValidator struct is responsible for validating the Kind Renovator resource
 // when it is created or updated.
-type RenovatorCustomValidator struct{}
+type RenovatorCustomValidator struct {
+	// Client reads the RunnerPools referenced by Renovators.
+	Client client.Reader
+}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the Kind Renovator.
 func (v *RenovatorCustomValidator) ValidateCreate(
-	_ context.Context,
+	ctx context.Context,
 	renovator *renovatev1beta1.Renovator,
 ) (admission.Warnings, error) {
 	if renovator == nil {
@@ -153,12 +157,45 @@ func (v *RenovatorCustomValidator) ValidateCreate(
 		return nil, err
 	}
 
-	return nil, nil
+	if err := validateBlackoutWindows(&renovator.Spec.JobSpec); err != nil {
+		return nil, err
+	}
+
+	if err := validateBlackoutWindows(&renovator.Spec.Discovery.JobSpec); err != nil {
+		return nil, err
+	}
+
+	if err := validateBlackoutWindows(&renovator.Spec.Runner.JobSpec); err != nil {
+		return nil, err
+	}
+
+	if err := validatePlatform(&renovator.Spec.Renovate.Platform); err != nil {
+		return nil, err
+	}
+
+	platformType := renovator.Spec.Renovate.Platform.Type
+	if err := validateWebhooks(platformType, &renovator.Spec.Webhooks); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhooks(platformType, &renovator.Spec.Discovery.Webhooks); err != nil {
+		return nil, err
+	}
+
+	if err := validateRepositories(&renovator.Spec.Discovery); err != nil {
+		return nil, err
+	}
+
+	if err := validateRunnerPool(ctx, v.Client, renovator.Namespace, renovator.Spec.Runner.Pool); err != nil {
+		return nil, err
+	}
+
+	return warnRenovateLogLevel(renovator), nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the Kind Renovator.
 func (v *RenovatorCustomValidator) ValidateUpdate(
-	_ context.Context,
+	ctx context.Context,
 	_ *renovatev1beta1.Renovator,
 	newRenovator *renovatev1beta1.Renovator,
 ) (admission.Warnings, error) {
@@ -192,7 +229,40 @@ func (v *RenovatorCustomValidator) ValidateUpdate(
 		return nil, err
 	}
 
-	return nil, nil
+	if err := validateBlackoutWindows(&newRenovator.Spec.JobSpec); err != nil {
+		return nil, err
+	}
+
+	if err := validateBlackoutWindows(&newRenovator.Spec.Discovery.JobSpec); err != nil {
+		return nil, err
+	}
+
+	if err := validateBlackoutWindows(&newRenovator.Spec.Runner.JobSpec); err != nil {
+		return nil, err
+	}
+
+	if err := validatePlatform(&newRenovator.Spec.Renovate.Platform); err != nil {
+		return nil, err
+	}
+
+	platformType := newRenovator.Spec.Renovate.Platform.Type
+	if err := validateWebhooks(platformType, &newRenovator.Spec.Webhooks); err != nil {
+		return nil, err
+	}
+
+	if err := validateWebhooks(platformType, &newRenovator.Spec.Discovery.Webhooks); err != nil {
+		return nil, err
+	}
+
+	if err := validateRepositories(&newRenovator.Spec.Discovery); err != nil {
+		return nil, err
+	}
+
+	if err := validateRunnerPool(ctx, v.Client, newRenovator.Namespace, newRenovator.Spec.Runner.Pool); err != nil {
+		return nil, err
+	}
+
+	return warnRenovateLogLevel(newRenovator), nil
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the Kind Renovator.
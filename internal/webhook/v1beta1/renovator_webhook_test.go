@@ -3,12 +3,14 @@ package v1beta1
 import (
 	"context"
 	"strings"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 var _ = Describe("Renovator Webhook", func() {
@@ -198,8 +200,15 @@ var _ = Describe("Renovator Webhook", func() {
 	Context("When creating Renovator under Validating Webhook", func() {
 		var validator RenovatorCustomValidator
 
+		platformToken := corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+			LocalObjectReference: corev1.LocalObjectReference{Name: "platform-token"},
+			Key:                  "token",
+		}}
+
 		BeforeEach(func() {
 			validator = RenovatorCustomValidator{}
+			obj.Spec.Renovate.Platform.Token = platformToken
+			oldObj.Spec.Renovate.Platform.Token = platformToken
 		})
 
 		It("Should accept valid timezone", func() {
@@ -214,6 +223,20 @@ var _ = Describe("Renovator Webhook", func() {
 			Expect(warnings).To(BeNil())
 		})
 
+		It("Should warn if Renovate logs above the info level", func() {
+			obj.Spec.Logging.Level = renovatev1beta1.LogLevel_WARN
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(ConsistOf(ContainSubstring("registry triggers require at least the info level")))
+
+			obj.Spec.Renovate.Logging = &renovatev1beta1.LoggingSpec{Level: renovatev1beta1.LogLevel_INFO}
+
+			warnings, err = validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+
 		It("Should accept empty timezone", func() {
 			By("leaving timezone empty")
 
@@ -342,6 +365,128 @@ var _ = Describe("Renovator Webhook", func() {
 			Expect(warnings).To(BeNil())
 		})
 
+		It("Should accept GitHub App authentication for GitHub", func() {
+			By("configuring a GitHub App on the GitHub platform")
+
+			obj.Spec.Renovate.Platform = renovatev1beta1.PlatformSpec{
+				Type:      renovatev1beta1.PlatformType_GITHUB,
+				GitHubApp: &renovatev1beta1.GitHubAppSpec{AppID: 1, InstallationID: 2},
+			}
+
+			By("calling the ValidateCreate method")
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+
+		It("Should reject GitHub App authentication for other platforms", func() {
+			By("configuring a GitHub App on the Gitea platform")
+
+			obj.Spec.Renovate.Platform = renovatev1beta1.PlatformSpec{
+				Type:      renovatev1beta1.PlatformType_GITEA,
+				GitHubApp: &renovatev1beta1.GitHubAppSpec{AppID: 1, InstallationID: 2},
+			}
+
+			By("calling the ValidateCreate method")
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ErrInvalidPlatform))
+			Expect(warnings).To(BeNil())
+		})
+
+		It("Should reject a platform without token and GitHub App", func() {
+			By("configuring neither a token nor a GitHub App")
+
+			obj.Spec.Renovate.Platform = renovatev1beta1.PlatformSpec{Type: renovatev1beta1.PlatformType_GITHUB}
+
+			By("calling the ValidateCreate method")
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ErrInvalidPlatform))
+			Expect(err.Error()).To(ContainSubstring("either token or githubApp is required"))
+			Expect(warnings).To(BeNil())
+		})
+
+		It("Should reject a platform with token and GitHub App", func() {
+			By("configuring both a token and a GitHub App")
+
+			obj.Spec.Renovate.Platform = renovatev1beta1.PlatformSpec{
+				Type:      renovatev1beta1.PlatformType_GITHUB,
+				Token:     platformToken,
+				GitHubApp: &renovatev1beta1.GitHubAppSpec{AppID: 1, InstallationID: 2},
+			}
+
+			By("calling the ValidateUpdate method")
+
+			warnings, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ErrInvalidPlatform))
+			Expect(err.Error()).To(ContainSubstring("mutually exclusive"))
+			Expect(warnings).To(BeNil())
+		})
+
+		It("Should accept organization webhooks for supported platforms", func() {
+			By("configuring organization scoped webhooks on the GitLab platform")
+
+			obj.Spec.Renovate.Platform = renovatev1beta1.PlatformSpec{
+				Type:  renovatev1beta1.PlatformType_GITLAB,
+				Token: platformToken,
+			}
+			obj.Spec.Webhooks = renovatev1beta1.WebhooksSpec{
+				Scope:         renovatev1beta1.WebhookScope_ORGANIZATION,
+				Organizations: []string{"group/subgroup"},
+			}
+
+			By("calling the ValidateCreate method")
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+
+		It("Should reject organization webhooks for unsupported platforms", func() {
+			By("configuring organization scoped webhooks on the Azure platform")
+
+			obj.Spec.Renovate.Platform = renovatev1beta1.PlatformSpec{
+				Type:  renovatev1beta1.PlatformType_AZURE,
+				Token: platformToken,
+			}
+			obj.Spec.Discovery.Webhooks = renovatev1beta1.WebhooksSpec{Scope: renovatev1beta1.WebhookScope_ORGANIZATION}
+
+			By("calling the ValidateCreate method")
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ErrInvalidWebhooks))
+			Expect(warnings).To(BeNil())
+		})
+
+		It("Should reject organizations without organization scope", func() {
+			By("configuring organizations for repository scoped webhooks")
+
+			obj.Spec.Webhooks = renovatev1beta1.WebhooksSpec{Organizations: []string{"thegeeklab"}}
+
+			By("calling the ValidateUpdate method")
+
+			warnings, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ErrInvalidWebhooks))
+			Expect(warnings).To(BeNil())
+		})
+
+		It("Should reject a debounce maxDelay shorter than the quiet period", func() {
+			By("configuring a debounce with a short maximum delay")
+
+			obj.Spec.Webhooks.Debounce = &renovatev1beta1.WebhookDebounceSpec{
+				QuietPeriod: metav1.Duration{Duration: 5 * time.Minute},
+				MaxDelay:    &metav1.Duration{Duration: time.Minute},
+			}
+
+			By("calling the ValidateCreate method")
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ErrInvalidWebhooks))
+			Expect(warnings).To(BeNil())
+		})
+
 		It("Should return error when object is nil on ValidateUpdate", func() {
 			By("calling the ValidateUpdate method with nil object")
 
@@ -6,7 +6,9 @@ import (
 	"fmt"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -23,7 +25,7 @@ var (
 func SetupRunnerWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &renovatev1beta1.Runner{}).
 		WithDefaulter(&RunnerCustomDefaulter{}).
-		WithValidator(&RunnerCustomValidator{}).
+		WithValidator(&RunnerCustomValidator{Client: mgr.GetAPIReader()}).
 		Complete()
 }
 
@@ -86,6 +88,16 @@ func (d *RunnerCustomDefaulter) Default(ctx context.Context, runner *renovatev1b
 		runner.Spec.MaxParallel = new(renovatev1beta1.DefaultRunnerMaxParallel)
 	}
 
+	if runner.Spec.Retry != nil {
+		if runner.Spec.Retry.InitialDelay == nil {
+			runner.Spec.Retry.InitialDelay = &metav1.Duration{Duration: renovatev1beta1.DefaultRetryInitialDelay}
+		}
+
+		if runner.Spec.Retry.Multiplier == nil {
+			runner.Spec.Retry.Multiplier = new(renovatev1beta1.DefaultRetryMultiplier)
+		}
+	}
+
 	defaultScratchVolume(runner.Spec.ScratchVolume)
 
 	return nil
@@ -96,11 +108,14 @@ func (d *RunnerCustomDefaulter) Default(ctx context.Context, runner *renovatev1b
 //
 // NOTE: The +kubebuilder:object:generate=false marker prevents controller-gen from generating DeepCopy methods,
 // as it is used only for temporary operations and does not need to be deeply copied.
-type RunnerCustomValidator struct{}
+type RunnerCustomValidator struct {
+	// Client reads the RunnerPools referenced by Runners.
+	Client client.Reader
+}
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the Kind Runner.
 func (v *RunnerCustomValidator) ValidateCreate(
-	_ context.Context,
+	ctx context.Context,
 	runner *renovatev1beta1.Runner,
 ) (admission.Warnings, error) {
 	if runner == nil {
@@ -117,12 +132,20 @@ func (v *RunnerCustomValidator) ValidateCreate(
 		return nil, err
 	}
 
+	if err := validateBlackoutWindows(&runner.Spec.JobSpec); err != nil {
+		return nil, err
+	}
+
+	if err := validateRunnerPool(ctx, v.Client, runner.Namespace, runner.Spec.Pool); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
 // ValidateUpdate implements webhook.CustomValidator so a webhook will be registered for the Kind Runner.
 func (v *RunnerCustomValidator) ValidateUpdate(
-	_ context.Context,
+	ctx context.Context,
 	_, newRunner *renovatev1beta1.Runner,
 ) (admission.Warnings, error) {
 	if newRunner == nil {
@@ -139,6 +162,14 @@ func (v *RunnerCustomValidator) ValidateUpdate(
 		return nil, err
 	}
 
+	if err := validateBlackoutWindows(&newRunner.Spec.JobSpec); err != nil {
+		return nil, err
+	}
+
+	if err := validateRunnerPool(ctx, v.Client, newRunner.Namespace, newRunner.Spec.Pool); err != nil {
+		return nil, err
+	}
+
 	return nil, nil
 }
 
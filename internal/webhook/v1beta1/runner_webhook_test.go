@@ -2,12 +2,16 @@ package v1beta1
 
 import (
 	"context"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 )
 
 var _ = Describe("Runner Webhook", func() {
@@ -63,6 +67,15 @@ var _ = Describe("Runner Webhook", func() {
 			Expect(obj.Spec.ImagePullPolicy).To(Equal(corev1.PullAlways))
 		})
 
+		It("Should apply retry defaults when a retry policy is set", func() {
+			obj.Spec.Retry = &renovatev1beta1.RetrySpec{MaxAttempts: 3}
+
+			err := defaulter.Default(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(obj.Spec.Retry.InitialDelay.Duration).To(Equal(renovatev1beta1.DefaultRetryInitialDelay))
+			Expect(*obj.Spec.Retry.Multiplier).To(Equal(renovatev1beta1.DefaultRetryMultiplier))
+		})
+
 		It("Should return error when object is not a Runner", func() {
 			By("calling the Default method with wrong object type")
 
@@ -104,6 +117,36 @@ var _ = Describe("Runner Webhook", func() {
 			Expect(warnings).To(BeNil())
 		})
 
+		It("Should accept valid blackout windows", func() {
+			By("setting a recurring and a fixed blackout window")
+
+			obj.Spec.BlackoutWindows = []renovatev1beta1.BlackoutWindowSpec{
+				{Name: "weekend", Schedule: "0 0 * * 6", Duration: &metav1.Duration{Duration: 48 * time.Hour}},
+				{Name: "freeze", End: &metav1.Time{Time: time.Date(2027, 1, 1, 0, 0, 0, 0, time.UTC)}},
+			}
+
+			By("calling the ValidateCreate method")
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+
+		It("Should reject recurring blackout windows without duration", func() {
+			By("setting a blackout window without duration")
+
+			obj.Spec.BlackoutWindows = []renovatev1beta1.BlackoutWindowSpec{
+				{Name: "weekend", Schedule: "0 0 * * 6"},
+			}
+
+			By("calling the ValidateCreate method")
+
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid blackout window"))
+			Expect(warnings).To(BeNil())
+		})
+
 		It("Should return error when object is nil on ValidateCreate", func() {
 			By("calling the ValidateCreate method with nil object")
 
@@ -164,4 +207,47 @@ var _ = Describe("Runner Webhook", func() {
 			Expect(warnings).To(BeNil())
 		})
 	})
+
+	Context("When referencing a RunnerPool of another namespace", func() {
+		var validator RunnerCustomValidator
+
+		BeforeEach(func() {
+			scheme := runtime.NewScheme()
+			Expect(renovatev1beta1.AddToScheme(scheme)).To(Succeed())
+
+			validator = RunnerCustomValidator{Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+				&renovatev1beta1.RunnerPool{
+					ObjectMeta: metav1.ObjectMeta{Name: "shared", Namespace: "renovate-system"},
+					Spec: renovatev1beta1.RunnerPoolSpec{
+						MaxParallel:       1,
+						AllowedNamespaces: []string{"team-a"},
+					},
+				},
+			).Build()}
+
+			obj.Namespace = "team-a"
+			obj.Spec.Pool = &renovatev1beta1.RunnerPoolRef{Name: "shared", Namespace: "renovate-system"}
+		})
+
+		It("Should accept a namespace allowed by the pool", func() {
+			warnings, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(warnings).To(BeNil())
+		})
+
+		It("Should reject a namespace not allowed by the pool", func() {
+			obj.Namespace = "team-b"
+
+			_, err := validator.ValidateUpdate(ctx, oldObj, obj)
+			Expect(err).To(MatchError(ErrInvalidRunnerPool))
+			Expect(err.Error()).To(ContainSubstring(`does not allow namespace "team-b"`))
+		})
+
+		It("Should reject a pool that does not exist", func() {
+			obj.Spec.Pool.Name = "missing"
+
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(MatchError(ErrInvalidRunnerPool))
+		})
+	})
 })
@@ -1,18 +1,30 @@
 package v1beta1
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	renovatev1beta1 "github.com/thegeeklab/renovate-operator/api/v1beta1"
+	"github.com/thegeeklab/renovate-operator/internal/scheduler"
+	corev1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 )
 
 var (
-	ErrInvalidTimezone = errors.New("invalid timezone")
-	ErrInvalidPath     = errors.New("path must be absolute")
+	ErrInvalidTimezone     = errors.New("invalid timezone")
+	ErrInvalidPath         = errors.New("path must be absolute")
+	ErrInvalidPlatform     = errors.New("invalid platform")
+	ErrInvalidWebhooks     = errors.New("invalid webhooks")
+	ErrInvalidRepositories = errors.New("invalid repositories")
+	ErrInvalidRunnerPool   = errors.New("invalid runner pool")
 )
 
 // validateTimezone returns an error if tz is not a valid IANA timezone name.
@@ -29,6 +41,58 @@ func validateTimezone(tz string) error {
 	return nil
 }
 
+// validateBlackoutWindows validates that each blackout window is either a
+// recurring window with a positive duration or a fixed window with an end.
+func validateBlackoutWindows(job *renovatev1beta1.JobSpec) error {
+	return scheduler.ValidateBlackoutWindows(job.BlackoutWindows, job.Timezone)
+}
+
+// validateRunnerPool validates that a RunnerPool referenced from another
+// namespace allows Runners of the namespace to reference it.
+func validateRunnerPool(
+	ctx context.Context, reader client.Reader, namespace string, ref *renovatev1beta1.RunnerPoolRef,
+) error {
+	if ref == nil || ref.Namespace == "" || ref.Namespace == namespace {
+		return nil
+	}
+
+	pool := &renovatev1beta1.RunnerPool{}
+	if err := reader.Get(ctx, types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}, pool); err != nil {
+		if api_errors.IsNotFound(err) {
+			return fmt.Errorf("%w: runner pool %s/%s not found", ErrInvalidRunnerPool, ref.Namespace, ref.Name)
+		}
+
+		return fmt.Errorf("failed to get runner pool %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+
+	if !pool.AllowsNamespace(namespace) {
+		return fmt.Errorf("%w: runner pool %s/%s does not allow namespace %q",
+			ErrInvalidRunnerPool, ref.Namespace, ref.Name, namespace)
+	}
+
+	return nil
+}
+
+// warnRenovateLogLevel warns if Renovate logs above the info level. The
+// container images of the repositories, which registry triggers rely on, are
+// not recorded from such runs.
+func warnRenovateLogLevel(renovator *renovatev1beta1.Renovator) admission.Warnings {
+	logging := renovator.Spec.Renovate.Logging
+	if logging == nil {
+		logging = &renovator.Spec.Logging
+	}
+
+	switch logging.Level {
+	case renovatev1beta1.LogLevel_WARN, renovatev1beta1.LogLevel_ERROR, renovatev1beta1.LogLevel_FATAL:
+		return admission.Warnings{fmt.Sprintf(
+			"renovate log level %q does not record the container images of repositories, registry triggers "+
+				"require at least the info level", logging.Level,
+		)}
+	default:
+		return nil
+	}
+}
+
 // validateScratchVolumePath validates that the scratch volume path is absolute.
 // Returns nil if scratch is nil or path is empty (will be defaulted).
 func validateScratchVolumePath(scratch *renovatev1beta1.ScratchVolumeSpec) error {
@@ -42,3 +106,94 @@ func validateScratchVolumePath(scratch *renovatev1beta1.ScratchVolumeSpec) error
 
 	return nil
 }
+
+// validatePlatform validates that exactly one of token and GitHub App
+// authentication is configured and that GitHub App authentication is only
+// configured for the GitHub platform.
+func validatePlatform(platform *renovatev1beta1.PlatformSpec) error {
+	hasToken := platform.Token != (corev1.EnvVarSource{})
+
+	if !hasToken && platform.GitHubApp == nil {
+		return fmt.Errorf("%w: either token or githubApp is required", ErrInvalidPlatform)
+	}
+
+	if hasToken && platform.GitHubApp != nil {
+		return fmt.Errorf("%w: token and githubApp are mutually exclusive", ErrInvalidPlatform)
+	}
+
+	if platform.GitHubApp != nil && platform.Type != renovatev1beta1.PlatformType_GITHUB {
+		return fmt.Errorf("%w: githubApp requires platform type %q", ErrInvalidPlatform, renovatev1beta1.PlatformType_GITHUB)
+	}
+
+	return nil
+}
+
+// validateWebhooks validates that organization webhooks are only configured
+// for platforms supporting them and that the debounce delays are positive.
+func validateWebhooks(platformType renovatev1beta1.PlatformType, webhooks *renovatev1beta1.WebhooksSpec) error {
+	if err := validateWebhookDebounce(webhooks.Debounce); err != nil {
+		return err
+	}
+
+	if webhooks.Scope != renovatev1beta1.WebhookScope_ORGANIZATION {
+		if len(webhooks.Organizations) > 0 {
+			return fmt.Errorf("%w: organizations require scope %q", ErrInvalidWebhooks,
+				renovatev1beta1.WebhookScope_ORGANIZATION)
+		}
+
+		return nil
+	}
+
+	supported := []renovatev1beta1.PlatformType{
+		renovatev1beta1.PlatformType_GITHUB,
+		renovatev1beta1.PlatformType_GITEA,
+		renovatev1beta1.PlatformType_GITLAB,
+	}
+
+	if !slices.Contains(supported, platformType) {
+		return fmt.Errorf("%w: scope %q is not supported for platform type %q", ErrInvalidWebhooks,
+			renovatev1beta1.WebhookScope_ORGANIZATION, platformType)
+	}
+
+	return nil
+}
+
+// validateWebhookDebounce validates that the quiet period is positive and
+// does not exceed the maximum delay.
+func validateWebhookDebounce(debounce *renovatev1beta1.WebhookDebounceSpec) error {
+	if debounce == nil {
+		return nil
+	}
+
+	if debounce.QuietPeriod.Duration <= 0 {
+		return fmt.Errorf("%w: debounce quietPeriod must be positive", ErrInvalidWebhooks)
+	}
+
+	if debounce.MaxDelay != nil && debounce.MaxDelay.Duration < debounce.QuietPeriod.Duration {
+		return fmt.Errorf("%w: debounce maxDelay must not be shorter than quietPeriod", ErrInvalidWebhooks)
+	}
+
+	return nil
+}
+
+// validateRepositories validates that the statically configured repositories
+// map to distinct GitRepos and that the static mode lists at least one.
+func validateRepositories(discovery *renovatev1beta1.DiscoverySpec) error {
+	if discovery.Mode == renovatev1beta1.DiscoveryMode_STATIC && len(discovery.Repositories) == 0 {
+		return fmt.Errorf("%w: mode %q requires at least one repository", ErrInvalidRepositories,
+			renovatev1beta1.DiscoveryMode_STATIC)
+	}
+
+	seen := make(map[string]struct{}, len(discovery.Repositories))
+
+	for _, repo := range discovery.Repositories {
+		name := strings.ToLower(repo.Name)
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("%w: duplicate repository %q", ErrInvalidRepositories, repo.Name)
+		}
+
+		seen[name] = struct{}{}
+	}
+
+	return nil
+}
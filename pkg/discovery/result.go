@@ -0,0 +1,294 @@
+package discovery
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/thegeeklab/renovate-operator/pkg/util/k8s"
+	corev1 "k8s.io/api/core/v1"
+	api_errors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// ResultDataKey is the ConfigMap key holding the JSON encoded repository list.
+	ResultDataKey = "repositories"
+	// ResultIDsDataKey is the ConfigMap key holding the JSON encoded platform
+	// IDs of the repositories of a shard by name. It is only set if the IDs
+	// are known.
+	ResultIDsDataKey = "repositoryIds"
+
+	// AnnotationResultRun identifies the discovery run a shard belongs to.
+	AnnotationResultRun = "renovate.thegeeklab.de/discovery-run"
+	// AnnotationResultShard is the index of a shard within the discovery result.
+	AnnotationResultShard = "renovate.thegeeklab.de/discovery-shard"
+	// AnnotationResultShards is the number of shards of the discovery result.
+	// It is only set on the index shard.
+	AnnotationResultShards = "renovate.thegeeklab.de/discovery-shards"
+	// AnnotationResultTotal is the number of repositories of the discovery result.
+	// It is only set on the index shard.
+	AnnotationResultTotal = "renovate.thegeeklab.de/discovery-total"
+
+	// DefaultMaxShardBytes keeps every shard well below the 1 MiB object size
+	// limit of the Kubernetes API.
+	DefaultMaxShardBytes = 512 * 1024
+)
+
+var (
+	ErrShardOutdated     = errors.New("discovery result shard belongs to a different run")
+	ErrInvalidResultMeta = errors.New("invalid discovery result annotation")
+)
+
+// ResultIndex describes a discovery result stored in one or more ConfigMaps.
+// Shard 0 is the index shard named after the base name, all other shards are
+// suffixed with their index.
+type ResultIndex struct {
+	Run    string
+	Shards int
+	Total  int
+}
+
+// ShardName returns the ConfigMap name of the shard with the given index.
+func ShardName(baseName string, index int) (string, error) {
+	if index == 0 {
+		return baseName, nil
+	}
+
+	return k8s.DeterministicSubdomain(baseName, "-"+strconv.Itoa(index))
+}
+
+// ShardRepositories splits the repositories into JSON encoded arrays of at
+// most maxBytes each. An empty list results in a single empty shard.
+func ShardRepositories(repos []string, maxBytes int) ([]string, error) {
+	shards, _, err := shardResult(repos, nil, maxBytes)
+
+	return shards, err
+}
+
+// shardResult splits the repositories into JSON encoded arrays and, if ids is
+// not nil, their IDs into JSON encoded objects. The array and the object of a
+// shard together hold at most maxBytes.
+func shardResult(repos []string, ids map[string]string, maxBytes int) ([]string, []string, error) {
+	shards := []string{}
+	idShards := []string{}
+	current := []byte{'['}
+	currentIDs := []byte{'{'}
+
+	for _, repo := range repos {
+		encoded, err := json.Marshal(repo)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var entry []byte
+
+		if id, ok := ids[repo]; ok {
+			encodedID, err := json.Marshal(id)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			entry = slices.Concat(encoded, []byte{':'}, encodedID)
+		}
+
+		// Account for the separators and the closing brackets.
+		size := len(current) + len(encoded) + 2
+		if ids != nil {
+			size += len(currentIDs) + len(entry) + 2
+		}
+
+		if len(current) > 1 && size > maxBytes {
+			shards = append(shards, string(append(current, ']')))
+			idShards = append(idShards, string(append(currentIDs, '}')))
+			current = []byte{'['}
+			currentIDs = []byte{'{'}
+		}
+
+		if len(current) > 1 {
+			current = append(current, ',')
+		}
+
+		current = append(current, encoded...)
+
+		if entry != nil {
+			if len(currentIDs) > 1 {
+				currentIDs = append(currentIDs, ',')
+			}
+
+			currentIDs = append(currentIDs, entry...)
+		}
+	}
+
+	shards = append(shards, string(append(current, ']')))
+	idShards = append(idShards, string(append(currentIDs, '}')))
+
+	return shards, idShards, nil
+}
+
+// WriteResult stores the repositories as sharded ConfigMaps controlled by
+// owner. The platform IDs of the repositories are stored alongside if ids is
+// not nil. The index shard is written last so readers never observe a
+// partially written run, and shards left over from larger previous runs are
+// deleted.
+func WriteResult(
+	ctx context.Context,
+	c client.Client,
+	owner client.Object,
+	baseName string,
+	repos []string,
+	ids map[string]string,
+	maxBytes int,
+) (ResultIndex, error) {
+	shards, idShards, err := shardResult(repos, ids, maxBytes)
+	if err != nil {
+		return ResultIndex{}, fmt.Errorf("failed to shard discovery result: %w", err)
+	}
+
+	hash := sha256.New()
+	for i, shard := range shards {
+		hash.Write([]byte(shard))
+
+		if ids != nil {
+			hash.Write([]byte(idShards[i]))
+		}
+	}
+
+	index := ResultIndex{
+		Run:    hex.EncodeToString(hash.Sum(nil))[:16],
+		Shards: len(shards),
+		Total:  len(repos),
+	}
+
+	for i := len(shards) - 1; i >= 0; i-- {
+		name, err := ShardName(baseName, i)
+		if err != nil {
+			return ResultIndex{}, err
+		}
+
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: owner.GetNamespace()},
+		}
+
+		if _, err := k8s.CreateOrUpdate(ctx, c, cm, owner, func() error {
+			if cm.Annotations == nil {
+				cm.Annotations = make(map[string]string)
+			}
+
+			cm.Annotations[AnnotationResultRun] = index.Run
+			cm.Annotations[AnnotationResultShard] = strconv.Itoa(i)
+
+			if i == 0 {
+				cm.Annotations[AnnotationResultShards] = strconv.Itoa(index.Shards)
+				cm.Annotations[AnnotationResultTotal] = strconv.Itoa(index.Total)
+			}
+
+			cm.Data = map[string]string{ResultDataKey: shards[i]}
+
+			if ids != nil {
+				cm.Data[ResultIDsDataKey] = idShards[i]
+			}
+
+			return nil
+		}); err != nil {
+			return ResultIndex{}, fmt.Errorf("failed to write discovery result shard %d: %w", i, err)
+		}
+	}
+
+	if err := deleteStaleShards(ctx, c, owner, index.Shards); err != nil {
+		return ResultIndex{}, err
+	}
+
+	return index, nil
+}
+
+func deleteStaleShards(ctx context.Context, c client.Client, owner client.Object, shards int) error {
+	cms := &corev1.ConfigMapList{}
+	if err := c.List(ctx, cms, client.InNamespace(owner.GetNamespace())); err != nil {
+		return fmt.Errorf("failed to list discovery result shards: %w", err)
+	}
+
+	for i := range cms.Items {
+		cm := &cms.Items[i]
+
+		if !metav1.IsControlledBy(cm, owner) {
+			continue
+		}
+
+		shard, err := strconv.Atoi(cm.Annotations[AnnotationResultShard])
+		if err != nil || shard < shards {
+			continue
+		}
+
+		if err := c.Delete(ctx, cm); err != nil && !api_errors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete stale discovery result shard %s: %w", cm.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// ReadResultIndex returns the result metadata stored on the index shard.
+// ConfigMaps written before sharding was introduced are treated as a single
+// shard with an unknown total.
+func ReadResultIndex(cm *corev1.ConfigMap) (ResultIndex, error) {
+	index := ResultIndex{Run: cm.Annotations[AnnotationResultRun], Shards: 1, Total: -1}
+
+	if value, ok := cm.Annotations[AnnotationResultShards]; ok {
+		shards, err := strconv.Atoi(value)
+		if err != nil || shards < 1 {
+			return ResultIndex{}, fmt.Errorf("%w: %s=%q", ErrInvalidResultMeta, AnnotationResultShards, value)
+		}
+
+		index.Shards = shards
+	}
+
+	if value, ok := cm.Annotations[AnnotationResultTotal]; ok {
+		total, err := strconv.Atoi(value)
+		if err != nil || total < 0 {
+			return ResultIndex{}, fmt.Errorf("%w: %s=%q", ErrInvalidResultMeta, AnnotationResultTotal, value)
+		}
+
+		index.Total = total
+	}
+
+	return index, nil
+}
+
+// ReadShard decodes the repositories of a shard. It returns ErrShardOutdated
+// if the shard does not belong to the run of the index.
+func ReadShard(cm *corev1.ConfigMap, index ResultIndex) ([]string, error) {
+	if cm.Annotations[AnnotationResultRun] != index.Run {
+		return nil, fmt.Errorf("%w: %s", ErrShardOutdated, cm.Name)
+	}
+
+	var repos []string
+	if err := json.Unmarshal([]byte(cm.Data[ResultDataKey]), &repos); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal discovery result shard %s: %w", cm.Name, err)
+	}
+
+	return repos, nil
+}
+
+// ReadShardIDs decodes the platform IDs of the repositories of a shard by
+// name. The map is empty if the shard does not hold IDs.
+func ReadShardIDs(cm *corev1.ConfigMap) (map[string]string, error) {
+	ids := make(map[string]string)
+
+	data, ok := cm.Data[ResultIDsDataKey]
+	if !ok {
+		return ids, nil
+	}
+
+	if err := json.Unmarshal([]byte(data), &ids); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal discovery result IDs of shard %s: %w", cm.Name, err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,200 @@
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("Result", func() {
+	Describe("ShardRepositories", func() {
+		It("should return a single empty shard for an empty list", func() {
+			shards, err := ShardRepositories(nil, DefaultMaxShardBytes)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shards).To(Equal([]string{"[]"}))
+		})
+
+		It("should split the repositories into shards within the size limit", func() {
+			repos := []string{"org/repo1", "org/repo2", "org/repo3", "org/repo4"}
+
+			shards, err := ShardRepositories(repos, 26)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shards).To(HaveLen(2))
+
+			var decoded []string
+
+			for _, shard := range shards {
+				Expect(len(shard)).To(BeNumerically("<=", 26))
+
+				var part []string
+				Expect(json.Unmarshal([]byte(shard), &part)).To(Succeed())
+
+				decoded = append(decoded, part...)
+			}
+
+			Expect(decoded).To(Equal(repos))
+		})
+
+		It("should keep a single repository exceeding the limit in its own shard", func() {
+			shards, err := ShardRepositories([]string{"a", "very/long-repository-name", "b"}, 8)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(shards).To(Equal([]string{`["a"]`, `["very/long-repository-name"]`, `["b"]`}))
+		})
+	})
+
+	Describe("WriteResult", func() {
+		var (
+			ctx        context.Context
+			fakeClient client.Client
+			owner      *corev1.Secret
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+
+			owner = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "owner", Namespace: "default", UID: "owner-uid"},
+			}
+			fakeClient = fake.NewClientBuilder().WithScheme(scheme).WithObjects(owner).Build()
+		})
+
+		readAll := func(baseName string) []string {
+			index := &corev1.ConfigMap{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: baseName}, index)).To(Succeed())
+
+			result, err := ReadResultIndex(index)
+			Expect(err).NotTo(HaveOccurred())
+
+			var repos []string
+
+			for i := range result.Shards {
+				name, err := ShardName(baseName, i)
+				Expect(err).NotTo(HaveOccurred())
+
+				cm := &corev1.ConfigMap{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, cm)).To(Succeed())
+				Expect(metav1.IsControlledBy(cm, owner)).To(BeTrue())
+
+				part, err := ReadShard(cm, result)
+				Expect(err).NotTo(HaveOccurred())
+
+				repos = append(repos, part...)
+			}
+
+			return repos
+		}
+
+		It("should write the shards and the index metadata", func() {
+			repos := []string{"org/repo1", "org/repo2", "org/repo3", "org/repo4"}
+
+			result, err := WriteResult(ctx, fakeClient, owner, "test-discovery", repos, nil, 26)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Shards).To(Equal(2))
+			Expect(result.Total).To(Equal(4))
+
+			index := &corev1.ConfigMap{}
+			Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: "test-discovery"}, index)).
+				To(Succeed())
+			Expect(index.Annotations).To(HaveKeyWithValue(AnnotationResultShards, "2"))
+			Expect(index.Annotations).To(HaveKeyWithValue(AnnotationResultTotal, "4"))
+
+			Expect(readAll("test-discovery")).To(Equal(repos))
+		})
+
+		It("should store the repository IDs alongside the repositories within the size limit", func() {
+			repos := []string{"org/repo1", "org/repo2", "org/repo3"}
+			ids := map[string]string{"org/repo1": "1", "org/repo2": "2", "org/repo3": "3"}
+
+			result, err := WriteResult(ctx, fakeClient, owner, "test-discovery", repos, ids, 40)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Shards).To(Equal(3))
+
+			decoded := make(map[string]string)
+
+			for i := range result.Shards {
+				name, err := ShardName("test-discovery", i)
+				Expect(err).NotTo(HaveOccurred())
+
+				cm := &corev1.ConfigMap{}
+				Expect(fakeClient.Get(ctx, client.ObjectKey{Namespace: "default", Name: name}, cm)).To(Succeed())
+				Expect(len(cm.Data[ResultDataKey]) + len(cm.Data[ResultIDsDataKey])).To(BeNumerically("<=", 40))
+
+				part, err := ReadShardIDs(cm)
+				Expect(err).NotTo(HaveOccurred())
+
+				maps.Copy(decoded, part)
+			}
+
+			Expect(decoded).To(Equal(ids))
+			Expect(readAll("test-discovery")).To(Equal(repos))
+		})
+
+		It("should delete shards left over from a larger previous result", func() {
+			_, err := WriteResult(ctx, fakeClient, owner, "test-discovery", []string{"a", "b", "c"}, nil, 6)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := WriteResult(ctx, fakeClient, owner, "test-discovery", []string{"a"}, nil, 6)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Shards).To(Equal(1))
+
+			cms := &corev1.ConfigMapList{}
+			Expect(fakeClient.List(ctx, cms)).To(Succeed())
+			Expect(cms.Items).To(HaveLen(1))
+			Expect(readAll("test-discovery")).To(Equal([]string{"a"}))
+		})
+	})
+
+	Describe("ReadResultIndex", func() {
+		It("should treat a ConfigMap without annotations as a single shard", func() {
+			result, err := ReadResultIndex(&corev1.ConfigMap{
+				Data: map[string]string{ResultDataKey: `["a","b"]`},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Shards).To(Equal(1))
+
+			repos, err := ReadShard(&corev1.ConfigMap{Data: map[string]string{ResultDataKey: `["a","b"]`}}, result)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repos).To(Equal([]string{"a", "b"}))
+		})
+
+		It("should reject invalid shard counts", func() {
+			_, err := ReadResultIndex(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{AnnotationResultShards: "zero"}},
+			})
+			Expect(err).To(MatchError(ErrInvalidResultMeta))
+		})
+	})
+
+	Describe("ReadShardIDs", func() {
+		It("should return no IDs for shards without IDs", func() {
+			ids, err := ReadShardIDs(&corev1.ConfigMap{Data: map[string]string{ResultDataKey: `["a"]`}})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ids).To(BeEmpty())
+		})
+	})
+
+	Describe("ReadShard", func() {
+		It("should reject shards of a different run", func() {
+			_, err := ReadShard(&corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-discovery-1",
+					Annotations: map[string]string{AnnotationResultRun: "old"},
+				},
+			}, ResultIndex{Run: "new", Shards: 2})
+			Expect(err).To(MatchError(ErrShardOutdated))
+		})
+	})
+})